@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"strings"
@@ -72,6 +73,18 @@ ERROR HANDLING:
 Remember: You are here to make the job search process smooth and efficient. Be proactive, accurate, and helpful.`
 )
 
+// bearerTransport adds the MCP server API key to every request
+type bearerTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.base.RoundTrip(req)
+}
+
 type Client struct {
 	mcpSession             *mcp.ClientSession
 	gemini                 *genai.Client
@@ -82,7 +95,7 @@ type Client struct {
 	sheetsID               string
 }
 
-func NewClient(ctx context.Context, mcpEndpoint, apiKey, model, sheetsID string) (*Client, error) {
+func NewClient(ctx context.Context, mcpEndpoint, mcpKey, apiKey, model, sheetsID string) (*Client, error) {
 	// Connect to MCP server
 	mcpClient := mcp.NewClient(&mcp.Implementation{
 		Name:    "project-ets-client",
@@ -91,9 +104,14 @@ func NewClient(ctx context.Context, mcpEndpoint, apiKey, model, sheetsID string)
 
 	fmt.Printf("Connecting to MCP server at: %s\n", mcpEndpoint)
 	
-	session, err := mcpClient.Connect(context.Background(), &mcp.StreamableClientTransport{
+	transport := &mcp.StreamableClientTransport{
 		Endpoint: mcpEndpoint,
-	}, nil)
+	}
+	if mcpKey != "" {
+		transport.HTTPClient = &http.Client{Transport: &bearerTransport{token: mcpKey, base: http.DefaultTransport}}
+	}
+
+	session, err := mcpClient.Connect(context.Background(), transport, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to MCP server at %s: %w", mcpEndpoint, err)
 	}
@@ -513,6 +531,8 @@ func main() {
 		mcpEndpoint = mcpEndpoint + "/mcp/stream"
 	}
 
+	mcpKey := os.Getenv("MCP_API_KEY")
+
 	apiKey := os.Getenv("GOOGLE_API_KEY")
 	if apiKey == "" {
 		apiKey = os.Getenv("GEMINI_API_KEY")
@@ -544,7 +564,7 @@ func main() {
 	}
 	fmt.Println(strings.Repeat("=", 80))
 
-	client, err := NewClient(ctx, mcpEndpoint, apiKey, model, sheetsID)
+	client, err := NewClient(ctx, mcpEndpoint, mcpKey, apiKey, model, sheetsID)
 	if err != nil {
 		log.Fatalf("Failed to create client: %v", err)
 	}
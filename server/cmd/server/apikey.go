@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+	"github.com/honeycarbs/project-ets/pkg/apikey"
+)
+
+// runAPIKey implements `server apikey`: it prints a new key once and the
+// entry to add to the AUTH_KEYS_PATH file, which stores only its hash
+func runAPIKey(args []string) error {
+	fs := flag.NewFlagSet("apikey", flag.ExitOnError)
+	name := fs.String("name", "", "key name, shown in logs")
+	scopes := fs.String("scopes", tools.ScopeRead, "comma-separated scopes: read, write, graph, admin")
+	expires := fs.Duration("expires", 0, "key lifetime such as 720h, 0 for no expiry")
+	_ = fs.Parse(args)
+
+	if strings.TrimSpace(*name) == "" {
+		return fmt.Errorf("-name is required")
+	}
+
+	entry := apikey.Key{Name: *name}
+	for _, scope := range strings.Split(*scopes, ",") {
+		scope = strings.TrimSpace(scope)
+		if !tools.ValidScope(scope) {
+			return fmt.Errorf("unknown scope %q (use read, write, graph or admin)", scope)
+		}
+		entry.Scopes = append(entry.Scopes, scope)
+	}
+	if *expires > 0 {
+		entry.ExpiresAt = time.Now().Add(*expires).UTC().Truncate(time.Second)
+	}
+
+	key, hash, err := apikey.Generate()
+	if err != nil {
+		return err
+	}
+	entry.Hash = hash
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "API key (shown once, give it to the client):\n")
+	fmt.Println(key)
+	fmt.Fprintf(os.Stderr, "\nAdd this entry to the \"keys\" list of the AUTH_KEYS_PATH file:\n")
+	fmt.Println(string(data))
+	return nil
+}
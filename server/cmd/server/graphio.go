@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/honeycarbs/project-ets/internal/config"
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/domain/graphio"
+	"github.com/honeycarbs/project-ets/internal/identity"
+	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+	"github.com/honeycarbs/project-ets/internal/repository"
+	storage "github.com/honeycarbs/project-ets/internal/storage/neo4j"
+	"github.com/honeycarbs/project-ets/pkg/neo4j"
+)
+
+// runExport implements `server export`
+func runExport(cfg config.Config, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	format := fs.String("format", graphio.FormatJSONL, "export format: graphml, cypher or jsonl")
+	out := fs.String("out", "-", "output file, - for stdout")
+	jobIDs := fs.String("job-ids", "", "comma-separated job IDs to export")
+	from := fs.String("from", "", "only jobs on or after this date (YYYY-MM-DD or RFC 3339)")
+	to := fs.String("to", "", "only jobs before this date (YYYY-MM-DD or RFC 3339)")
+	timeField := fs.String("time-field", repository.TimeFieldFetchedAt, "date used by -from/-to: fetchedAt or postedAt")
+	owner := fs.String("owner", "", "user ID whose keywords are exported; empty for keywords without an owner")
+	_ = fs.Parse(args)
+
+	filter := repository.ExportFilter{TimeField: *timeField}
+	if *jobIDs != "" {
+		for _, id := range strings.Split(*jobIDs, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				filter.JobIDs = append(filter.JobIDs, id)
+			}
+		}
+	}
+	var err error
+	if filter.From, err = tools.ParseExportDate(*from); err != nil {
+		return fmt.Errorf("invalid -from: %w", err)
+	}
+	if filter.To, err = tools.ParseExportDate(*to); err != nil {
+		return fmt.Errorf("invalid -to: %w", err)
+	}
+
+	ctx := ownerContext(*owner)
+	client, err := newNeo4jClient(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close(ctx) }()
+
+	var w io.Writer = os.Stdout
+	if *out != "-" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return fmt.Errorf("create output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	exporter := graphio.NewExporter(storage.NewExportRepository(client))
+	n, err := exporter.Export(ctx, w, *format, filter)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "exported %d jobs\n", n)
+	return nil
+}
+
+// runImport implements `server import`
+func runImport(cfg config.Config, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	format := fs.String("format", graphio.FormatJSONL, "import format: graphml or jsonl")
+	in := fs.String("in", "-", "input file, - for stdin")
+	owner := fs.String("owner", "", "user ID the imported keywords belong to; empty for no owner")
+	_ = fs.Parse(args)
+
+	var r io.Reader = os.Stdin
+	if *in != "-" {
+		f, err := os.Open(*in)
+		if err != nil {
+			return fmt.Errorf("open input file: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	ctx := ownerContext(*owner)
+	client, err := newNeo4jClient(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close(ctx) }()
+	if err := storage.EnsureCompanyAliases(ctx, client); err != nil {
+		return err
+	}
+
+	importer := graphio.NewImporter(storage.NewJobRepository(client), storage.NewKeywordRepository(client))
+	stats, err := importer.Import(ctx, r, *format)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "imported %d jobs and %d keywords\n", stats.Jobs, stats.Keywords)
+	return nil
+}
+
+// ownerContext acts as the given user, so keywords are read and written in
+// that user's scope like tool calls are
+func ownerContext(owner string) context.Context {
+	ctx := context.Background()
+	if owner = strings.TrimSpace(owner); owner != "" {
+		ctx = identity.NewContext(ctx, domain.User{ID: owner})
+	}
+	return ctx
+}
+
+func newNeo4jClient(cfg config.Config) (*neo4j.Client, error) {
+	return neo4j.NewClient(neo4j.Config{
+		URI:      cfg.Neo4j.URI,
+		Username: cfg.Neo4j.Username,
+		Password: cfg.Neo4j.Password,
+	})
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/honeycarbs/project-ets/internal/config"
+	storage "github.com/honeycarbs/project-ets/internal/storage/neo4j"
+)
+
+// runNormalizeKeywords implements `server normalize-keywords`: a one-off
+// migration that folds stored keywords onto their current canonical form
+func runNormalizeKeywords(cfg config.Config, _ []string) error {
+	ctx := context.Background()
+	client, err := newNeo4jClient(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close(ctx) }()
+
+	renamed, err := storage.NewKeywordRepository(client).NormalizeKeywords(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "normalized %d keywords\n", renamed)
+	return nil
+}
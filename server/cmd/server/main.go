@@ -14,11 +14,36 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "apikey" {
+		if err := runAPIKey(os.Args[2:]); err != nil {
+			log.Fatalf("apikey failed: %v", err)
+		}
+		return
+	}
+
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("failed to load config: %v", err)
 	}
 
+	if len(os.Args) > 1 {
+		var err error
+		switch os.Args[1] {
+		case "export":
+			err = runExport(cfg, os.Args[2:])
+		case "import":
+			err = runImport(cfg, os.Args[2:])
+		case "normalize-keywords":
+			err = runNormalizeKeywords(cfg, os.Args[2:])
+		default:
+			log.Fatalf("unknown command %q (available: export, import, normalize-keywords, apikey)", os.Args[1])
+		}
+		if err != nil {
+			log.Fatalf("%s failed: %v", os.Args[1], err)
+		}
+		return
+	}
+
 	logger := logging.New(cfg.LogLevel)
 	defer func() { _ = logger.Sync() }()
 
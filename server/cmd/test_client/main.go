@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 
 	mcp "github.com/modelcontextprotocol/go-sdk/mcp"
@@ -22,9 +23,14 @@ func main() {
 		Version: "0.1.0",
 	}, nil)
 
-	session, err := client.Connect(ctx, &mcp.StreamableClientTransport{
+	transport := &mcp.StreamableClientTransport{
 		Endpoint: "http://localhost:8080/mcp/stream",
-	}, nil)
+	}
+	if key := os.Getenv("MCP_API_KEY"); key != "" {
+		transport.HTTPClient = &http.Client{Transport: &bearerTransport{token: key, base: http.DefaultTransport}}
+	}
+
+	session, err := client.Connect(ctx, transport, nil)
 	if err != nil {
 		log.Fatalf("Failed to connect: %v", err)
 	}
@@ -37,6 +43,18 @@ func main() {
 	fmt.Println("\nAll tests completed")
 }
 
+// bearerTransport adds the API key to every request
+type bearerTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.base.RoundTrip(req)
+}
+
 func testJobSearch(ctx context.Context, session *mcp.ClientSession) {
 	fmt.Println("\nTEST: job_search")
 
@@ -3,7 +3,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Config contains runtime settings for the MCP server
@@ -24,6 +26,51 @@ type Config struct {
 	Sheets struct {
 		CredentialsPath string
 	}
+	GraphTool struct {
+		MaxRows   int           // default 200
+		MaxBytes  int           // default 64 KiB of formatted output
+		Timeout   time.Duration // default 10s
+		SchemaTTL time.Duration // default 10m; how long introspected schema is cached
+	} // Limits applied to ad-hoc graph_tool queries
+	SavedQueries struct {
+		Path string // optional JSON file with additional saved queries
+	}
+	Lifecycle struct {
+		CloseAfterMisses int // default 3; refreshes a job may be missing from before it is closed
+	}
+	Reminders struct {
+		FollowUpDays int // default 10; days without a status change after applying before a follow-up is due
+	}
+	SavedSearches struct {
+		Tick       time.Duration // default 1m; how often due searches are checked, 0 disables the scheduler
+		Jitter     time.Duration // default 5m; most a scheduled run is delayed, capped at a tenth of its interval
+		RunTimeout time.Duration // default 2m; bounds one run and its lease
+	}
+	Notify struct {
+		ConfigPath    string        // optional JSON file with notification channels
+		RetryAttempts int           // default 3; delivery attempts per digest
+		RetryBackoff  time.Duration // default 2s; first retry delay, doubled per attempt
+	}
+	Auth struct {
+		KeysPath string // JSON file with hashed API keys and their scopes
+		Disabled bool   // serve without authentication, for local development only
+	}
+	OAuth struct {
+		Issuer   string // authorization server whose JWT access tokens are accepted; empty disables OAuth
+		JWKSURL  string // signing keys; discovered from the issuer metadata when empty
+		Resource string // public URL of /mcp/stream, advertised in the protected resource metadata
+		Audience string // required aud claim; defaults to Resource
+	}
+	CORS struct {
+		AllowedOrigins []string // browser origins allowed to call the server; "*" allows any
+	}
+	RateLimit struct {
+		ConfigPath string // optional JSON file with tool call policies; built-in defaults otherwise
+		Disabled   bool   // serve tool calls without rate limits or quotas
+	}
+	Tools struct {
+		Timeout time.Duration // default 3m; bounds one tool call, 0 leaves calls unbounded
+	}
 }
 
 // Load populates config from environment variables
@@ -60,6 +107,162 @@ func Load() (Config, error) {
 
 	cfg.Sheets.CredentialsPath = os.Getenv("GOOGLE_SHEETS_CREDENTIALS_PATH")
 
+	cfg.SavedQueries.Path = os.Getenv("SAVED_QUERIES_PATH")
+
+	cfg.GraphTool.MaxRows = 200
+	cfg.GraphTool.MaxBytes = 64 * 1024
+	cfg.GraphTool.Timeout = 10 * time.Second
+	cfg.GraphTool.SchemaTTL = 10 * time.Minute
+
+	cfg.Lifecycle.CloseAfterMisses = 3
+
+	cfg.Reminders.FollowUpDays = 10
+
+	cfg.SavedSearches.Tick = time.Minute
+	cfg.SavedSearches.Jitter = 5 * time.Minute
+	cfg.SavedSearches.RunTimeout = 2 * time.Minute
+
+	cfg.Tools.Timeout = 3 * time.Minute
+
+	cfg.Notify.ConfigPath = os.Getenv("NOTIFY_CONFIG_PATH")
+
+	cfg.Auth.KeysPath = os.Getenv("AUTH_KEYS_PATH")
+	cfg.OAuth.Issuer = os.Getenv("OAUTH_ISSUER")
+	cfg.OAuth.JWKSURL = os.Getenv("OAUTH_JWKS_URL")
+	cfg.OAuth.Resource = os.Getenv("OAUTH_RESOURCE")
+	cfg.OAuth.Audience = os.Getenv("OAUTH_AUDIENCE")
+	if cfg.OAuth.Audience == "" {
+		cfg.OAuth.Audience = cfg.OAuth.Resource
+	}
+	if v := os.Getenv("CORS_ALLOWED_ORIGINS"); v != "" {
+		for _, origin := range strings.Split(v, ",") {
+			if origin = strings.TrimSpace(origin); origin != "" {
+				cfg.CORS.AllowedOrigins = append(cfg.CORS.AllowedOrigins, strings.TrimSuffix(origin, "/"))
+			}
+		}
+	}
+	cfg.RateLimit.ConfigPath = os.Getenv("RATE_LIMIT_CONFIG_PATH")
+	cfg.Notify.RetryAttempts = 3
+	cfg.Notify.RetryBackoff = 2 * time.Second
+
+	var invalidVars []string
+
+	if v := os.Getenv("GRAPH_TOOL_MAX_ROWS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.GraphTool.MaxRows = n
+		} else {
+			invalidVars = append(invalidVars, "GRAPH_TOOL_MAX_ROWS")
+		}
+	}
+
+	if v := os.Getenv("GRAPH_TOOL_MAX_BYTES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.GraphTool.MaxBytes = n
+		} else {
+			invalidVars = append(invalidVars, "GRAPH_TOOL_MAX_BYTES")
+		}
+	}
+
+	if v := os.Getenv("GRAPH_TOOL_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.GraphTool.Timeout = d
+		} else {
+			invalidVars = append(invalidVars, "GRAPH_TOOL_TIMEOUT")
+		}
+	}
+
+	if v := os.Getenv("GRAPH_SCHEMA_TTL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			cfg.GraphTool.SchemaTTL = d
+		} else {
+			invalidVars = append(invalidVars, "GRAPH_SCHEMA_TTL")
+		}
+	}
+
+	if v := os.Getenv("LIFECYCLE_CLOSE_AFTER_MISSES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.Lifecycle.CloseAfterMisses = n
+		} else {
+			invalidVars = append(invalidVars, "LIFECYCLE_CLOSE_AFTER_MISSES")
+		}
+	}
+
+	if v := os.Getenv("REMINDER_FOLLOW_UP_DAYS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.Reminders.FollowUpDays = n
+		} else {
+			invalidVars = append(invalidVars, "REMINDER_FOLLOW_UP_DAYS")
+		}
+	}
+
+	if v := os.Getenv("SAVED_SEARCH_TICK"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			cfg.SavedSearches.Tick = d
+		} else {
+			invalidVars = append(invalidVars, "SAVED_SEARCH_TICK")
+		}
+	}
+
+	if v := os.Getenv("SAVED_SEARCH_JITTER"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			cfg.SavedSearches.Jitter = d
+		} else {
+			invalidVars = append(invalidVars, "SAVED_SEARCH_JITTER")
+		}
+	}
+
+	if v := os.Getenv("SAVED_SEARCH_RUN_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.SavedSearches.RunTimeout = d
+		} else {
+			invalidVars = append(invalidVars, "SAVED_SEARCH_RUN_TIMEOUT")
+		}
+	}
+
+	if v := os.Getenv("NOTIFY_RETRY_ATTEMPTS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.Notify.RetryAttempts = n
+		} else {
+			invalidVars = append(invalidVars, "NOTIFY_RETRY_ATTEMPTS")
+		}
+	}
+
+	if v := os.Getenv("NOTIFY_RETRY_BACKOFF"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			cfg.Notify.RetryBackoff = d
+		} else {
+			invalidVars = append(invalidVars, "NOTIFY_RETRY_BACKOFF")
+		}
+	}
+
+	if v := os.Getenv("AUTH_DISABLED"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.Auth.Disabled = b
+		} else {
+			invalidVars = append(invalidVars, "AUTH_DISABLED")
+		}
+	}
+
+	if v := os.Getenv("RATE_LIMIT_DISABLED"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.RateLimit.Disabled = b
+		} else {
+			invalidVars = append(invalidVars, "RATE_LIMIT_DISABLED")
+		}
+	}
+
+	if v := os.Getenv("TOOL_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			cfg.Tools.Timeout = d
+		} else {
+			invalidVars = append(invalidVars, "TOOL_TIMEOUT")
+		}
+	}
+
+	if len(invalidVars) > 0 {
+		return cfg, fmt.Errorf("invalid environment variables: %s", strings.Join(invalidVars, ", "))
+	}
+
 	var missingVars []string
 
 	if cfg.Neo4j.URI == "" {
@@ -74,6 +277,10 @@ func Load() (Config, error) {
 		missingVars = append(missingVars, "NEO4J_PASSWORD")
 	}
 
+	if cfg.OAuth.Issuer != "" && cfg.OAuth.Resource == "" {
+		missingVars = append(missingVars, "OAUTH_RESOURCE")
+	}
+
 	if len(missingVars) > 0 {
 		return cfg, fmt.Errorf("missing required environment variables: %s", strings.Join(missingVars, ", "))
 	}
@@ -0,0 +1,156 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+)
+
+const (
+	defaultGapLimit = 25
+
+	// defaultKeywordConfidence applies to keywords persisted without a confidence
+	defaultKeywordConfidence = 1.0
+
+	// maxGapSuggestions bounds how many missing terms get a tailoring suggestion
+	maxGapSuggestions = 5
+)
+
+// KeywordGapReport aggregates stored keywords across jobs and reports which the profile lacks
+func (s *Service) KeywordGapReport(ctx context.Context, params tools.KeywordGapParams) (tools.KeywordGapResult, error) {
+	if len(params.JobIDs) == 0 {
+		return tools.KeywordGapResult{}, fmt.Errorf("job_ids are required")
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultGapLimit
+	}
+
+	profile, err := s.resolveProfile(ctx, tools.JobAnalysisParams{
+		CandidateID: params.CandidateID,
+		Profile:     params.Profile,
+	})
+	if err != nil {
+		return tools.KeywordGapResult{}, err
+	}
+	if profile.Empty() {
+		return tools.KeywordGapResult{}, tools.ErrNoProfile
+	}
+
+	subgraphs, err := s.repo.GetJobSubgraphs(ctx, params.JobIDs)
+	if err != nil {
+		return tools.KeywordGapResult{}, err
+	}
+
+	type aggregate struct {
+		weight float64
+		jobs   []string
+	}
+	terms := make(map[string]*aggregate)
+	for _, sg := range subgraphs {
+		jobID := sg.Job.ID.String()
+		seen := make(map[string]bool, len(sg.Keywords))
+		for _, kw := range sg.Keywords {
+			term := domain.NormalizeKeyword(kw.Value)
+			if term == "" || seen[term] {
+				continue
+			}
+			seen[term] = true
+
+			confidence := kw.Confidence
+			if confidence <= 0 {
+				confidence = defaultKeywordConfidence
+			}
+
+			agg, ok := terms[term]
+			if !ok {
+				agg = &aggregate{}
+				terms[term] = agg
+			}
+			agg.weight += confidence
+			agg.jobs = append(agg.jobs, jobID)
+		}
+	}
+
+	result := tools.KeywordGapResult{
+		JobCount:    len(subgraphs),
+		Missing:     []tools.KeywordGapTerm{},
+		Covered:     []tools.KeywordGapTerm{},
+		GeneratedAt: time.Now().UTC(),
+	}
+
+	var totalWeight, coveredWeight float64
+	for term, agg := range terms {
+		entry := tools.KeywordGapTerm{
+			Term:          term,
+			Weight:        agg.weight,
+			Frequency:     len(agg.jobs),
+			AvgConfidence: agg.weight / float64(len(agg.jobs)),
+			JobIDs:        agg.jobs,
+		}
+		totalWeight += agg.weight
+		if profile.Has(term) {
+			coveredWeight += agg.weight
+			result.Covered = append(result.Covered, entry)
+		} else {
+			result.Missing = append(result.Missing, entry)
+		}
+	}
+
+	if totalWeight > 0 {
+		result.Coverage = coveredWeight / totalWeight
+	}
+
+	prioritizeGapTerms(result.Missing)
+	prioritizeGapTerms(result.Covered)
+	if len(result.Missing) > limit {
+		result.Missing = result.Missing[:limit]
+	}
+	result.Suggestions = gapSuggestions(result.Missing, result.JobCount)
+
+	return result, nil
+}
+
+func prioritizeGapTerms(terms []tools.KeywordGapTerm) {
+	sort.Slice(terms, func(i, j int) bool {
+		if terms[i].Weight != terms[j].Weight {
+			return terms[i].Weight > terms[j].Weight
+		}
+		if terms[i].Frequency != terms[j].Frequency {
+			return terms[i].Frequency > terms[j].Frequency
+		}
+		return terms[i].Term < terms[j].Term
+	})
+	for i := range terms {
+		terms[i].Priority = i + 1
+	}
+}
+
+// gapSuggestions turns the top missing terms into resume tailoring hints:
+// terms most of the batch asks for belong in the general resume, the rest
+// only in applications to the jobs that demand them
+func gapSuggestions(missing []tools.KeywordGapTerm, jobCount int) []string {
+	n := len(missing)
+	if n > maxGapSuggestions {
+		n = maxGapSuggestions
+	}
+
+	suggestions := make([]string, 0, n)
+	for _, term := range missing[:n] {
+		if term.Frequency*2 >= jobCount {
+			suggestions = append(suggestions, fmt.Sprintf(
+				"%q is demanded by %d of %d jobs: if you have this experience, add it to your skills section and to an experience bullet that shows it",
+				term.Term, term.Frequency, jobCount))
+		} else {
+			suggestions = append(suggestions, fmt.Sprintf(
+				"%q is specific to %d job(s): mention it only when tailoring the resume for %v",
+				term.Term, term.Frequency, term.JobIDs))
+		}
+	}
+	return suggestions
+}
@@ -0,0 +1,51 @@
+package analysis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+	"github.com/honeycarbs/project-ets/internal/repository"
+)
+
+// subgraphRepo serves fixed job subgraphs; the other queries are unused
+type subgraphRepo struct {
+	repository.AnalysisRepository
+	subgraphs []repository.JobSubgraph
+}
+
+func (r subgraphRepo) GetJobSubgraphs(context.Context, []string) ([]repository.JobSubgraph, error) {
+	return r.subgraphs, nil
+}
+
+func TestKeywordGapReportNeedsProfileTerms(t *testing.T) {
+	jobID := uuid.New()
+	svc := NewService(subgraphRepo{subgraphs: []repository.JobSubgraph{{
+		Job:      domain.Job{ID: jobID},
+		Keywords: []repository.KeywordNode{{Value: "go"}, {Value: "kubernetes"}},
+	}}}, nil, nil, nil)
+
+	// Punctuation passes the tool's blank check but yields no terms, which
+	// would report every keyword as missing
+	_, err := svc.KeywordGapReport(context.Background(), tools.KeywordGapParams{
+		JobIDs:  []string{jobID.String()},
+		Profile: "--- !!!",
+	})
+	if !tools.IsInvalidParams(err) {
+		t.Fatalf("empty profile error = %v, want invalid params", err)
+	}
+
+	result, err := svc.KeywordGapReport(context.Background(), tools.KeywordGapParams{
+		JobIDs:  []string{jobID.String()},
+		Profile: "Skills: Go",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Covered) != 1 || result.Covered[0].Term != "go" || len(result.Missing) != 1 {
+		t.Errorf("covered %v, missing %v", result.Covered, result.Missing)
+	}
+}
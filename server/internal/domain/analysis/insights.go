@@ -0,0 +1,119 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+	"github.com/honeycarbs/project-ets/internal/repository"
+)
+
+const (
+	defaultInsightWindowDays = 30
+	defaultInsightLimit      = 20
+)
+
+// SkillInsights returns skill co-occurrences and windowed skill/keyword trends
+func (s *Service) SkillInsights(ctx context.Context, params tools.SkillInsightsParams) (tools.SkillInsightsResult, error) {
+	windowDays := params.WindowDays
+	if windowDays <= 0 {
+		windowDays = defaultInsightWindowDays
+	}
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultInsightLimit
+	}
+
+	var timeField, fieldName string
+	switch strings.ToLower(params.TimeField) {
+	case "", "fetched_at", "fetchedat":
+		timeField, fieldName = repository.TimeFieldFetchedAt, "fetched_at"
+	case "posted_at", "postedat":
+		timeField, fieldName = repository.TimeFieldPostedAt, "posted_at"
+	default:
+		return tools.SkillInsightsResult{}, fmt.Errorf("unsupported time_field %q (use fetched_at or posted_at)", params.TimeField)
+	}
+
+	var orderBy string
+	switch strings.ToLower(params.SortBy) {
+	case "", "count":
+		orderBy = repository.TrendOrderCount
+	case "delta":
+		orderBy = repository.TrendOrderDelta
+	default:
+		return tools.SkillInsightsResult{}, fmt.Errorf("unsupported sort_by %q (use count or delta)", params.SortBy)
+	}
+
+	now := time.Now().UTC()
+	window := time.Duration(windowDays) * 24 * time.Hour
+	q := repository.TrendQuery{
+		TimeField: timeField,
+		Current:   repository.TimeWindow{From: now.Add(-window), To: now},
+		Previous:  repository.TimeWindow{From: now.Add(-2 * window), To: now.Add(-window)},
+		OrderBy:   orderBy,
+		Limit:     limit,
+	}
+
+	result := tools.SkillInsightsResult{
+		TimeField:      fieldName,
+		CurrentWindow:  tools.InsightWindow{From: q.Current.From, To: q.Current.To},
+		PreviousWindow: tools.InsightWindow{From: q.Previous.From, To: q.Previous.To},
+		GeneratedAt:    now,
+	}
+
+	if len(params.Skills) > 0 {
+		skills := make([]string, 0, len(params.Skills))
+		for _, sk := range params.Skills {
+			if sk = strings.ToLower(strings.TrimSpace(sk)); sk != "" {
+				skills = append(skills, sk)
+			}
+		}
+
+		cooccurrences, err := s.repo.GetSkillCooccurrences(ctx, skills, limit)
+		if err != nil {
+			return tools.SkillInsightsResult{}, err
+		}
+		result.Cooccurrences = make([]tools.SkillCooccurrenceEntry, 0, len(cooccurrences))
+		for _, c := range cooccurrences {
+			result.Cooccurrences = append(result.Cooccurrences, tools.SkillCooccurrenceEntry{
+				Skill:      c.Skill,
+				Cooccurs:   c.Cooccurs,
+				CommonWith: c.CommonWith,
+			})
+		}
+	}
+
+	skillTrends, err := s.repo.GetSkillTrends(ctx, q)
+	if err != nil {
+		return tools.SkillInsightsResult{}, err
+	}
+	result.SkillTrends = toTrendEntries(skillTrends)
+
+	keywordTrends, err := s.repo.GetKeywordTrends(ctx, q)
+	if err != nil {
+		return tools.SkillInsightsResult{}, err
+	}
+	result.KeywordTrends = toTrendEntries(keywordTrends)
+
+	return result, nil
+}
+
+func toTrendEntries(trends []repository.TermTrend) []tools.TermTrendEntry {
+	entries := make([]tools.TermTrendEntry, 0, len(trends))
+	for _, tr := range trends {
+		entry := tools.TermTrendEntry{
+			Term:     tr.Term,
+			Current:  tr.Current,
+			Previous: tr.Previous,
+			Delta:    tr.Current - tr.Previous,
+		}
+		if tr.Previous > 0 {
+			change := float64(entry.Delta) / float64(tr.Previous)
+			entry.Change = &change
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
@@ -0,0 +1,191 @@
+package analysis
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+	"github.com/honeycarbs/project-ets/internal/repository"
+)
+
+const (
+	// maxPhraseTokens bounds the n-grams extracted from a profile; longer
+	// keywords are rare and would only be matched by exact phrase anyway
+	maxPhraseTokens = 4
+
+	skillMatchWeight   = 2.0
+	keywordMatchWeight = 1.0
+)
+
+// skillLinePrefixes mark profile lines that enumerate skills explicitly
+var skillLinePrefixes = []string{"skills", "technical skills", "technologies", "tech stack", "tools", "languages"}
+
+// Profile is a candidate profile parsed into comparable terms
+type Profile struct {
+	// Skills are terms the candidate lists explicitly on a skills line
+	Skills []string
+	// phrases holds the skills and every tokenized 1..maxPhraseTokens-gram
+	// of the profile text
+	phrases map[string]bool
+}
+
+// ParseProfile tokenizes free-form resume text the same way stored keywords
+// are; terms listed on a skills line are normalized whole, aliases included
+func ParseProfile(text string) Profile {
+	p := Profile{phrases: make(map[string]bool)}
+	if strings.TrimSpace(text) == "" {
+		return p
+	}
+
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(text, "\n") {
+		label, list, ok := strings.Cut(line, ":")
+		if !ok || !isSkillLabel(label) {
+			continue
+		}
+		for _, item := range strings.FieldsFunc(list, func(r rune) bool {
+			return r == ',' || r == ';' || r == '|' || r == '•'
+		}) {
+			skill := domain.NormalizeKeyword(item)
+			if skill == "" || seen[skill] {
+				continue
+			}
+			seen[skill] = true
+			p.Skills = append(p.Skills, skill)
+			p.phrases[skill] = true
+		}
+	}
+
+	// Prose is not folded through aliases: "node" in a sentence is not
+	// necessarily node.js, while a skills line lists whole terms
+	tokens := domain.KeywordTokens(text)
+	for i := range tokens {
+		for n := 1; n <= maxPhraseTokens && i+n <= len(tokens); n++ {
+			p.phrases[strings.Join(tokens[i:i+n], " ")] = true
+		}
+	}
+
+	return p
+}
+
+// CandidateProfile builds a Profile from a stored candidate: listed skills
+// count as declared skills and the summary and experience supply phrases
+func CandidateProfile(c domain.Candidate) Profile {
+	var text strings.Builder
+	text.WriteString(c.Headline + "\n" + c.Summary + "\n")
+	for _, e := range c.Experience {
+		text.WriteString(e.Title + "\n" + e.Description + "\n")
+		if len(e.Skills) > 0 {
+			text.WriteString("skills: " + strings.Join(e.Skills, ", ") + "\n")
+		}
+	}
+	if len(c.Skills) > 0 {
+		names := make([]string, 0, len(c.Skills))
+		for _, s := range c.Skills {
+			names = append(names, s.Name)
+		}
+		text.WriteString("skills: " + strings.Join(names, ", ") + "\n")
+	}
+
+	return ParseProfile(text.String())
+}
+
+// Empty reports whether the profile contributed no terms
+func (p Profile) Empty() bool {
+	return len(p.phrases) == 0
+}
+
+// Has reports whether the profile mentions an already normalized term
+func (p Profile) Has(term string) bool {
+	return p.phrases[term]
+}
+
+func isSkillLabel(label string) bool {
+	label = strings.ToLower(strings.TrimSpace(strings.TrimLeft(label, "#*-• ")))
+	for _, prefix := range skillLinePrefixes {
+		if label == prefix {
+			return true
+		}
+	}
+	return false
+}
+
+// matchJob computes keyword coverage of a job subgraph by a profile. Skills
+// carry more weight than agent-extracted keywords because they come from the
+// posting itself; a term that is both is counted once as a skill
+func matchJob(profile Profile, sg repository.JobSubgraph) *tools.JobMatch {
+	weights := make(map[string]float64)
+	var skillTotal, skillHit, keywordTotal, keywordHit int
+
+	for _, skill := range sg.Job.Skills {
+		term := domain.NormalizeKeyword(skill.Name)
+		if _, exists := weights[term]; term == "" || exists {
+			continue
+		}
+		weights[term] = skillMatchWeight
+		skillTotal++
+		if profile.Has(term) {
+			skillHit++
+		}
+	}
+	for _, kw := range sg.Keywords {
+		term := domain.NormalizeKeyword(kw.Value)
+		if _, exists := weights[term]; term == "" || exists {
+			continue
+		}
+		weights[term] = keywordMatchWeight
+		keywordTotal++
+		if profile.Has(term) {
+			keywordHit++
+		}
+	}
+
+	terms := make([]string, 0, len(weights))
+	for term := range weights {
+		terms = append(terms, term)
+	}
+	// Heavier terms first so the most important gaps lead the list
+	sort.Slice(terms, func(i, j int) bool {
+		if weights[terms[i]] != weights[terms[j]] {
+			return weights[terms[i]] > weights[terms[j]]
+		}
+		return terms[i] < terms[j]
+	})
+
+	match := &tools.JobMatch{
+		MatchedKeywords: []string{},
+		MissingKeywords: []string{},
+	}
+	var total, hit float64
+	for _, term := range terms {
+		total += weights[term]
+		if profile.Has(term) {
+			hit += weights[term]
+			match.MatchedKeywords = append(match.MatchedKeywords, term)
+		} else {
+			match.MissingKeywords = append(match.MissingKeywords, term)
+		}
+	}
+
+	if total > 0 {
+		match.Score = hit / total
+	}
+	if skillTotal > 0 {
+		match.SkillCoverage = float64(skillHit) / float64(skillTotal)
+	}
+	if keywordTotal > 0 {
+		match.KeywordCoverage = float64(keywordHit) / float64(keywordTotal)
+	}
+	return match
+}
+
+// rankSummaries orders summaries by match score and assigns 1-based ranks
+func rankSummaries(summaries []tools.JobAnalysisSummary) {
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].Match.Score > summaries[j].Match.Score
+	})
+	for i := range summaries {
+		summaries[i].Match.Rank = i + 1
+	}
+}
@@ -0,0 +1,63 @@
+package analysis
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/repository"
+)
+
+func TestProfileHas(t *testing.T) {
+	profile := ParseProfile("Backend engineer. Wrote a graph node scheduler and AI tooling.\n" +
+		"Skills: Golang, K8s, Postgres, Machine Learning\n")
+
+	cases := []struct {
+		term string
+		want bool
+	}{
+		// Skills lines are normalized whole, aliases included
+		{"go", true},
+		{"kubernetes", true},
+		{"postgresql", true},
+		{"machine learning", true},
+		// Prose is matched as written
+		{"graph node", true},
+		{"backend engineer", true},
+		{"node.js", false},
+		{"artificial intelligence", false},
+		{"ai", true},
+	}
+	for _, tc := range cases {
+		if got := profile.Has(tc.term); got != tc.want {
+			t.Errorf("Has(%q) = %v, want %v", tc.term, got, tc.want)
+		}
+	}
+}
+
+func TestMatchJob(t *testing.T) {
+	profile := ParseProfile("Skills: Go, Kubernetes\nShipped node.js services")
+	sg := repository.JobSubgraph{
+		Job: domain.Job{Skills: []domain.SkillRef{{Name: "Golang"}, {Name: "Rust"}}},
+		Keywords: []repository.KeywordNode{
+			{Value: "k8s"},
+			{Value: "Node"},
+			{Value: "go"}, // already counted as a skill
+		},
+	}
+
+	match := matchJob(profile, sg)
+	if want := []string{"go", "kubernetes", "node.js"}; !slices.Equal(match.MatchedKeywords, want) {
+		t.Errorf("matched = %q, want %q", match.MatchedKeywords, want)
+	}
+	if want := []string{"rust"}; !slices.Equal(match.MissingKeywords, want) {
+		t.Errorf("missing = %q, want %q", match.MissingKeywords, want)
+	}
+	if match.SkillCoverage != 0.5 || match.KeywordCoverage != 1 {
+		t.Errorf("coverage = %v skills, %v keywords, want 0.5 and 1", match.SkillCoverage, match.KeywordCoverage)
+	}
+	// go and rust weigh 2 as skills, kubernetes and node.js 1 as keywords
+	if want := 4.0 / 6.0; match.Score != want {
+		t.Errorf("score = %v, want %v", match.Score, want)
+	}
+}
@@ -0,0 +1,138 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+	"github.com/honeycarbs/project-ets/internal/repository"
+)
+
+const (
+	defaultRelatedLimit = 10
+
+	// relatedCandidateFactor widens the graph lookup so title similarity can
+	// reorder candidates before the final limit is applied
+	relatedCandidateFactor = 5
+
+	sharedSkillWeight   = 2.0
+	sharedKeywordWeight = 1.0
+	sameCompanyWeight   = 1.5
+	titleWeight         = 3.0
+)
+
+// titleStopwords are seniority and filler tokens that say little about the role itself
+var titleStopwords = map[string]bool{
+	"senior": true, "sr": true, "junior": true, "jr": true, "lead": true,
+	"staff": true, "principal": true, "i": true, "ii": true, "iii": true,
+	"iv": true, "and": true, "of": true, "the": true, "a": true, "an": true,
+	"to": true, "for": true, "in": true, "at": true, "with": true,
+}
+
+// RelatedJobs ranks stored jobs related to params.JobID
+func (s *Service) RelatedJobs(ctx context.Context, params tools.RelatedJobsParams) (tools.RelatedJobsResult, error) {
+	if params.JobID == "" {
+		return tools.RelatedJobsResult{}, fmt.Errorf("job_id is required")
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultRelatedLimit
+	}
+
+	source, err := s.repo.GetJobSubgraphs(ctx, []string{params.JobID})
+	if err != nil {
+		return tools.RelatedJobsResult{}, err
+	}
+	if len(source) == 0 {
+		return tools.RelatedJobsResult{}, fmt.Errorf("job %s not found", params.JobID)
+	}
+	sourceJob := source[0].Job
+
+	candidates, err := s.repo.FindRelatedJobs(ctx, params.JobID, limit*relatedCandidateFactor)
+	if err != nil {
+		return tools.RelatedJobsResult{}, err
+	}
+
+	sourceTokens := titleTokens(sourceJob.Title)
+	entries := make([]tools.RelatedJobEntry, 0, len(candidates))
+	for _, c := range candidates {
+		similarity := jaccard(sourceTokens, titleTokens(c.Job.Title))
+		relevance := relatedRelevance(c, similarity)
+		if relevance < params.MinRelevance {
+			continue
+		}
+
+		entries = append(entries, tools.RelatedJobEntry{
+			JobID:           c.Job.ID.String(),
+			Title:           c.Job.Title,
+			Company:         c.Job.Company.Name,
+			Location:        c.Job.Location,
+			URL:             c.Job.URL,
+			SharedSkills:    c.SharedSkills,
+			SharedKeywords:  c.SharedKeywords,
+			SameCompany:     c.SameCompany,
+			TitleSimilarity: similarity,
+			Relevance:       relevance,
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Relevance > entries[j].Relevance
+	})
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	return tools.RelatedJobsResult{
+		JobID:       sourceJob.ID.String(),
+		Title:       sourceJob.Title,
+		Related:     entries,
+		GeneratedAt: time.Now().UTC(),
+	}, nil
+}
+
+func relatedRelevance(c repository.RelatedJob, titleSimilarity float64) float64 {
+	score := float64(len(c.SharedSkills))*sharedSkillWeight +
+		float64(len(c.SharedKeywords))*sharedKeywordWeight +
+		titleSimilarity*titleWeight
+	if c.SameCompany {
+		score += sameCompanyWeight
+	}
+	return score
+}
+
+// titleTokens splits a job title into lowercase tokens without seniority noise
+func titleTokens(title string) map[string]bool {
+	fields := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	})
+
+	tokens := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		if titleStopwords[f] {
+			continue
+		}
+		tokens[f] = true
+	}
+	return tokens
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for t := range a {
+		if b[t] {
+			shared++
+		}
+	}
+	union := len(a) + len(b) - shared
+	return float64(shared) / float64(union)
+}
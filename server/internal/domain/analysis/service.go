@@ -2,20 +2,29 @@ package analysis
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/honeycarbs/project-ets/internal/mcp/tools"
 	"github.com/honeycarbs/project-ets/internal/repository"
 )
 
+// maxCompanyContacts caps the contacts listed per company in supporting data
+const maxCompanyContacts = 10
+
 // Service retrieves graph context for job analysis
 type Service struct {
-	repo repository.AnalysisRepository
+	repo       repository.AnalysisRepository
+	candidates repository.CandidateRepository
+	ghosts     tools.GhostService
+	contacts   repository.ContactRepository
 }
 
-// NewService creates an analysis service
-func NewService(repo repository.AnalysisRepository) *Service {
-	return &Service{repo: repo}
+// NewService creates an analysis service; ghosts and contacts may be nil to
+// skip ghost scoring and known contacts
+func NewService(repo repository.AnalysisRepository, candidates repository.CandidateRepository, ghosts tools.GhostService, contacts repository.ContactRepository) *Service {
+	return &Service{repo: repo, candidates: candidates, ghosts: ghosts, contacts: contacts}
 }
 
 // Analyze retrieves job subgraphs and related context from the graph
@@ -25,18 +34,130 @@ func (s *Service) Analyze(ctx context.Context, params tools.JobAnalysisParams) (
 		return tools.JobAnalysisResult{}, err
 	}
 
+	profile, err := s.resolveProfile(ctx, params)
+	if err != nil {
+		return tools.JobAnalysisResult{}, err
+	}
+
 	summaries := make([]tools.JobAnalysisSummary, 0, len(subgraphs))
+	skippedClosed := 0
+	companyIDs := make(map[string]string)
 	for _, sg := range subgraphs {
-		summaries = append(summaries, s.buildSummary(sg, params.Profile, params.Focus))
+		if sg.Job.Closed() && !params.IncludeClosed {
+			skippedClosed++
+			continue
+		}
+		summary := s.buildSummary(sg, params.Focus)
+		if !profile.Empty() {
+			summary.Match = matchJob(profile, sg)
+		}
+		summaries = append(summaries, summary)
+		companyIDs[summary.JobID] = sg.Job.Company.ID
 	}
 
-	return tools.JobAnalysisResult{
+	result := tools.JobAnalysisResult{
 		Jobs:        summaries,
 		GeneratedAt: time.Now().UTC(),
-	}, nil
+	}
+	if !profile.Empty() {
+		rankSummaries(result.Jobs)
+		result.ProfileSkills = profile.Skills
+	}
+
+	var notes []string
+	if skippedClosed > 0 {
+		notes = append(notes, fmt.Sprintf("skipped %d closed job(s); pass include_closed to analyze them", skippedClosed))
+	}
+	if err := s.attachGhostScores(ctx, result.Jobs); err != nil {
+		notes = append(notes, fmt.Sprintf("ghost scores unavailable: %v", err))
+	}
+	if err := s.attachContacts(ctx, result.Jobs, companyIDs); err != nil {
+		notes = append(notes, fmt.Sprintf("contacts unavailable: %v", err))
+	}
+	result.Notes = strings.Join(notes, "; ")
+
+	return result, nil
+}
+
+func (s *Service) attachGhostScores(ctx context.Context, summaries []tools.JobAnalysisSummary) error {
+	if s.ghosts == nil || len(summaries) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(summaries))
+	for _, summary := range summaries {
+		ids = append(ids, summary.JobID)
+	}
+
+	assessments, err := s.ghosts.Assess(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for i := range summaries {
+		if a, ok := assessments[summaries[i].JobID]; ok {
+			summaries[i].Ghost = &a
+		}
+	}
+	return nil
+}
+
+// attachContacts adds the known contacts at each job's company to its
+// supporting data
+func (s *Service) attachContacts(ctx context.Context, summaries []tools.JobAnalysisSummary, companyIDs map[string]string) error {
+	if s.contacts == nil || len(summaries) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	ids := make([]string, 0, len(companyIDs))
+	for _, id := range companyIDs {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	contacts, err := s.contacts.SearchContacts(ctx, repository.ContactFilter{CompanyIDs: ids, Limit: maxCompanyContacts * len(ids)})
+	if err != nil {
+		return err
+	}
+
+	byCompany := make(map[string][]map[string]any)
+	for _, c := range contacts {
+		if len(byCompany[c.Company.ID]) >= maxCompanyContacts {
+			continue
+		}
+		entry := map[string]any{"id": c.ID, "name": c.Name}
+		for key, value := range map[string]string{
+			"role":         c.Role,
+			"email":        c.Email,
+			"linkedin_url": c.LinkedInURL,
+			"notes":        c.Notes,
+		} {
+			if value != "" {
+				entry[key] = value
+			}
+		}
+		byCompany[c.Company.ID] = append(byCompany[c.Company.ID], entry)
+	}
+
+	for i := range summaries {
+		known := byCompany[companyIDs[summaries[i].JobID]]
+		if len(known) == 0 {
+			continue
+		}
+		if summaries[i].SupportingData == nil {
+			summaries[i].SupportingData = map[string]any{}
+		}
+		summaries[i].SupportingData["contacts"] = known
+	}
+	return nil
 }
 
-func (s *Service) buildSummary(sg repository.JobSubgraph, profile, focus string) tools.JobAnalysisSummary {
+func (s *Service) buildSummary(sg repository.JobSubgraph, focus string) tools.JobAnalysisSummary {
 	skills := make([]string, 0, len(sg.Job.Skills))
 	for _, skill := range sg.Job.Skills {
 		skills = append(skills, skill.Name)
@@ -45,8 +166,9 @@ func (s *Service) buildSummary(sg repository.JobSubgraph, profile, focus string)
 	keywords := make([]tools.KeywordEntry, 0, len(sg.Keywords))
 	for _, kw := range sg.Keywords {
 		keywords = append(keywords, tools.KeywordEntry{
-			Value: kw.Value,
-			Notes: kw.Source,
+			Value:      kw.Value,
+			Confidence: kw.Confidence,
+			Notes:      kw.Source,
 		})
 	}
 
@@ -62,8 +184,28 @@ func (s *Service) buildSummary(sg repository.JobSubgraph, profile, focus string)
 			"url":         sg.Job.URL,
 			"description": sg.Job.Description,
 			"skills":      skills,
-			"profile":     profile,
 			"focus":       focus,
 		},
 	}
 }
+
+// resolveProfile prefers a stored candidate over free-form profile text
+func (s *Service) resolveProfile(ctx context.Context, params tools.JobAnalysisParams) (Profile, error) {
+	if params.CandidateID == "" {
+		return ParseProfile(params.Profile), nil
+	}
+
+	if s.candidates == nil {
+		return Profile{}, fmt.Errorf("candidate profiles are not configured")
+	}
+
+	candidate, found, err := s.candidates.GetCandidate(ctx, params.CandidateID)
+	if err != nil {
+		return Profile{}, err
+	}
+	if !found {
+		return Profile{}, fmt.Errorf("candidate %s not found", params.CandidateID)
+	}
+
+	return CandidateProfile(candidate), nil
+}
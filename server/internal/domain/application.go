@@ -0,0 +1,87 @@
+package domain
+
+import "time"
+
+// Application pipeline statuses
+const (
+	ApplicationSaved        = "saved"
+	ApplicationApplied      = "applied"
+	ApplicationScreening    = "screening"
+	ApplicationInterviewing = "interviewing"
+	ApplicationOffer        = "offer"
+	ApplicationRejected     = "rejected"
+	ApplicationWithdrawn    = "withdrawn"
+)
+
+// ApplicationStatuses lists the pipeline statuses in order
+var ApplicationStatuses = []string{
+	ApplicationSaved,
+	ApplicationApplied,
+	ApplicationScreening,
+	ApplicationInterviewing,
+	ApplicationOffer,
+	ApplicationRejected,
+	ApplicationWithdrawn,
+}
+
+// applicationTransitions maps each status to the statuses it may move to.
+// Stages may be skipped going forward; rejected and withdrawn are final, and
+// an offer can still be withdrawn (declined) or rejected (rescinded)
+var applicationTransitions = map[string][]string{
+	"":                      {ApplicationSaved, ApplicationApplied},
+	ApplicationSaved:        {ApplicationApplied, ApplicationWithdrawn},
+	ApplicationApplied:      {ApplicationScreening, ApplicationInterviewing, ApplicationOffer, ApplicationRejected, ApplicationWithdrawn},
+	ApplicationScreening:    {ApplicationInterviewing, ApplicationOffer, ApplicationRejected, ApplicationWithdrawn},
+	ApplicationInterviewing: {ApplicationOffer, ApplicationRejected, ApplicationWithdrawn},
+	ApplicationOffer:        {ApplicationRejected, ApplicationWithdrawn},
+}
+
+// ValidApplicationStatus reports whether status is a known pipeline status
+func ValidApplicationStatus(status string) bool {
+	for _, s := range ApplicationStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// CanTransitionApplication reports whether an application in status from may
+// move to status to; from is empty for an application that does not exist yet
+func CanTransitionApplication(from, to string) bool {
+	for _, next := range applicationTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// NextApplicationStatuses returns the statuses reachable from status
+func NextApplicationStatuses(status string) []string {
+	return append([]string(nil), applicationTransitions[status]...)
+}
+
+// ApplicationTransition is one recorded status change of an application
+type ApplicationTransition struct {
+	From string    `json:"from,omitempty"`
+	To   string    `json:"to"`
+	At   time.Time `json:"at"`
+	Note string    `json:"note,omitempty"`
+}
+
+// Application is a candidate's application to a job, stored as an
+// APPLIED_TO relationship
+type Application struct {
+	CandidateID string
+	Job         Job
+	Status      string
+	AppliedAt   time.Time // zero until the application reaches applied
+	UpdatedAt   time.Time
+	History     []ApplicationTransition // oldest first
+}
+
+// Active reports whether the application can still move forward
+func (a Application) Active() bool {
+	return a.Status != ApplicationRejected && a.Status != ApplicationWithdrawn
+}
@@ -0,0 +1,198 @@
+// Package application moves candidates' job applications through the
+// pipeline state machine and records every transition
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+	"github.com/honeycarbs/project-ets/internal/repository"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 500
+)
+
+// Service implements tools.ApplicationService
+type Service struct {
+	repo  repository.ApplicationRepository
+	clock func() time.Time
+}
+
+// NewService creates an application service
+func NewService(repo repository.ApplicationRepository) *Service {
+	return &Service{
+		repo:  repo,
+		clock: time.Now,
+	}
+}
+
+// UpdateApplication creates the application or moves it to params.Status if
+// the state machine allows it
+func (s *Service) UpdateApplication(ctx context.Context, params tools.ApplicationUpdateParams) (tools.ApplicationUpdateResult, error) {
+	candidateID := strings.TrimSpace(params.CandidateID)
+	jobID, err := uuid.Parse(strings.TrimSpace(params.JobID))
+	if err != nil {
+		return tools.ApplicationUpdateResult{}, fmt.Errorf("invalid job_id %q", params.JobID)
+	}
+
+	status := strings.ToLower(strings.TrimSpace(params.Status))
+	if !domain.ValidApplicationStatus(status) {
+		return tools.ApplicationUpdateResult{}, fmt.Errorf("unsupported status %q (use %s)", params.Status, strings.Join(domain.ApplicationStatuses, ", "))
+	}
+
+	at := s.clock().UTC()
+	if params.At != "" {
+		at, err = time.Parse(time.RFC3339, params.At)
+		if err != nil {
+			return tools.ApplicationUpdateResult{}, fmt.Errorf("invalid at %q: use RFC 3339", params.At)
+		}
+		at = at.UTC()
+	}
+
+	app, found, err := s.repo.GetApplication(ctx, candidateID, jobID.String())
+	if err != nil {
+		return tools.ApplicationUpdateResult{}, fmt.Errorf("get application: %w", err)
+	}
+	if !found {
+		app = domain.Application{CandidateID: candidateID, Job: domain.Job{ID: jobID}}
+	}
+	previous := app.Status
+
+	if !domain.CanTransitionApplication(previous, status) {
+		return tools.ApplicationUpdateResult{}, transitionError(previous, status)
+	}
+	if n := len(app.History); n > 0 && at.Before(app.History[n-1].At) {
+		return tools.ApplicationUpdateResult{}, fmt.Errorf("at %s is before the last transition (%s)",
+			at.Format(time.RFC3339), app.History[n-1].At.Format(time.RFC3339))
+	}
+
+	app.Status = status
+	app.UpdatedAt = at
+	if status == domain.ApplicationApplied {
+		app.AppliedAt = at
+	}
+	app.History = append(app.History, domain.ApplicationTransition{
+		From: previous,
+		To:   status,
+		At:   at,
+		Note: strings.TrimSpace(params.Note),
+	})
+
+	saved, err := s.repo.SaveApplication(ctx, app, previous)
+	if err != nil {
+		return tools.ApplicationUpdateResult{}, fmt.Errorf("save application: %w", err)
+	}
+	if !saved {
+		if !found {
+			return tools.ApplicationUpdateResult{}, fmt.Errorf("candidate %s or job %s not found", candidateID, jobID)
+		}
+		return tools.ApplicationUpdateResult{}, fmt.Errorf("application was changed concurrently; retry")
+	}
+
+	// Re-read so a new application carries its job's title and company
+	stored, ok, err := s.repo.GetApplication(ctx, candidateID, jobID.String())
+	if err != nil {
+		return tools.ApplicationUpdateResult{}, fmt.Errorf("get application: %w", err)
+	}
+	if ok {
+		app = stored
+	}
+
+	return tools.ApplicationUpdateResult{
+		Application: toApplication(app, true),
+		Previous:    previous,
+	}, nil
+}
+
+// ListApplications returns the candidate's applications, most recently updated first
+func (s *Service) ListApplications(ctx context.Context, params tools.ApplicationListParams) (tools.ApplicationListResult, error) {
+	statuses := make([]string, 0, len(params.Statuses))
+	for _, st := range params.Statuses {
+		st = strings.ToLower(strings.TrimSpace(st))
+		if !domain.ValidApplicationStatus(st) {
+			return tools.ApplicationListResult{}, fmt.Errorf("unsupported status %q (use %s)", st, strings.Join(domain.ApplicationStatuses, ", "))
+		}
+		statuses = append(statuses, st)
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	candidateID := strings.TrimSpace(params.CandidateID)
+	apps, err := s.repo.ListApplications(ctx, repository.ApplicationFilter{
+		CandidateID: candidateID,
+		JobIDs:      params.JobIDs,
+		Statuses:    statuses,
+		Limit:       limit,
+	})
+	if err != nil {
+		return tools.ApplicationListResult{}, fmt.Errorf("list applications: %w", err)
+	}
+
+	result := tools.ApplicationListResult{
+		CandidateID:  candidateID,
+		Applications: make([]tools.Application, 0, len(apps)),
+		Counts:       make(map[string]int),
+	}
+	for _, app := range apps {
+		result.Applications = append(result.Applications, toApplication(app, params.IncludeHistory))
+		result.Counts[app.Status]++
+	}
+
+	return result, nil
+}
+
+func transitionError(from, to string) error {
+	if from == "" {
+		return fmt.Errorf("a new application must start as %s", strings.Join(domain.NextApplicationStatuses(""), " or "))
+	}
+	if from == to {
+		return fmt.Errorf("application is already %s", to)
+	}
+	next := domain.NextApplicationStatuses(from)
+	if len(next) == 0 {
+		return fmt.Errorf("application is %s and can no longer change", from)
+	}
+	return fmt.Errorf("cannot move from %s to %s (allowed: %s)", from, to, strings.Join(next, ", "))
+}
+
+func toApplication(app domain.Application, withHistory bool) tools.Application {
+	out := tools.Application{
+		CandidateID:  app.CandidateID,
+		JobID:        app.Job.ID.String(),
+		Title:        app.Job.Title,
+		Company:      app.Job.Company.Name,
+		URL:          app.Job.URL,
+		Status:       app.Status,
+		AppliedAt:    app.AppliedAt,
+		UpdatedAt:    app.UpdatedAt,
+		NextStatuses: domain.NextApplicationStatuses(app.Status),
+	}
+	if out.NextStatuses == nil {
+		out.NextStatuses = []string{}
+	}
+	if withHistory {
+		for _, h := range app.History {
+			out.History = append(out.History, tools.ApplicationTransition{
+				From: h.From,
+				To:   h.To,
+				At:   h.At,
+				Note: h.Note,
+			})
+		}
+	}
+	return out
+}
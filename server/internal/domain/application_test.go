@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCanTransitionApplication(t *testing.T) {
+	cases := []struct {
+		from, to string
+		want     bool
+	}{
+		{"", ApplicationSaved, true},
+		{"", ApplicationApplied, true},
+		{"", ApplicationOffer, false},
+		{ApplicationSaved, ApplicationApplied, true},
+		{ApplicationSaved, ApplicationRejected, false},
+		{ApplicationApplied, ApplicationOffer, true},
+		{ApplicationScreening, ApplicationApplied, false},
+		{ApplicationInterviewing, ApplicationRejected, true},
+		// An offer can be declined or rescinded, but not moved back
+		{ApplicationOffer, ApplicationWithdrawn, true},
+		{ApplicationOffer, ApplicationRejected, true},
+		{ApplicationOffer, ApplicationInterviewing, false},
+		{ApplicationRejected, ApplicationApplied, false},
+		{ApplicationWithdrawn, ApplicationSaved, false},
+	}
+	for _, c := range cases {
+		if got := CanTransitionApplication(c.from, c.to); got != c.want {
+			t.Errorf("%q -> %q = %v, want %v", c.from, c.to, got, c.want)
+		}
+	}
+}
+
+func TestFinalApplicationStatuses(t *testing.T) {
+	for _, status := range ApplicationStatuses {
+		final := len(applicationTransitions[status]) == 0
+		want := slices.Contains([]string{ApplicationRejected, ApplicationWithdrawn}, status)
+		if final != want {
+			t.Errorf("%s final = %v, want %v", status, final, want)
+		}
+	}
+}
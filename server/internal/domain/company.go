@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// companySuffixes are legal-form tokens dropped from the end of company names
+var companySuffixes = map[string]bool{
+	"inc": true, "incorporated": true, "llc": true, "llp": true, "lp": true,
+	"ltd": true, "limited": true, "corp": true, "corporation": true, "co": true,
+	"company": true, "plc": true, "gmbh": true, "ag": true, "sa": true, "sas": true,
+	"sarl": true, "srl": true, "spa": true, "bv": true, "nv": true, "pty": true,
+	"pte": true, "oy": true, "ab": true, "kk": true, "kg": true, "se": true,
+}
+
+// NormalizeCompanyName folds case, punctuation and trailing legal suffixes so
+// that "Acme, Inc." and "ACME Inc" compare equal
+func NormalizeCompanyName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.NewReplacer("&", " and ", "'", "", "’", "", ".", "").Replace(name)
+
+	tokens := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	// Keep at least one token so names like "The Company" survive; "and"
+	// joining two suffixes ("GmbH & Co. KG") goes with them
+	for len(tokens) > 1 && companySuffixes[tokens[len(tokens)-1]] {
+		tokens = tokens[:len(tokens)-1]
+		if len(tokens) > 1 && tokens[len(tokens)-1] == "and" {
+			tokens = tokens[:len(tokens)-1]
+		}
+	}
+	return strings.Join(tokens, " ")
+}
+
+// CompanyID derives the Company node identifier from a company name; it is
+// empty when the name has no letters or digits
+func CompanyID(name string) string {
+	return strings.ReplaceAll(NormalizeCompanyName(name), " ", "-")
+}
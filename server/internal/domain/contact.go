@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// Contact link kinds
+const (
+	// ContactReferred is stored as (:Contact)-[:REFERRED]->(:Job)
+	ContactReferred = "referred"
+	// ContactInterviewed is stored as (:Job)-[:INTERVIEWED_BY]->(:Contact)
+	ContactInterviewed = "interviewed"
+)
+
+// ContactLink ties a contact to a job, and to a candidate's application
+// when CandidateID is set
+type ContactLink struct {
+	Kind        string
+	Job         Job // ID and, when read back, Title and Company
+	CandidateID string
+	At          time.Time
+}
+
+// Contact is a recruiter, referrer or interviewer
+type Contact struct {
+	ID          string
+	Name        string
+	Role        string
+	Email       string
+	LinkedInURL string
+	Notes       string
+	Company     CompanyRef // linked via WORKS_AT; empty when unknown
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Links       []ContactLink
+}
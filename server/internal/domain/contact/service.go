@@ -0,0 +1,219 @@
+// Package contact records recruiters, referrers and interviewers and links
+// them to companies, jobs and applications
+package contact
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+	"github.com/honeycarbs/project-ets/internal/repository"
+)
+
+const (
+	defaultSearchLimit = 25
+	maxSearchLimit     = 200
+)
+
+// Service implements tools.ContactService
+type Service struct {
+	repo      repository.ContactRepository
+	companies repository.CompanyRepository
+	clock     func() time.Time
+}
+
+// NewService creates a contact service; companies resolves merged company
+// names in searches
+func NewService(repo repository.ContactRepository, companies repository.CompanyRepository) *Service {
+	return &Service{
+		repo:      repo,
+		companies: companies,
+		clock:     time.Now,
+	}
+}
+
+// AddContact creates or updates a contact. Without contact_id an existing
+// contact with the same email is updated instead of creating a duplicate
+func (s *Service) AddContact(ctx context.Context, params tools.ContactAddParams) (tools.ContactAddResult, error) {
+	email := strings.TrimSpace(params.Email)
+	if email != "" {
+		addr, err := mail.ParseAddress(email)
+		if err != nil {
+			return tools.ContactAddResult{}, fmt.Errorf("invalid email %q", params.Email)
+		}
+		email = addr.Address
+	}
+	linkedIn := strings.TrimSpace(params.LinkedInURL)
+	if linkedIn != "" {
+		if u, err := url.Parse(linkedIn); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return tools.ContactAddResult{}, fmt.Errorf("invalid linkedin_url %q", params.LinkedInURL)
+		}
+	}
+
+	id := strings.TrimSpace(params.ContactID)
+	created := false
+	if id == "" && email != "" {
+		existing, err := s.repo.SearchContacts(ctx, repository.ContactFilter{Email: email, Limit: 1})
+		if err != nil {
+			return tools.ContactAddResult{}, fmt.Errorf("find contact by email: %w", err)
+		}
+		if len(existing) > 0 {
+			id = existing[0].ID
+		}
+	}
+	if id == "" {
+		if strings.TrimSpace(params.Name) == "" {
+			return tools.ContactAddResult{}, fmt.Errorf("name is required for new contacts")
+		}
+		id = uuid.NewString()
+		created = true
+	}
+
+	now := s.clock().UTC()
+	contact := domain.Contact{
+		ID:          id,
+		Name:        strings.TrimSpace(params.Name),
+		Role:        strings.TrimSpace(params.Role),
+		Email:       email,
+		LinkedInURL: linkedIn,
+		Notes:       strings.TrimSpace(params.Notes),
+		UpdatedAt:   now,
+	}
+	if company := strings.TrimSpace(params.Company); company != "" {
+		contact.Company = domain.CompanyRef{ID: domain.CompanyID(company), Name: company}
+		if contact.Company.ID == "" {
+			return tools.ContactAddResult{}, fmt.Errorf("invalid company %q", params.Company)
+		}
+	}
+
+	candidateID := strings.TrimSpace(params.CandidateID)
+	var links []domain.ContactLink
+	requested := make(map[string]bool)
+	addLinks := func(kind string, jobIDs []string) error {
+		for _, raw := range jobIDs {
+			jobID, err := uuid.Parse(strings.TrimSpace(raw))
+			if err != nil {
+				return fmt.Errorf("invalid job id %q", raw)
+			}
+			requested[jobID.String()] = true
+			links = append(links, domain.ContactLink{
+				Kind:        kind,
+				Job:         domain.Job{ID: jobID},
+				CandidateID: candidateID,
+				At:          now,
+			})
+		}
+		return nil
+	}
+	if err := addLinks(domain.ContactReferred, params.ReferredJobIDs); err != nil {
+		return tools.ContactAddResult{}, err
+	}
+	if err := addLinks(domain.ContactInterviewed, params.InterviewedJobIDs); err != nil {
+		return tools.ContactAddResult{}, err
+	}
+
+	stored, err := s.repo.UpsertContact(ctx, contact, links)
+	if err != nil {
+		return tools.ContactAddResult{}, fmt.Errorf("upsert contact: %w", err)
+	}
+
+	for _, link := range stored.Links {
+		delete(requested, link.Job.ID.String())
+	}
+	var missing []string
+	for _, link := range links {
+		if id := link.Job.ID.String(); requested[id] {
+			missing = append(missing, id)
+			delete(requested, id)
+		}
+	}
+
+	return tools.ContactAddResult{
+		Contact:     toContact(stored),
+		Created:     created,
+		MissingJobs: missing,
+	}, nil
+}
+
+// SearchContacts finds contacts by text, company, job or candidate
+func (s *Service) SearchContacts(ctx context.Context, params tools.ContactSearchParams) (tools.ContactSearchResult, error) {
+	filter := repository.ContactFilter{
+		Query:       params.Query,
+		JobID:       strings.TrimSpace(params.JobID),
+		CandidateID: strings.TrimSpace(params.CandidateID),
+		Limit:       params.Limit,
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultSearchLimit
+	}
+	if filter.Limit > maxSearchLimit {
+		filter.Limit = maxSearchLimit
+	}
+
+	if company := strings.TrimSpace(params.Company); company != "" {
+		companyID, err := s.resolveCompany(ctx, company)
+		if err != nil {
+			return tools.ContactSearchResult{}, err
+		}
+		filter.CompanyIDs = []string{companyID}
+	}
+
+	contacts, err := s.repo.SearchContacts(ctx, filter)
+	if err != nil {
+		return tools.ContactSearchResult{}, fmt.Errorf("search contacts: %w", err)
+	}
+
+	result := tools.ContactSearchResult{Contacts: make([]tools.Contact, 0, len(contacts))}
+	for _, c := range contacts {
+		result.Contacts = append(result.Contacts, toContact(c))
+	}
+	return result, nil
+}
+
+// resolveCompany maps a company name or ID to the surviving company ID
+func (s *Service) resolveCompany(ctx context.Context, company string) (string, error) {
+	id := domain.CompanyID(company)
+	if s.companies == nil {
+		return id, nil
+	}
+	for _, candidate := range []string{company, id} {
+		record, ok, err := s.companies.ResolveCompany(ctx, candidate)
+		if err != nil {
+			return "", fmt.Errorf("resolve company: %w", err)
+		}
+		if ok {
+			return record.ID, nil
+		}
+	}
+	return id, nil
+}
+
+func toContact(c domain.Contact) tools.Contact {
+	out := tools.Contact{
+		ID:          c.ID,
+		Name:        c.Name,
+		Role:        c.Role,
+		Email:       c.Email,
+		LinkedInURL: c.LinkedInURL,
+		Company:     c.Company.Name,
+		CompanyID:   c.Company.ID,
+		Notes:       c.Notes,
+	}
+	for _, link := range c.Links {
+		out.Links = append(out.Links, tools.ContactLink{
+			Kind:        link.Kind,
+			JobID:       link.Job.ID.String(),
+			JobTitle:    link.Job.Title,
+			CandidateID: link.CandidateID,
+			At:          link.At,
+		})
+	}
+	return out
+}
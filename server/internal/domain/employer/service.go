@@ -0,0 +1,268 @@
+// Package employer resolves companies and aggregates their postings into employer profiles
+package employer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+	"github.com/honeycarbs/project-ets/internal/repository"
+)
+
+const (
+	defaultRecentLimit = 10
+	topTermsLimit      = 10
+	velocityWindow     = 30 * 24 * time.Hour
+)
+
+// Service implements tools.EmployerService
+type Service struct {
+	repo  repository.CompanyRepository
+	clock func() time.Time
+}
+
+// NewService creates an employer service
+func NewService(repo repository.CompanyRepository) *Service {
+	return &Service{
+		repo:  repo,
+		clock: time.Now,
+	}
+}
+
+// EmployerProfile aggregates the postings of the company matching params.Company
+func (s *Service) EmployerProfile(ctx context.Context, params tools.EmployerProfileParams) (tools.EmployerProfile, error) {
+	company, ok, err := s.resolve(ctx, params.Company)
+	if err != nil {
+		return tools.EmployerProfile{}, err
+	}
+	if !ok {
+		return tools.EmployerProfile{}, fmt.Errorf("company %q not found", params.Company)
+	}
+
+	jobs, err := s.repo.ListCompanyJobs(ctx, company.ID)
+	if err != nil {
+		return tools.EmployerProfile{}, fmt.Errorf("list company jobs: %w", err)
+	}
+
+	recentLimit := params.RecentLimit
+	if recentLimit <= 0 {
+		recentLimit = defaultRecentLimit
+	}
+
+	return buildProfile(company, jobs, s.clock().UTC(), recentLimit), nil
+}
+
+// MergeCompanies folds the source companies into the target. Sources are
+// resolved like employer_profile input; names with no node become aliases
+func (s *Service) MergeCompanies(ctx context.Context, params tools.CompanyMergeParams) (tools.CompanyMergeResult, error) {
+	target, ok, err := s.resolve(ctx, params.Target)
+	if err != nil {
+		return tools.CompanyMergeResult{}, err
+	}
+	if !ok {
+		return tools.CompanyMergeResult{}, fmt.Errorf("target company %q not found", params.Target)
+	}
+
+	var sourceIDs []string
+	seen := map[string]bool{target.ID: true}
+	for _, src := range params.Sources {
+		id := domain.CompanyID(src)
+		if existing, ok, err := s.resolve(ctx, src); err != nil {
+			return tools.CompanyMergeResult{}, err
+		} else if ok {
+			id = existing.ID
+		}
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		sourceIDs = append(sourceIDs, id)
+	}
+	if len(sourceIDs) == 0 {
+		return tools.CompanyMergeResult{}, fmt.Errorf("no sources to merge into %q", target.ID)
+	}
+
+	merged, err := s.repo.MergeCompanies(ctx, target.ID, sourceIDs)
+	if err != nil {
+		return tools.CompanyMergeResult{}, fmt.Errorf("merge companies: %w", err)
+	}
+
+	jobs, err := s.repo.ListCompanyJobs(ctx, merged.ID)
+	if err != nil {
+		return tools.CompanyMergeResult{}, fmt.Errorf("list company jobs: %w", err)
+	}
+
+	aliases := merged.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+	return tools.CompanyMergeResult{
+		CompanyID: merged.ID,
+		Name:      merged.Name,
+		Names:     merged.Names,
+		Aliases:   aliases,
+		Postings:  len(jobs),
+	}, nil
+}
+
+// resolve looks a company up by normalized ID, then by the raw input so
+// nodes created before normalization stay reachable
+func (s *Service) resolve(ctx context.Context, ref string) (repository.CompanyRecord, bool, error) {
+	ref = strings.TrimSpace(ref)
+	candidates := []string{domain.CompanyID(ref), ref, strings.ReplaceAll(strings.ToLower(ref), " ", "-")}
+
+	tried := make(map[string]bool, len(candidates))
+	for _, id := range candidates {
+		if id == "" || tried[id] {
+			continue
+		}
+		tried[id] = true
+
+		company, ok, err := s.repo.ResolveCompany(ctx, id)
+		if err != nil {
+			return repository.CompanyRecord{}, false, fmt.Errorf("resolve company: %w", err)
+		}
+		if ok {
+			return company, true, nil
+		}
+	}
+	return repository.CompanyRecord{}, false, nil
+}
+
+func buildProfile(company repository.CompanyRecord, jobs []domain.Job, now time.Time, recentLimit int) tools.EmployerProfile {
+	profile := tools.EmployerProfile{
+		CompanyID:      company.ID,
+		Name:           company.Name,
+		Names:          company.Names,
+		Aliases:        company.Aliases,
+		Postings:       len(jobs),
+		Locations:      []tools.TermCount{},
+		TopSkills:      []tools.TermCount{},
+		RecentPostings: []tools.EmployerPosting{},
+	}
+
+	locations := make(map[string]int)
+	skills := make(map[string]int)
+	var salaries []float64
+	last90 := 0
+
+	for _, job := range jobs {
+		if job.Remote {
+			profile.RemotePostings++
+		}
+		if loc := strings.TrimSpace(job.Location); loc != "" {
+			locations[loc]++
+		}
+		for _, skill := range job.Skills {
+			if skill.Name != "" {
+				skills[skill.Name]++
+			}
+		}
+		if job.Score > 0 {
+			salaries = append(salaries, job.Score)
+		}
+
+		posted := postingDate(job)
+		if posted.IsZero() {
+			continue
+		}
+		if profile.FirstPosted == nil || posted.Before(*profile.FirstPosted) {
+			t := posted
+			profile.FirstPosted = &t
+		}
+		if profile.LastPosted == nil || posted.After(*profile.LastPosted) {
+			t := posted
+			profile.LastPosted = &t
+		}
+
+		age := now.Sub(posted)
+		switch {
+		case age < velocityWindow:
+			profile.Velocity.Last30Days++
+		case age < 2*velocityWindow:
+			profile.Velocity.Previous30Days++
+		}
+		if age < 3*velocityWindow {
+			last90++
+		}
+	}
+
+	profile.Velocity.PerWeek = float64(last90) / (3 * velocityWindow.Hours() / (24 * 7))
+	profile.Velocity.Trend = velocityTrend(profile.Velocity.Last30Days, profile.Velocity.Previous30Days)
+	profile.Locations = topTerms(locations, topTermsLimit)
+	profile.TopSkills = topTerms(skills, topTermsLimit)
+
+	if len(salaries) > 0 {
+		sort.Float64s(salaries)
+		median := salaries[len(salaries)/2]
+		if len(salaries)%2 == 0 {
+			median = (salaries[len(salaries)/2-1] + salaries[len(salaries)/2]) / 2
+		}
+		profile.Salary = &tools.SalaryRange{
+			Min:    salaries[0],
+			Median: median,
+			Max:    salaries[len(salaries)-1],
+			Count:  len(salaries),
+		}
+	}
+
+	sorted := make([]domain.Job, len(jobs))
+	copy(sorted, jobs)
+	sort.SliceStable(sorted, func(i, j int) bool { return postingDate(sorted[i]).After(postingDate(sorted[j])) })
+	for i, job := range sorted {
+		if i >= recentLimit {
+			break
+		}
+		profile.RecentPostings = append(profile.RecentPostings, tools.EmployerPosting{
+			JobID:    job.ID.String(),
+			Title:    job.Title,
+			Location: job.Location,
+			Salary:   job.Score,
+			PostedAt: postingDate(job),
+		})
+	}
+
+	return profile
+}
+
+// postingDate falls back to the fetch time when the provider gave no posting date
+func postingDate(job domain.Job) time.Time {
+	if !job.PostedAt.IsZero() && job.PostedAt.Unix() > 0 {
+		return job.PostedAt.UTC()
+	}
+	return job.FetchedAt.UTC()
+}
+
+func velocityTrend(last, previous int) string {
+	switch {
+	case last == 0 && previous == 0:
+		return "inactive"
+	case float64(last) > float64(previous)*1.2:
+		return "growing"
+	case float64(last) < float64(previous)*0.8:
+		return "slowing"
+	default:
+		return "steady"
+	}
+}
+
+func topTerms(counts map[string]int, limit int) []tools.TermCount {
+	terms := make([]tools.TermCount, 0, len(counts))
+	for term, count := range counts {
+		terms = append(terms, tools.TermCount{Term: term, Count: count})
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if terms[i].Count != terms[j].Count {
+			return terms[i].Count > terms[j].Count
+		}
+		return terms[i].Term < terms[j].Term
+	})
+	if len(terms) > limit {
+		terms = terms[:limit]
+	}
+	return terms
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// Event kinds
+const (
+	EventInterview = "interview"
+	EventFollowUp  = "follow_up"
+	EventDeadline  = "deadline"
+)
+
+// EventKinds lists the supported event kinds
+var EventKinds = []string{EventInterview, EventFollowUp, EventDeadline}
+
+// ValidEventKind reports whether kind is a supported event kind
+func ValidEventKind(kind string) bool {
+	for _, k := range EventKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is a scheduled item for a job, linked to a candidate when it belongs
+// to their application
+type Event struct {
+	ID           string
+	Kind         string
+	Title        string
+	Start        time.Time
+	End          time.Time // exclusive; for all-day events the day after the last day
+	AllDay       bool
+	Location     string
+	Notes        string
+	Round        int      // interview round, 0 when not applicable
+	Interviewers []string // names as entered
+	CandidateID  string   // empty for events that only concern the job
+	Job          Job
+	CreatedAt    time.Time
+}
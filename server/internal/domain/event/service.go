@@ -0,0 +1,296 @@
+// Package event schedules interviews, follow-ups and deadlines and exports
+// them as an iCalendar feed
+package event
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+	"github.com/honeycarbs/project-ets/internal/repository"
+	"github.com/honeycarbs/project-ets/pkg/ical"
+)
+
+const (
+	defaultListLimit       = 50
+	maxListLimit           = 500
+	defaultDurationMinutes = 60
+	// feedHistory and feedLimit bound the events served in the calendar feed
+	feedHistory = 90 * 24 * time.Hour
+	feedLimit   = 1000
+	dateLayout  = "2006-01-02"
+	prodID      = "-//project-ets//job search events//EN"
+)
+
+// Service implements tools.EventService
+type Service struct {
+	repo  repository.EventRepository
+	clock func() time.Time
+}
+
+// NewService creates an event service
+func NewService(repo repository.EventRepository) *Service {
+	return &Service{
+		repo:  repo,
+		clock: time.Now,
+	}
+}
+
+// AddEvent validates and stores an event. A date-only start makes an all-day
+// event; timed events last DurationMinutes when no end is given
+func (s *Service) AddEvent(ctx context.Context, params tools.EventAddParams) (tools.Event, error) {
+	jobID, err := uuid.Parse(strings.TrimSpace(params.JobID))
+	if err != nil {
+		return tools.Event{}, fmt.Errorf("invalid job_id %q", params.JobID)
+	}
+
+	kind := strings.ToLower(strings.TrimSpace(params.Kind))
+	if !domain.ValidEventKind(kind) {
+		return tools.Event{}, fmt.Errorf("unsupported kind %q (use %s)", params.Kind, strings.Join(domain.EventKinds, ", "))
+	}
+
+	start, allDay, err := parseTime(params.Start)
+	if err != nil {
+		return tools.Event{}, fmt.Errorf("invalid start: %w", err)
+	}
+
+	var end time.Time
+	if params.End != "" {
+		var endAllDay bool
+		end, endAllDay, err = parseTime(params.End)
+		if err != nil {
+			return tools.Event{}, fmt.Errorf("invalid end: %w", err)
+		}
+		if endAllDay != allDay {
+			return tools.Event{}, fmt.Errorf("start and end must both be dates or both be times")
+		}
+		if allDay {
+			// end names the last day; iCalendar ends are exclusive
+			end = end.AddDate(0, 0, 1)
+		}
+		if !end.After(start) {
+			return tools.Event{}, fmt.Errorf("end must be after start")
+		}
+	} else if allDay {
+		end = start.AddDate(0, 0, 1)
+	} else {
+		minutes := params.DurationMinutes
+		if minutes <= 0 {
+			minutes = defaultDurationMinutes
+		}
+		end = start.Add(time.Duration(minutes) * time.Minute)
+	}
+
+	if params.Round < 0 {
+		return tools.Event{}, fmt.Errorf("round must not be negative")
+	}
+
+	title := strings.TrimSpace(params.Title)
+	if title == "" {
+		title = defaultTitle(kind, params.Round)
+	}
+
+	var interviewers []string
+	for _, name := range params.Interviewers {
+		if name = strings.TrimSpace(name); name != "" {
+			interviewers = append(interviewers, name)
+		}
+	}
+
+	candidateID := strings.TrimSpace(params.CandidateID)
+	stored, created, err := s.repo.CreateEvent(ctx, domain.Event{
+		ID:           uuid.NewString(),
+		Kind:         kind,
+		Title:        title,
+		Start:        start,
+		End:          end,
+		AllDay:       allDay,
+		Location:     strings.TrimSpace(params.Location),
+		Notes:        strings.TrimSpace(params.Notes),
+		Round:        params.Round,
+		Interviewers: interviewers,
+		CandidateID:  candidateID,
+		Job:          domain.Job{ID: jobID},
+		CreatedAt:    s.clock().UTC(),
+	})
+	if err != nil {
+		return tools.Event{}, fmt.Errorf("create event: %w", err)
+	}
+	if !created {
+		if candidateID != "" {
+			return tools.Event{}, fmt.Errorf("job %s or candidate %s not found", jobID, candidateID)
+		}
+		return tools.Event{}, fmt.Errorf("job %s not found", jobID)
+	}
+
+	return toEvent(stored), nil
+}
+
+// ListEvents returns matching events, earliest first. Without from only
+// events that have not ended are listed unless IncludePast is set
+func (s *Service) ListEvents(ctx context.Context, params tools.EventListParams) (tools.EventListResult, error) {
+	filter := repository.EventFilter{
+		CandidateID: strings.TrimSpace(params.CandidateID),
+		Limit:       params.Limit,
+	}
+	if id := strings.TrimSpace(params.JobID); id != "" {
+		filter.JobIDs = []string{id}
+	}
+	for _, kind := range params.Kinds {
+		kind = strings.ToLower(strings.TrimSpace(kind))
+		if !domain.ValidEventKind(kind) {
+			return tools.EventListResult{}, fmt.Errorf("unsupported kind %q (use %s)", kind, strings.Join(domain.EventKinds, ", "))
+		}
+		filter.Kinds = append(filter.Kinds, kind)
+	}
+
+	if params.From != "" {
+		from, _, err := parseTime(params.From)
+		if err != nil {
+			return tools.EventListResult{}, fmt.Errorf("invalid from: %w", err)
+		}
+		filter.From = from
+	} else if !params.IncludePast {
+		filter.From = s.clock().UTC()
+	}
+	if params.To != "" {
+		to, _, err := parseTime(params.To)
+		if err != nil {
+			return tools.EventListResult{}, fmt.Errorf("invalid to: %w", err)
+		}
+		filter.To = to
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+
+	events, err := s.repo.ListEvents(ctx, filter)
+	if err != nil {
+		return tools.EventListResult{}, fmt.Errorf("list events: %w", err)
+	}
+
+	result := tools.EventListResult{Events: make([]tools.Event, 0, len(events))}
+	for _, e := range events {
+		result.Events = append(result.Events, toEvent(e))
+	}
+	return result, nil
+}
+
+// CalendarFeed renders recent and upcoming events as an RFC 5545 calendar
+func (s *Service) CalendarFeed(ctx context.Context, candidateID string) ([]byte, error) {
+	now := s.clock().UTC()
+	events, err := s.repo.ListEvents(ctx, repository.EventFilter{
+		CandidateID: candidateID,
+		From:        now.Add(-feedHistory),
+		Limit:       feedLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list events: %w", err)
+	}
+
+	cal := ical.Calendar{
+		ProdID: prodID,
+		Name:   "Job search",
+		Events: make([]ical.Event, 0, len(events)),
+	}
+	for _, e := range events {
+		cal.Events = append(cal.Events, toICal(e))
+	}
+
+	var buf bytes.Buffer
+	if err := cal.Encode(&buf, now); err != nil {
+		return nil, fmt.Errorf("encode calendar: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// parseTime accepts RFC 3339 timestamps and YYYY-MM-DD dates; dates are
+// reported as all-day and anchored at midnight UTC
+func parseTime(value string) (time.Time, bool, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), false, nil
+	}
+	if t, err := time.Parse(dateLayout, value); err == nil {
+		return t, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("%q is neither RFC 3339 nor YYYY-MM-DD", value)
+}
+
+func defaultTitle(kind string, round int) string {
+	switch kind {
+	case domain.EventInterview:
+		if round > 0 {
+			return fmt.Sprintf("Interview round %d", round)
+		}
+		return "Interview"
+	case domain.EventFollowUp:
+		return "Follow up"
+	default:
+		return "Deadline"
+	}
+}
+
+func toEvent(e domain.Event) tools.Event {
+	return tools.Event{
+		ID:           e.ID,
+		Kind:         e.Kind,
+		Title:        e.Title,
+		Start:        e.Start,
+		End:          e.End,
+		AllDay:       e.AllDay,
+		Location:     e.Location,
+		Notes:        e.Notes,
+		Round:        e.Round,
+		Interviewers: e.Interviewers,
+		CandidateID:  e.CandidateID,
+		JobID:        e.Job.ID.String(),
+		JobTitle:     e.Job.Title,
+		Company:      e.Job.Company.Name,
+	}
+}
+
+func toICal(e domain.Event) ical.Event {
+	summary := e.Title
+	if e.Job.Company.Name != "" {
+		summary += " – " + e.Job.Company.Name
+	}
+
+	var desc []string
+	if e.Job.Title != "" {
+		desc = append(desc, "Job: "+e.Job.Title)
+	}
+	if e.Round > 0 {
+		desc = append(desc, fmt.Sprintf("Round: %d", e.Round))
+	}
+	if len(e.Interviewers) > 0 {
+		desc = append(desc, "Interviewers: "+strings.Join(e.Interviewers, ", "))
+	}
+	if e.Notes != "" {
+		desc = append(desc, "", e.Notes)
+	}
+
+	return ical.Event{
+		UID:         e.ID + "@project-ets",
+		Summary:     summary,
+		Description: strings.Join(desc, "\n"),
+		Location:    e.Location,
+		URL:         e.Job.URL,
+		Categories:  []string{e.Kind},
+		Start:       e.Start.UTC(),
+		End:         e.End.UTC(),
+		AllDay:      e.AllDay,
+		Created:     e.CreatedAt,
+		Modified:    e.CreatedAt,
+	}
+}
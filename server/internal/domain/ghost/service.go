@@ -0,0 +1,303 @@
+// Package ghost detects re-listed postings and scores how likely a posting is
+// a ghost job: listed for a long time, reposted, with no salary and a vague description
+package ghost
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+	"github.com/honeycarbs/project-ets/internal/repository"
+)
+
+const (
+	defaultReportLimit = 25
+
+	// Repost detection thresholds
+	minTitleSimilarity       = 0.8
+	minDescriptionSimilarity = 0.6
+	// Descriptions shorter than this carry too little text to compare, so
+	// such postings only match on an identical title
+	minComparableWords = 20
+
+	// Signal weights; they sum to 1
+	weightAge     = 0.3
+	weightReposts = 0.3
+	weightSalary  = 0.2
+	weightVague   = 0.2
+
+	// Listing age is not suspicious before freshDays and fully so after staleDays
+	freshDays = 30
+	staleDays = 90
+	// Reposts saturate the signal at this count
+	maxReposts = 3
+	// Descriptions are fully vague below vagueWords and specific above detailedWords
+	vagueWords    = 40
+	detailedWords = 150
+
+	highThreshold   = 0.6
+	mediumThreshold = 0.35
+)
+
+// Service implements tools.GhostService
+type Service struct {
+	repo  repository.PostingRepository
+	clock func() time.Time
+}
+
+// NewService creates a ghost-posting service
+func NewService(repo repository.PostingRepository) *Service {
+	return &Service{
+		repo:  repo,
+		clock: time.Now,
+	}
+}
+
+// DetectReposts compares each job with the other postings of its company and
+// links near-identical ones, newer to older. It returns the number of pairs found
+func (s *Service) DetectReposts(ctx context.Context, jobIDs []string) (int, error) {
+	candidates, err := s.repo.ListRepostCandidates(ctx, jobIDs)
+	if err != nil {
+		return 0, fmt.Errorf("list repost candidates: %w", err)
+	}
+
+	seen := make(map[[2]string]bool)
+	var links []repository.RepostLink
+	for _, c := range candidates {
+		for _, other := range c.Others {
+			similarity, ok := repostSimilarity(c.Job, other)
+			if !ok {
+				continue
+			}
+			newer, older := c.Job, other
+			if listedSince(other).After(listedSince(c.Job)) ||
+				(listedSince(other).Equal(listedSince(c.Job)) && other.ID.String() > c.Job.ID.String()) {
+				newer, older = other, c.Job
+			}
+			key := [2]string{newer.ID.String(), older.ID.String()}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			links = append(links, repository.RepostLink{
+				JobID:      key[0],
+				OriginalID: key[1],
+				Similarity: similarity,
+			})
+		}
+	}
+
+	if err := s.repo.LinkReposts(ctx, links); err != nil {
+		return 0, fmt.Errorf("link reposts: %w", err)
+	}
+	return len(links), nil
+}
+
+// Assess scores the given jobs; unknown IDs are left out of the result
+func (s *Service) Assess(ctx context.Context, jobIDs []string) (map[string]tools.GhostAssessment, error) {
+	assessments := make(map[string]tools.GhostAssessment, len(jobIDs))
+	if len(jobIDs) == 0 {
+		return assessments, nil
+	}
+
+	signals, err := s.repo.ListPostingSignals(ctx, repository.PostingFilter{JobIDs: jobIDs})
+	if err != nil {
+		return nil, fmt.Errorf("list posting signals: %w", err)
+	}
+
+	now := s.clock().UTC()
+	for _, sig := range signals {
+		assessments[sig.Job.ID.String()] = assess(sig, now)
+	}
+	return assessments, nil
+}
+
+// GhostReport assesses stored jobs and lists them by descending score
+func (s *Service) GhostReport(ctx context.Context, params tools.GhostReportParams) (tools.GhostReportResult, error) {
+	filter := repository.PostingFilter{JobIDs: params.JobIDs}
+	if company := strings.TrimSpace(params.Company); company != "" {
+		filter.CompanyID = domain.CompanyID(company)
+		if filter.CompanyID == "" {
+			return tools.GhostReportResult{}, fmt.Errorf("company %q has no letters or digits", params.Company)
+		}
+	}
+
+	signals, err := s.repo.ListPostingSignals(ctx, filter)
+	if err != nil {
+		return tools.GhostReportResult{}, fmt.Errorf("list posting signals: %w", err)
+	}
+
+	result := tools.GhostReportResult{
+		Jobs: []tools.GhostReportJob{},
+		LevelCounts: map[string]int{
+			tools.GhostLevelHigh:   0,
+			tools.GhostLevelMedium: 0,
+			tools.GhostLevelLow:    0,
+		},
+	}
+
+	if params.Rescan && len(signals) > 0 {
+		ids := make([]string, 0, len(signals))
+		for _, sig := range signals {
+			ids = append(ids, sig.Job.ID.String())
+		}
+		if result.RepostsLinked, err = s.DetectReposts(ctx, ids); err != nil {
+			return tools.GhostReportResult{}, err
+		}
+		if signals, err = s.repo.ListPostingSignals(ctx, filter); err != nil {
+			return tools.GhostReportResult{}, fmt.Errorf("list posting signals: %w", err)
+		}
+	}
+
+	now := s.clock().UTC()
+	for _, sig := range signals {
+		assessment := assess(sig, now)
+		result.Assessed++
+		result.LevelCounts[assessment.Level]++
+		if assessment.Score < params.MinScore {
+			continue
+		}
+		result.Jobs = append(result.Jobs, tools.GhostReportJob{
+			JobID:   sig.Job.ID.String(),
+			Title:   sig.Job.Title,
+			Company: sig.Job.Company.Name,
+			URL:     sig.Job.URL,
+			Ghost:   assessment,
+		})
+	}
+
+	sort.SliceStable(result.Jobs, func(i, j int) bool {
+		return result.Jobs[i].Ghost.Score > result.Jobs[j].Ghost.Score
+	})
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultReportLimit
+	}
+	if len(result.Jobs) > limit {
+		result.Jobs = result.Jobs[:limit]
+	}
+
+	result.GeneratedAt = now
+	return result, nil
+}
+
+// repostSimilarity reports whether b looks like a re-listing of a. Postings
+// for different locations are separate openings, not reposts
+func repostSimilarity(a, b domain.Job) (float64, bool) {
+	if !strings.EqualFold(strings.TrimSpace(a.Location), strings.TrimSpace(b.Location)) {
+		return 0, false
+	}
+
+	title := titleSimilarity(a.Title, b.Title)
+	if title < minTitleSimilarity {
+		return 0, false
+	}
+
+	if len(words(a.Description)) < minComparableWords || len(words(b.Description)) < minComparableWords {
+		return title, title == 1
+	}
+
+	description := descriptionSimilarity(a.Description, b.Description)
+	if description < minDescriptionSimilarity {
+		return 0, false
+	}
+	return round2((title + description) / 2), true
+}
+
+// listedSince is the earliest time the posting is known to exist
+func listedSince(job domain.Job) time.Time {
+	since := job.FirstSeenAt
+	if !job.PostedAt.IsZero() && (since.IsZero() || job.PostedAt.Before(since)) {
+		since = job.PostedAt
+	}
+	if since.IsZero() {
+		since = job.FetchedAt
+	}
+	return since
+}
+
+func assess(sig repository.PostingSignals, now time.Time) tools.GhostAssessment {
+	job := sig.Job
+	a := tools.GhostAssessment{
+		SeenCount: job.SeenCount,
+		Reposts:   sig.Reposts,
+	}
+	if !job.FirstSeenAt.IsZero() {
+		t := job.FirstSeenAt.UTC()
+		a.FirstSeenAt = &t
+	}
+	if !job.LastSeenAt.IsZero() {
+		t := job.LastSeenAt.UTC()
+		a.LastSeenAt = &t
+	}
+
+	// Age runs to the last sighting, so a posting that disappeared stops aging
+	end := job.LastSeenAt
+	if end.IsZero() {
+		end = now
+	}
+	if since := listedSince(job); !since.IsZero() && end.After(since) {
+		a.AgeDays = int(end.Sub(since).Hours() / 24)
+	}
+
+	var score float64
+	if age := ramp(float64(a.AgeDays), freshDays, staleDays); age > 0 {
+		score += weightAge * age
+		a.Reasons = append(a.Reasons, fmt.Sprintf("listed for %d days", a.AgeDays))
+	}
+
+	if n := len(sig.Reposts); n > 0 {
+		score += weightReposts * math.Min(float64(n), maxReposts) / maxReposts
+		a.Reasons = append(a.Reasons, fmt.Sprintf("re-listed %d time(s)", n))
+	}
+
+	if job.Score <= 0 {
+		score += weightSalary
+		a.Reasons = append(a.Reasons, "no salary advertised")
+	}
+
+	// Short text and no extractable skills both point to a generic posting
+	vagueness := 0.6 * (1 - ramp(float64(len(words(job.Description))), vagueWords, detailedWords))
+	if len(job.Skills) == 0 {
+		vagueness += 0.4
+	}
+	if vagueness > 0 {
+		score += weightVague * vagueness
+		if vagueness >= 0.7 {
+			a.Reasons = append(a.Reasons, "vague description")
+		}
+	}
+
+	a.Score = round2(score)
+	switch {
+	case a.Score >= highThreshold:
+		a.Level = tools.GhostLevelHigh
+	case a.Score >= mediumThreshold:
+		a.Level = tools.GhostLevelMedium
+	default:
+		a.Level = tools.GhostLevelLow
+	}
+	return a
+}
+
+// ramp maps v linearly from 0 at lo to 1 at hi
+func ramp(v, lo, hi float64) float64 {
+	switch {
+	case v <= lo:
+		return 0
+	case v >= hi:
+		return 1
+	default:
+		return (v - lo) / (hi - lo)
+	}
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,63 @@
+package ghost
+
+import (
+	"strings"
+	"unicode"
+)
+
+// shingleSize is the number of consecutive words compared between descriptions
+const shingleSize = 3
+
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	shared := 0
+	for k := range a {
+		if b[k] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+func wordSet(text string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range words(text) {
+		set[w] = true
+	}
+	return set
+}
+
+func shingles(text string) map[string]bool {
+	ws := words(text)
+	set := make(map[string]bool)
+	if len(ws) < shingleSize {
+		for _, w := range ws {
+			set[w] = true
+		}
+		return set
+	}
+	for i := 0; i+shingleSize <= len(ws); i++ {
+		set[strings.Join(ws[i:i+shingleSize], " ")] = true
+	}
+	return set
+}
+
+// titleSimilarity compares titles as word sets so reordering ("Senior Go
+// Engineer" vs "Go Engineer, Senior") does not matter
+func titleSimilarity(a, b string) float64 {
+	return jaccard(wordSet(a), wordSet(b))
+}
+
+// descriptionSimilarity compares word shingles, which tolerates small edits
+// such as a changed date or reference number
+func descriptionSimilarity(a, b string) float64 {
+	return jaccard(shingles(a), shingles(b))
+}
@@ -0,0 +1,147 @@
+package graphio
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+)
+
+var cypherEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`'`, `\'`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\t", `\t`,
+)
+
+func cypherString(s string) string {
+	return "'" + cypherEscaper.Replace(s) + "'"
+}
+
+func cypherFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// cypherUpsertJob is the UpsertJobs statement for one job bound to job.
+// Merged company IDs are resolved through their CompanyAlias node in the
+// script itself, since the target database may have merged differently
+const cypherUpsertJob = `MERGE (j:Job {source: job.source, externalId: job.externalId})
+WITH j, job, datetime({epochMillis: job.fetchedAt}) as seenAt,
+     coalesce(j.lastSeenAt, j.fetchedAt) as previousSeen,
+     coalesce(j.firstSeenAt, j.fetchedAt) as firstSeen,
+     coalesce(j.contentHash, "") <> job.contentHash as changed
+WITH j, job, seenAt, previousSeen, firstSeen, changed,
+     previousSeen IS NULL OR seenAt > previousSeen as newSighting
+WITH j, job, seenAt, previousSeen, firstSeen, changed, newSighting,
+     newSighting AND NOT (j.status = 'closed' AND j.closedReason = 'provider') as reopen
+SET j.firstSeenAt = CASE WHEN firstSeen IS NULL OR seenAt < firstSeen THEN seenAt ELSE firstSeen END,
+    j.lastSeenAt = CASE WHEN newSighting THEN seenAt ELSE previousSeen END,
+    j.seenCount = coalesce(j.seenCount, CASE WHEN previousSeen IS NULL THEN 0 ELSE 1 END) +
+                  CASE WHEN newSighting THEN 1 ELSE 0 END,
+    j.status = CASE WHEN reopen OR j.status IS NULL THEN 'open' ELSE j.status END,
+    j.closedAt = CASE WHEN reopen THEN null ELSE j.closedAt END,
+    j.closedReason = CASE WHEN reopen THEN null ELSE j.closedReason END,
+    j.missedRefreshes = CASE WHEN reopen THEN 0 ELSE j.missedRefreshes END
+SET j.id = job.id,
+    j.title = job.title,
+    j.location = job.location,
+    j.remote = job.remote,
+    j.url = job.url,
+    j.postedAt = datetime({epochMillis: job.postedAt}),
+    j.description = job.description,
+    j.score = job.score,
+    j.fetchedAt = datetime({epochMillis: job.fetchedAt}),
+    j.contentHash = job.contentHash
+FOREACH (_ IN CASE WHEN changed THEN [1] ELSE [] END |
+  CREATE (snap:JobSnapshot {
+    hash: job.contentHash,
+    capturedAt: seenAt,
+    title: job.title,
+    description: job.description,
+    location: job.location,
+    remote: job.remote,
+    url: job.url,
+    score: job.score
+  })
+  CREATE (j)-[:HAS_SNAPSHOT]->(snap)
+)
+WITH j, job
+OPTIONAL MATCH (:CompanyAlias {id: job.company.id})-[:ALIAS_OF]->(merged:Company)
+WITH j, job, coalesce(merged.id, job.company.id) as companyId
+FOREACH (_ IN CASE WHEN coalesce(companyId, "") = "" THEN [] ELSE [1] END |
+  MERGE (c:Company {id: companyId})
+  SET c.name = CASE WHEN coalesce(c.name, "") = "" THEN job.company.name ELSE c.name END,
+      c.names = CASE WHEN job.company.name = "" OR job.company.name IN coalesce(c.names, []) THEN coalesce(c.names, [])
+                     ELSE coalesce(c.names, []) + job.company.name END
+  MERGE (j)-[:WORKED_AT]->(c)
+)
+WITH j, job
+FOREACH (skill IN job.skills |
+  MERGE (s:Skill {id: skill.id})
+  SET s.name = skill.name
+  MERGE (j)-[:REQUIRES]->(s)
+)
+`
+
+// writeCypher writes one statement per job that binds the job as a literal
+// map and runs the UpsertJobs statement on it, so the script keeps the same
+// lifecycle properties, content hash and snapshots, and running it twice
+// changes nothing. Keyword edges and their notes belong to ownerID, the
+// user who exported them; the shared Keyword nodes carry only their value
+func writeCypher(w io.Writer, records []Record, ownerID string) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "// project-ets graph export: %d jobs\n", len(records))
+	fmt.Fprintln(bw, "// Run with: cypher-shell -f <file>")
+
+	for _, r := range records {
+		job := r.job()
+		skills := make([]string, 0, len(r.Skills))
+		for _, s := range r.Skills {
+			skills = append(skills, fmt.Sprintf("{id: %s, name: %s}", cypherString(s.ID), cypherString(s.Name)))
+		}
+
+		fmt.Fprintln(bw)
+		fmt.Fprintf(bw, "WITH {id: %s,\n", cypherString(r.ID))
+		fmt.Fprintf(bw, "      title: %s,\n", cypherString(r.Title))
+		fmt.Fprintf(bw, "      company: {id: %s, name: %s},\n", cypherString(r.Company.ID), cypherString(r.Company.Name))
+		fmt.Fprintf(bw, "      location: %s,\n", cypherString(r.Location))
+		fmt.Fprintf(bw, "      remote: %t,\n", r.Remote)
+		fmt.Fprintf(bw, "      url: %s,\n", cypherString(r.URL))
+		fmt.Fprintf(bw, "      source: %s,\n", cypherString(r.Source))
+		fmt.Fprintf(bw, "      externalId: %s,\n", cypherString(r.ExternalID))
+		fmt.Fprintf(bw, "      postedAt: %d,\n", r.PostedAt.UnixMilli())
+		fmt.Fprintf(bw, "      description: %s,\n", cypherString(r.Description))
+		fmt.Fprintf(bw, "      score: %s,\n", cypherFloat(r.Score))
+		fmt.Fprintf(bw, "      fetchedAt: %d,\n", r.FetchedAt.UnixMilli())
+		fmt.Fprintf(bw, "      contentHash: %s,\n", cypherString(job.ContentHash()))
+		fmt.Fprintf(bw, "      skills: [%s]} AS job\n", strings.Join(skills, ", "))
+		fmt.Fprint(bw, cypherUpsertJob)
+
+		for i, k := range r.Keywords {
+			value := domain.NormalizeKeyword(k.Value)
+			if value == "" {
+				continue
+			}
+			fmt.Fprintf(bw, "MERGE (k%d:Keyword {value: %s})\n", i, cypherString(value))
+			fmt.Fprintf(bw, "MERGE (j)-[hk%d:HAS_KEYWORD {ownerId: %s}]->(k%d)\n", i, cypherString(ownerID), i)
+			fmt.Fprintf(bw, "SET hk%d.createdAt = coalesce(hk%d.createdAt, datetime())", i, i)
+			if k.Notes != "" {
+				fmt.Fprintf(bw, ",\n    hk%d.notes = %s", i, cypherString(k.Notes))
+			}
+			if k.Source != "" {
+				fmt.Fprintf(bw, ",\n    hk%d.source = %s", i, cypherString(k.Source))
+			}
+			if k.Confidence > 0 {
+				fmt.Fprintf(bw, ",\n    hk%d.confidence = %s", i, cypherFloat(k.Confidence))
+			}
+			fmt.Fprintln(bw)
+		}
+		fmt.Fprintln(bw, ";")
+	}
+
+	return bw.Flush()
+}
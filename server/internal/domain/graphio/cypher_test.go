@@ -0,0 +1,34 @@
+package graphio
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteCypherKeepsKeywordsOwned(t *testing.T) {
+	records := []Record{{
+		ID:         "6f1c2a4e-0000-4000-8000-000000000001",
+		Title:      "Go Developer",
+		Source:     "adzuna",
+		ExternalID: "123",
+		Keywords: []Keyword{
+			{Value: "Go", Notes: "core stack", Source: "manual"},
+		},
+	}}
+
+	var sb strings.Builder
+	if err := writeCypher(&sb, records, "user-a"); err != nil {
+		t.Fatal(err)
+	}
+	script := sb.String()
+
+	if !strings.Contains(script, "MERGE (j)-[hk0:HAS_KEYWORD {ownerId: 'user-a'}]->(k0)") {
+		t.Errorf("keyword edge is not owned:\n%s", script)
+	}
+	if !strings.Contains(script, "hk0.notes = 'core stack'") {
+		t.Errorf("notes are not on the edge:\n%s", script)
+	}
+	if strings.Contains(script, "SET k0.notes") {
+		t.Errorf("notes written to the shared Keyword node:\n%s", script)
+	}
+}
@@ -0,0 +1,278 @@
+// Package graphio exports the Job/Company/Skill/Keyword subgraph as GraphML,
+// a Cypher script or JSON Lines, and imports GraphML and JSON Lines back
+// through the same MERGE semantics the job and keyword repositories use
+package graphio
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/identity"
+	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+	"github.com/honeycarbs/project-ets/internal/repository"
+)
+
+// Supported formats
+const (
+	FormatGraphML = "graphml"
+	FormatCypher  = "cypher"
+	FormatJSONL   = "jsonl"
+)
+
+// importBatchSize bounds the number of jobs written per transaction
+const importBatchSize = 100
+
+// ParseFormat normalizes a format name
+func ParseFormat(format string) (string, error) {
+	switch f := strings.ToLower(strings.TrimSpace(format)); f {
+	case FormatGraphML, FormatCypher, FormatJSONL:
+		return f, nil
+	case "json", "ndjson":
+		return FormatJSONL, nil
+	default:
+		return "", fmt.Errorf("unsupported export format %q (use graphml, cypher or jsonl)", format)
+	}
+}
+
+// Record is the portable form of a job and its neighborhood
+type Record struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Company     Company   `json:"company"`
+	Location    string    `json:"location,omitempty"`
+	Remote      bool      `json:"remote"`
+	URL         string    `json:"url,omitempty"`
+	Source      string    `json:"source"`
+	ExternalID  string    `json:"external_id"`
+	PostedAt    time.Time `json:"posted_at"`
+	Description string    `json:"description,omitempty"`
+	Score       float64   `json:"score,omitempty"`
+	FetchedAt   time.Time `json:"fetched_at"`
+	Skills      []Skill   `json:"skills"`
+	Keywords    []Keyword `json:"keywords"`
+}
+
+// Company is the exported Company node
+type Company struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Skill is the exported Skill node
+type Skill struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Keyword is an exported Keyword node with its HAS_KEYWORD edge properties
+type Keyword = repository.ExportedKeyword
+
+func recordFromExport(e repository.ExportedJob) Record {
+	r := Record{
+		ID:          e.Job.ID.String(),
+		Title:       e.Job.Title,
+		Company:     Company{ID: e.Job.Company.ID, Name: e.Job.Company.Name},
+		Location:    e.Job.Location,
+		Remote:      e.Job.Remote,
+		URL:         e.Job.URL,
+		Source:      e.Job.Source,
+		ExternalID:  e.Job.ExternalID,
+		PostedAt:    e.Job.PostedAt.UTC(),
+		Description: e.Job.Description,
+		Score:       e.Job.Score,
+		FetchedAt:   e.Job.FetchedAt.UTC(),
+		Skills:      make([]Skill, 0, len(e.Job.Skills)),
+		Keywords:    e.Keywords,
+	}
+	for _, s := range e.Job.Skills {
+		r.Skills = append(r.Skills, Skill{ID: s.ID, Name: s.Name})
+	}
+	if r.Keywords == nil {
+		r.Keywords = []Keyword{}
+	}
+	return r
+}
+
+// validate checks the fields UpsertJobs uses as MERGE keys
+func (r Record) validate() error {
+	if _, err := uuid.Parse(r.ID); err != nil {
+		return fmt.Errorf("job %q: invalid id", r.ID)
+	}
+	if r.Source == "" || r.ExternalID == "" {
+		return fmt.Errorf("job %s: source and external_id are required", r.ID)
+	}
+	for _, s := range r.Skills {
+		if s.ID == "" {
+			return fmt.Errorf("job %s: skill %q has no id", r.ID, s.Name)
+		}
+	}
+	return nil
+}
+
+func (r Record) job() domain.Job {
+	id, _ := uuid.Parse(r.ID)
+	job := domain.Job{
+		ID:          id,
+		Title:       r.Title,
+		Company:     domain.CompanyRef{ID: r.Company.ID, Name: r.Company.Name},
+		Location:    r.Location,
+		Remote:      r.Remote,
+		URL:         r.URL,
+		Source:      r.Source,
+		ExternalID:  r.ExternalID,
+		PostedAt:    r.PostedAt,
+		Description: r.Description,
+		Score:       r.Score,
+		FetchedAt:   r.FetchedAt,
+		Skills:      make([]domain.SkillRef, 0, len(r.Skills)),
+	}
+	for _, s := range r.Skills {
+		job.Skills = append(job.Skills, domain.SkillRef{ID: s.ID, Name: s.Name})
+	}
+	return job
+}
+
+// keywordRecords groups keywords by source, since PersistKeywords applies one source per record
+func (r Record) keywordRecords() []tools.KeywordRecord {
+	bySource := make(map[string]int)
+	var records []tools.KeywordRecord
+	for _, k := range r.Keywords {
+		i, ok := bySource[k.Source]
+		if !ok {
+			i = len(records)
+			bySource[k.Source] = i
+			records = append(records, tools.KeywordRecord{JobID: r.ID, Source: k.Source})
+		}
+		records[i].Keywords = append(records[i].Keywords, tools.KeywordEntry{
+			Value:      k.Value,
+			Confidence: k.Confidence,
+			Notes:      k.Notes,
+		})
+	}
+	return records
+}
+
+// Exporter writes the job subgraph in a portable format
+type Exporter struct {
+	repo repository.ExportRepository
+}
+
+// NewExporter creates an Exporter
+func NewExporter(repo repository.ExportRepository) *Exporter {
+	return &Exporter{repo: repo}
+}
+
+// Export writes the jobs matching filter to w and returns how many were written
+func (e *Exporter) Export(ctx context.Context, w io.Writer, format string, filter repository.ExportFilter) (int, error) {
+	format, err := ParseFormat(format)
+	if err != nil {
+		return 0, err
+	}
+
+	exported, err := e.repo.ExportJobs(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("read jobs: %w", err)
+	}
+
+	records := make([]Record, 0, len(exported))
+	for _, job := range exported {
+		records = append(records, recordFromExport(job))
+	}
+
+	switch format {
+	case FormatGraphML:
+		err = writeGraphML(w, records)
+	case FormatCypher:
+		err = writeCypher(w, records, identity.OwnerID(ctx))
+	default:
+		err = writeJSONL(w, records)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(records), nil
+}
+
+// ImportStats summarizes an import
+type ImportStats struct {
+	Jobs     int `json:"jobs"`
+	Keywords int `json:"keywords"`
+}
+
+// Importer restores exported jobs through the job and keyword repositories
+type Importer struct {
+	jobs     repository.JobRepository
+	keywords tools.KeywordRepository
+}
+
+// NewImporter creates an Importer
+func NewImporter(jobs repository.JobRepository, keywords tools.KeywordRepository) *Importer {
+	return &Importer{
+		jobs:     jobs,
+		keywords: keywords,
+	}
+}
+
+// Import reads GraphML or JSON Lines from r and upserts it. Re-importing the
+// same data is a no-op apart from refreshed properties. Cypher scripts are
+// run directly with cypher-shell instead
+func (i *Importer) Import(ctx context.Context, r io.Reader, format string) (ImportStats, error) {
+	format, err := ParseFormat(format)
+	if err != nil {
+		return ImportStats{}, err
+	}
+
+	var records []Record
+	switch format {
+	case FormatGraphML:
+		records, err = readGraphML(r)
+	case FormatJSONL:
+		records, err = readJSONL(r)
+	default:
+		return ImportStats{}, fmt.Errorf("cypher scripts are imported with cypher-shell, e.g. cypher-shell -f export.cypher")
+	}
+	if err != nil {
+		return ImportStats{}, err
+	}
+
+	for _, rec := range records {
+		if err := rec.validate(); err != nil {
+			return ImportStats{}, err
+		}
+	}
+
+	var stats ImportStats
+	for start := 0; start < len(records); start += importBatchSize {
+		end := start + importBatchSize
+		if end > len(records) {
+			end = len(records)
+		}
+		batch := records[start:end]
+
+		jobs := make([]domain.Job, 0, len(batch))
+		var keywordRecords []tools.KeywordRecord
+		keywordCount := 0
+		for _, rec := range batch {
+			jobs = append(jobs, rec.job())
+			keywordRecords = append(keywordRecords, rec.keywordRecords()...)
+			keywordCount += len(rec.Keywords)
+		}
+
+		if err := i.jobs.UpsertJobs(ctx, jobs); err != nil {
+			return stats, fmt.Errorf("upsert jobs: %w", err)
+		}
+		if err := i.keywords.PersistKeywords(ctx, keywordRecords); err != nil {
+			return stats, fmt.Errorf("persist keywords: %w", err)
+		}
+		stats.Jobs += len(jobs)
+		stats.Keywords += keywordCount
+	}
+
+	return stats, nil
+}
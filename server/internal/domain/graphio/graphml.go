@@ -0,0 +1,326 @@
+package graphio
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const graphmlNamespace = "http://graphml.graphdrawing.org/xmlns"
+
+type graphmlDoc struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr,omitempty"`
+	Keys    []graphmlKey `xml:"key"`
+	Graph   graphmlGraph `xml:"graph"`
+}
+
+type graphmlKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphmlGraph struct {
+	ID          string        `xml:"id,attr,omitempty"`
+	EdgeDefault string        `xml:"edgedefault,attr,omitempty"`
+	Nodes       []graphmlNode `xml:"node"`
+	Edges       []graphmlEdge `xml:"edge"`
+}
+
+type graphmlNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphmlData `xml:"data"`
+}
+
+type graphmlEdge struct {
+	ID     string        `xml:"id,attr,omitempty"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphmlData `xml:"data"`
+}
+
+type graphmlData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Node keys use the Neo4j property name as id; edge keys are prefixed so the
+// same attribute name can exist on both
+var graphmlKeys = []graphmlKey{
+	{ID: "labels", For: "node", Name: "labels", Type: "string"},
+	{ID: "id", For: "node", Name: "id", Type: "string"},
+	{ID: "title", For: "node", Name: "title", Type: "string"},
+	{ID: "location", For: "node", Name: "location", Type: "string"},
+	{ID: "remote", For: "node", Name: "remote", Type: "boolean"},
+	{ID: "url", For: "node", Name: "url", Type: "string"},
+	{ID: "source", For: "node", Name: "source", Type: "string"},
+	{ID: "externalId", For: "node", Name: "externalId", Type: "string"},
+	{ID: "postedAt", For: "node", Name: "postedAt", Type: "string"},
+	{ID: "description", For: "node", Name: "description", Type: "string"},
+	{ID: "score", For: "node", Name: "score", Type: "double"},
+	{ID: "fetchedAt", For: "node", Name: "fetchedAt", Type: "string"},
+	{ID: "name", For: "node", Name: "name", Type: "string"},
+	{ID: "value", For: "node", Name: "value", Type: "string"},
+	{ID: "notes", For: "node", Name: "notes", Type: "string"},
+	{ID: "label", For: "edge", Name: "label", Type: "string"},
+	{ID: "e_source", For: "edge", Name: "source", Type: "string"},
+	{ID: "e_confidence", For: "edge", Name: "confidence", Type: "double"},
+}
+
+func graphmlTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// writeGraphML writes a directed graph of Job, Company, Skill and Keyword
+// nodes. Shared nodes appear once; node ids are <label>:<key>
+func writeGraphML(w io.Writer, records []Record) error {
+	doc := graphmlDoc{
+		Xmlns: graphmlNamespace,
+		Keys:  graphmlKeys,
+		Graph: graphmlGraph{ID: "project-ets", EdgeDefault: "directed"},
+	}
+
+	seen := make(map[string]bool)
+	addNode := func(id string, data ...graphmlData) {
+		if seen[id] {
+			return
+		}
+		seen[id] = true
+		node := graphmlNode{ID: id}
+		for _, d := range data {
+			if d.Value != "" {
+				node.Data = append(node.Data, d)
+			}
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, node)
+	}
+	addEdge := func(source, target, label string, data ...graphmlData) {
+		edge := graphmlEdge{
+			ID:     fmt.Sprintf("e%d", len(doc.Graph.Edges)),
+			Source: source,
+			Target: target,
+			Data:   []graphmlData{{Key: "label", Value: label}},
+		}
+		for _, d := range data {
+			if d.Value != "" {
+				edge.Data = append(edge.Data, d)
+			}
+		}
+		doc.Graph.Edges = append(doc.Graph.Edges, edge)
+	}
+
+	for _, r := range records {
+		jobNode := "job:" + r.ID
+		addNode(jobNode,
+			graphmlData{Key: "labels", Value: ":Job"},
+			graphmlData{Key: "id", Value: r.ID},
+			graphmlData{Key: "title", Value: r.Title},
+			graphmlData{Key: "location", Value: r.Location},
+			graphmlData{Key: "remote", Value: strconv.FormatBool(r.Remote)},
+			graphmlData{Key: "url", Value: r.URL},
+			graphmlData{Key: "source", Value: r.Source},
+			graphmlData{Key: "externalId", Value: r.ExternalID},
+			graphmlData{Key: "postedAt", Value: graphmlTime(r.PostedAt)},
+			graphmlData{Key: "description", Value: r.Description},
+			graphmlData{Key: "score", Value: strconv.FormatFloat(r.Score, 'g', -1, 64)},
+			graphmlData{Key: "fetchedAt", Value: graphmlTime(r.FetchedAt)},
+		)
+
+		if r.Company.ID != "" {
+			companyNode := "company:" + r.Company.ID
+			addNode(companyNode,
+				graphmlData{Key: "labels", Value: ":Company"},
+				graphmlData{Key: "id", Value: r.Company.ID},
+				graphmlData{Key: "name", Value: r.Company.Name},
+			)
+			addEdge(jobNode, companyNode, "WORKED_AT")
+		}
+
+		for _, s := range r.Skills {
+			skillNode := "skill:" + s.ID
+			addNode(skillNode,
+				graphmlData{Key: "labels", Value: ":Skill"},
+				graphmlData{Key: "id", Value: s.ID},
+				graphmlData{Key: "name", Value: s.Name},
+			)
+			addEdge(jobNode, skillNode, "REQUIRES")
+		}
+
+		for _, k := range r.Keywords {
+			keywordNode := "keyword:" + k.Value
+			addNode(keywordNode,
+				graphmlData{Key: "labels", Value: ":Keyword"},
+				graphmlData{Key: "value", Value: k.Value},
+				graphmlData{Key: "notes", Value: k.Notes},
+			)
+			confidence := ""
+			if k.Confidence > 0 {
+				confidence = strconv.FormatFloat(k.Confidence, 'g', -1, 64)
+			}
+			addEdge(jobNode, keywordNode, "HAS_KEYWORD",
+				graphmlData{Key: "e_source", Value: k.Source},
+				graphmlData{Key: "e_confidence", Value: confidence},
+			)
+		}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encode graphml: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// readGraphML rebuilds job records from a GraphML document. Keys are resolved
+// by attribute name, so files edited in other tools import as long as the
+// property names are kept
+func readGraphML(r io.Reader) ([]Record, error) {
+	var doc graphmlDoc
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decode graphml: %w", err)
+	}
+
+	nodeKeys := make(map[string]string)
+	edgeKeys := make(map[string]string)
+	for _, k := range doc.Keys {
+		name := k.Name
+		if name == "" {
+			name = k.ID
+		}
+		switch k.For {
+		case "edge":
+			edgeKeys[k.ID] = name
+		default:
+			nodeKeys[k.ID] = name
+		}
+	}
+
+	props := func(keys map[string]string, data []graphmlData) map[string]string {
+		out := make(map[string]string, len(data))
+		for _, d := range data {
+			name, ok := keys[d.Key]
+			if !ok {
+				name = d.Key
+			}
+			out[name] = strings.TrimSpace(d.Value)
+		}
+		return out
+	}
+
+	nodes := make(map[string]map[string]string, len(doc.Graph.Nodes))
+	jobs := make(map[string]*Record)
+	var order []string
+	for _, n := range doc.Graph.Nodes {
+		p := props(nodeKeys, n.Data)
+		nodes[n.ID] = p
+		if !hasLabel(p["labels"], "Job") {
+			continue
+		}
+
+		rec := &Record{
+			ID:          p["id"],
+			Title:       p["title"],
+			Location:    p["location"],
+			URL:         p["url"],
+			Source:      p["source"],
+			ExternalID:  p["externalId"],
+			Description: p["description"],
+			Skills:      []Skill{},
+			Keywords:    []Keyword{},
+		}
+		var err error
+		if rec.Remote, err = parseOptionalBool(p["remote"]); err != nil {
+			return nil, fmt.Errorf("node %s: remote: %w", n.ID, err)
+		}
+		if rec.Score, err = parseOptionalFloat(p["score"]); err != nil {
+			return nil, fmt.Errorf("node %s: score: %w", n.ID, err)
+		}
+		if rec.PostedAt, err = parseOptionalTime(p["postedAt"]); err != nil {
+			return nil, fmt.Errorf("node %s: postedAt: %w", n.ID, err)
+		}
+		if rec.FetchedAt, err = parseOptionalTime(p["fetchedAt"]); err != nil {
+			return nil, fmt.Errorf("node %s: fetchedAt: %w", n.ID, err)
+		}
+		jobs[n.ID] = rec
+		order = append(order, n.ID)
+	}
+
+	for _, e := range doc.Graph.Edges {
+		rec, ok := jobs[e.Source]
+		if !ok {
+			continue
+		}
+		target, ok := nodes[e.Target]
+		if !ok {
+			return nil, fmt.Errorf("edge %s: unknown target node %q", e.ID, e.Target)
+		}
+		p := props(edgeKeys, e.Data)
+
+		switch p["label"] {
+		case "WORKED_AT":
+			rec.Company = Company{ID: target["id"], Name: target["name"]}
+		case "REQUIRES":
+			rec.Skills = append(rec.Skills, Skill{ID: target["id"], Name: target["name"]})
+		case "HAS_KEYWORD":
+			confidence, err := parseOptionalFloat(p["confidence"])
+			if err != nil {
+				return nil, fmt.Errorf("edge %s: confidence: %w", e.ID, err)
+			}
+			rec.Keywords = append(rec.Keywords, Keyword{
+				Value:      target["value"],
+				Notes:      target["notes"],
+				Source:     p["source"],
+				Confidence: confidence,
+			})
+		}
+	}
+
+	records := make([]Record, 0, len(order))
+	for _, id := range order {
+		records = append(records, *jobs[id])
+	}
+	return records, nil
+}
+
+func hasLabel(labels, label string) bool {
+	for _, l := range strings.Split(labels, ":") {
+		if strings.TrimSpace(l) == label {
+			return true
+		}
+	}
+	return false
+}
+
+func parseOptionalBool(s string) (bool, error) {
+	if s == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(s)
+}
+
+func parseOptionalFloat(s string) (float64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+func parseOptionalTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
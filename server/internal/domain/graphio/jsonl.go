@@ -0,0 +1,34 @@
+package graphio
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// writeJSONL writes one job record per line
+func writeJSONL(w io.Writer, records []Record) error {
+	enc := json.NewEncoder(w)
+	for _, rec := range records {
+		if err := enc.Encode(rec); err != nil {
+			return fmt.Errorf("encode job %s: %w", rec.ID, err)
+		}
+	}
+	return nil
+}
+
+func readJSONL(r io.Reader) ([]Record, error) {
+	dec := json.NewDecoder(r)
+	var records []Record
+	for line := 1; ; line++ {
+		var rec Record
+		if err := dec.Decode(&rec); err != nil {
+			if errors.Is(err, io.EOF) {
+				return records, nil
+			}
+			return nil, fmt.Errorf("record %d: %w", line, err)
+		}
+		records = append(records, rec)
+	}
+}
@@ -0,0 +1,45 @@
+// Package graphschema caches the introspected graph schema served to clients
+package graphschema
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/honeycarbs/project-ets/internal/repository"
+)
+
+// Cache holds the last schema snapshot and reloads it after ttl or on demand
+type Cache struct {
+	repo repository.SchemaRepository
+	ttl  time.Duration
+
+	mu     sync.Mutex
+	schema *repository.GraphSchema
+}
+
+// NewCache creates a schema cache; a zero ttl keeps the snapshot until refreshed
+func NewCache(repo repository.SchemaRepository, ttl time.Duration) *Cache {
+	return &Cache{
+		repo: repo,
+		ttl:  ttl,
+	}
+}
+
+// Schema returns the cached schema, reloading it when refresh is set, nothing
+// is cached yet or the snapshot is older than the ttl
+func (c *Cache) Schema(ctx context.Context, refresh bool) (repository.GraphSchema, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !refresh && c.schema != nil && (c.ttl <= 0 || time.Since(c.schema.FetchedAt) < c.ttl) {
+		return *c.schema, nil
+	}
+
+	schema, err := c.repo.GetGraphSchema(ctx)
+	if err != nil {
+		return repository.GraphSchema{}, err
+	}
+	c.schema = &schema
+	return schema, nil
+}
@@ -0,0 +1,208 @@
+// Package history lists stored versions of a job posting and diffs them
+package history
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+	"github.com/honeycarbs/project-ets/internal/repository"
+)
+
+// maxDiffSegments bounds the LCS table for very long descriptions
+const maxDiffSegments = 2000
+
+// Service implements tools.JobHistoryService
+type Service struct {
+	repo repository.SnapshotRepository
+}
+
+// NewService creates a history service
+func NewService(repo repository.SnapshotRepository) *Service {
+	return &Service{repo: repo}
+}
+
+// JobHistory lists a job's versions and compares two of them. Without
+// explicit versions the latest is compared with the one before it
+func (s *Service) JobHistory(ctx context.Context, params tools.JobHistoryParams) (tools.JobHistoryResult, error) {
+	jobID := strings.TrimSpace(params.JobID)
+	job, snapshots, found, err := s.repo.ListSnapshots(ctx, jobID)
+	if err != nil {
+		return tools.JobHistoryResult{}, fmt.Errorf("list snapshots: %w", err)
+	}
+	if !found {
+		return tools.JobHistoryResult{}, fmt.Errorf("job %s not found", jobID)
+	}
+
+	result := tools.JobHistoryResult{
+		JobID:    jobID,
+		Title:    job.Title,
+		Company:  job.Company.Name,
+		Versions: make([]tools.JobVersion, 0, len(snapshots)),
+	}
+	for i, snap := range snapshots {
+		result.Versions = append(result.Versions, tools.JobVersion{
+			Version:    i + 1,
+			Hash:       snap.Hash,
+			CapturedAt: snap.CapturedAt,
+			Title:      snap.Title,
+			Location:   snap.Location,
+			Score:      snap.Score,
+			Words:      len(strings.Fields(snap.Description)),
+		})
+	}
+
+	if len(snapshots) < 2 {
+		if params.From != 0 || params.To != 0 {
+			return tools.JobHistoryResult{}, fmt.Errorf("job %s has %d version(s); nothing to compare", jobID, len(snapshots))
+		}
+		return result, nil
+	}
+
+	from, to, err := selectVersions(params.From, params.To, len(snapshots))
+	if err != nil {
+		return tools.JobHistoryResult{}, err
+	}
+
+	older, newer := snapshots[from-1], snapshots[to-1]
+	result.Diff = &tools.JobVersionDiff{
+		From:   from,
+		To:     to,
+		Fields: diffFields(older, newer),
+	}
+	if normalize(older.Description) != normalize(newer.Description) {
+		result.Diff.Description = diffText(older.Description, newer.Description)
+	}
+
+	return result, nil
+}
+
+// selectVersions resolves the requested versions, defaulting to the latest
+// and the one before it
+func selectVersions(from, to, count int) (int, int, error) {
+	if to == 0 {
+		to = count
+	}
+	if from == 0 {
+		from = to - 1
+		if from < 1 {
+			from = 1
+		}
+	}
+	if from < 1 || from > count || to < 1 || to > count {
+		return 0, 0, fmt.Errorf("versions must be between 1 and %d", count)
+	}
+	if from == to {
+		return 0, 0, fmt.Errorf("from and to must be different versions")
+	}
+	return from, to, nil
+}
+
+func diffFields(older, newer domain.JobSnapshot) []tools.FieldChange {
+	changes := []tools.FieldChange{}
+	add := func(field, a, b string) {
+		if a != b {
+			changes = append(changes, tools.FieldChange{Field: field, From: a, To: b})
+		}
+	}
+	add("title", older.Title, newer.Title)
+	add("location", older.Location, newer.Location)
+	add("remote", strconv.FormatBool(older.Remote), strconv.FormatBool(newer.Remote))
+	add("url", older.URL, newer.URL)
+	add("salary", formatScore(older.Score), formatScore(newer.Score))
+	return changes
+}
+
+func formatScore(score float64) string {
+	if score == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(score, 'f', -1, 64)
+}
+
+func normalize(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// diffText compares descriptions sentence by sentence using the longest
+// common subsequence, so reflowed whitespace does not show up as a change
+func diffText(a, b string) []tools.DiffLine {
+	as, bs := segments(a), segments(b)
+	if len(as) > maxDiffSegments || len(bs) > maxDiffSegments {
+		lines := make([]tools.DiffLine, 0, len(as)+len(bs))
+		for _, seg := range as {
+			lines = append(lines, tools.DiffLine{Op: tools.DiffDelete, Text: seg})
+		}
+		for _, seg := range bs {
+			lines = append(lines, tools.DiffLine{Op: tools.DiffInsert, Text: seg})
+		}
+		return lines
+	}
+
+	// lcs[i][j] is the LCS length of as[i:] and bs[j:]
+	lcs := make([][]int, len(as)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(bs)+1)
+	}
+	for i := len(as) - 1; i >= 0; i-- {
+		for j := len(bs) - 1; j >= 0; j-- {
+			if as[i] == bs[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	lines := make([]tools.DiffLine, 0, len(as)+len(bs))
+	i, j := 0, 0
+	for i < len(as) && j < len(bs) {
+		switch {
+		case as[i] == bs[j]:
+			lines = append(lines, tools.DiffLine{Op: tools.DiffEqual, Text: as[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, tools.DiffLine{Op: tools.DiffDelete, Text: as[i]})
+			i++
+		default:
+			lines = append(lines, tools.DiffLine{Op: tools.DiffInsert, Text: bs[j]})
+			j++
+		}
+	}
+	for ; i < len(as); i++ {
+		lines = append(lines, tools.DiffLine{Op: tools.DiffDelete, Text: as[i]})
+	}
+	for ; j < len(bs); j++ {
+		lines = append(lines, tools.DiffLine{Op: tools.DiffInsert, Text: bs[j]})
+	}
+	return lines
+}
+
+// segments splits text into lines, and lines into sentences, with
+// whitespace collapsed
+func segments(text string) []string {
+	var out []string
+	for _, line := range strings.Split(text, "\n") {
+		line = normalize(line)
+		start := 0
+		for i := 0; i < len(line); i++ {
+			switch line[i] {
+			case '.', '!', '?':
+				if i+1 < len(line) && line[i+1] == ' ' {
+					out = append(out, line[start:i+1])
+					start = i + 2
+				}
+			}
+		}
+		if start < len(line) {
+			out = append(out, line[start:])
+		}
+	}
+	return out
+}
@@ -14,3 +14,16 @@ type Provider interface {
 	// Search returns normalized jobs for a query
 	Search(ctx context.Context, query string, filters domain.JobSearchFilters) ([]domain.Job, error)
 }
+
+// StatusChecker is implemented by providers that can tell whether a stored
+// posting is still listed. It returns one of the domain.JobStatus values;
+// domain.JobStatusUnknown when the provider cannot tell
+type StatusChecker interface {
+	CheckStatus(ctx context.Context, job domain.Job) (string, error)
+}
+
+// RefreshRecorder is told which postings a provider returned for a query, so
+// postings that stop appearing can be marked missing
+type RefreshRecorder interface {
+	RecordRefresh(ctx context.Context, source, query string, filters domain.JobSearchFilters, externalIDs []string) error
+}
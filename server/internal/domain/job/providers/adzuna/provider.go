@@ -3,7 +3,6 @@ package adzuna
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/google/uuid"
 
@@ -15,6 +14,7 @@ import (
 // searchClient describes the subset of the Adzuna client used by the provider.
 type searchClient interface {
 	SearchJobs(ctx context.Context, query string, params adzuna.SearchParams) ([]adzuna.Job, error)
+	CheckPosting(ctx context.Context, postingURL string) (adzuna.PostingState, error)
 }
 
 // Provider implements job.Provider using Adzuna API
@@ -56,20 +56,20 @@ func (p *Provider) Search(ctx context.Context, query string, filters domain.JobS
 	for _, j := range respJobs {
 		jobID, err := uuid.Parse(j.ID)
 		if err != nil {
-			jobID = uuid.New()
+			jobID = domain.StableJobID(p.Name(), j.ID)
 		}
 
 		out = append(out, domain.Job{
 			ID:    jobID,
 			Title: j.Title,
 			Company: domain.CompanyRef{
-				ID:   slugify(j.CompanyName),
+				ID:   domain.CompanyID(j.CompanyName),
 				Name: j.CompanyName,
 			},
 			Location:    j.Location,
 			Remote:      j.Remote,
 			URL:         j.URL,
-			Source:      "adzuna",
+			Source:      p.Name(),
 			ExternalID:  j.ID,
 			Description: j.Description,
 			Score:       j.SalaryMax,
@@ -81,12 +81,28 @@ func (p *Provider) Search(ctx context.Context, query string, filters domain.JobS
 	return out, nil
 }
 
-var _ jobdomain.Provider = (*Provider)(nil)
+// CheckStatus reports whether a stored posting's landing page is still up
+func (p *Provider) CheckStatus(ctx context.Context, job domain.Job) (string, error) {
+	if p == nil || p.client == nil {
+		return domain.JobStatusUnknown, fmt.Errorf("adzuna provider: client is nil")
+	}
 
-func slugify(s string) string {
-	if s == "" {
-		return ""
+	state, err := p.client.CheckPosting(ctx, job.URL)
+	if err != nil {
+		return domain.JobStatusUnknown, err
+	}
+
+	switch state {
+	case adzuna.PostingLive:
+		return domain.JobStatusOpen, nil
+	case adzuna.PostingGone:
+		return domain.JobStatusClosed, nil
+	default:
+		return domain.JobStatusUnknown, nil
 	}
-	s = strings.ToLower(strings.TrimSpace(s))
-	return strings.ReplaceAll(s, " ", "-")
 }
+
+var (
+	_ jobdomain.Provider      = (*Provider)(nil)
+	_ jobdomain.StatusChecker = (*Provider)(nil)
+)
@@ -0,0 +1,50 @@
+package adzuna
+
+import (
+	"context"
+	"testing"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/pkg/adzuna"
+)
+
+type fakeClient struct {
+	jobs []adzuna.Job
+}
+
+func (c fakeClient) SearchJobs(context.Context, string, adzuna.SearchParams) ([]adzuna.Job, error) {
+	return c.jobs, nil
+}
+
+func (c fakeClient) CheckPosting(context.Context, string) (adzuna.PostingState, error) {
+	return adzuna.PostingUnknown, nil
+}
+
+func TestSearchKeepsJobIDsAcrossRefreshes(t *testing.T) {
+	provider, err := NewProvider(fakeClient{jobs: []adzuna.Job{
+		{ID: "4812345678", Title: "Go Developer"},
+		{ID: "4812345679", Title: "SRE"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := provider.Search(context.Background(), "go", domain.JobSearchFilters{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := provider.Search(context.Background(), "go", domain.JobSearchFilters{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first[0].ID != second[0].ID || first[1].ID != second[1].ID {
+		t.Errorf("IDs changed between refreshes: %v, %v then %v, %v", first[0].ID, first[1].ID, second[0].ID, second[1].ID)
+	}
+	if first[0].ID == first[1].ID {
+		t.Errorf("different postings share ID %v", first[0].ID)
+	}
+	if want := domain.StableJobID("adzuna", "4812345678"); first[0].ID != want {
+		t.Errorf("ID = %v, want %v", first[0].ID, want)
+	}
+}
@@ -19,6 +19,7 @@ type Option func(*config)
 type config struct {
 	providers []Provider
 	repo      Repository
+	recorder  RefreshRecorder
 	clock     func() time.Time
 }
 
@@ -36,6 +37,13 @@ func WithRepository(repo Repository) Option {
 	}
 }
 
+// WithRefreshRecorder sets the recorder notified of each provider's results
+func WithRefreshRecorder(recorder RefreshRecorder) Option {
+	return func(c *config) {
+		c.recorder = recorder
+	}
+}
+
 // WithClock sets a custom clock
 func WithClock(clock func() time.Time) Option {
 	return func(c *config) {
@@ -62,12 +70,14 @@ func NewService(opts ...Option) (Service, error) {
 	return &service{
 		providers: cfg.providers,
 		repo:      cfg.repo,
+		recorder:  cfg.recorder,
 		clock:     cfg.clock,
 	}, nil
 }
 
-// NewServiceWithDeps creates a Service with direct dependencies (Wire-compatible)
-func NewServiceWithDeps(repo Repository, providers []Provider) (Service, error) {
+// NewServiceWithDeps creates a Service with direct dependencies (Wire-compatible).
+// recorder may be nil
+func NewServiceWithDeps(repo Repository, providers []Provider, recorder RefreshRecorder) (Service, error) {
 	if repo == nil {
 		return nil, fmt.Errorf("job.Service: repository is required")
 	}
@@ -78,6 +88,7 @@ func NewServiceWithDeps(repo Repository, providers []Provider) (Service, error)
 	return &service{
 		providers: providers,
 		repo:      repo,
+		recorder:  recorder,
 		clock:     time.Now,
 	}, nil
 }
@@ -85,6 +96,7 @@ func NewServiceWithDeps(repo Repository, providers []Provider) (Service, error)
 type service struct {
 	providers []Provider
 	repo      Repository
+	recorder  RefreshRecorder
 	clock     func() time.Time
 }
 
@@ -106,6 +118,7 @@ func (s *service) Search(
 	}
 	dedup := make(map[key]domain.Job)
 	sourceCount := 0
+	returned := make(map[string][]string)
 
 	for _, p := range s.providers {
 		jobs, err := p.Search(ctx, query, filters)
@@ -115,15 +128,17 @@ func (s *service) Search(
 		if len(jobs) > 0 {
 			sourceCount++
 		}
+		returned[p.Name()] = []string{}
 
 		for _, j := range jobs {
 			if j.Source == "" || j.ExternalID == "" {
 				continue
 			}
 			k := key{source: j.Source, externalID: j.ExternalID}
+			returned[p.Name()] = append(returned[p.Name()], j.ExternalID)
 
 			if j.ID == uuid.Nil {
-				j.ID = uuid.New()
+				j.ID = domain.StableJobID(j.Source, j.ExternalID)
 			}
 			if j.FetchedAt.IsZero() {
 				j.FetchedAt = now
@@ -144,8 +159,37 @@ func (s *service) Search(
 		}
 	}
 
+	// Refresh tracking is best effort, like the provider calls above. Only
+	// providers that answered are recorded; a failed call says nothing about
+	// which postings are gone
+	if s.recorder != nil {
+		for source, externalIDs := range returned {
+			_ = s.recorder.RecordRefresh(ctx, source, query, filters, externalIDs)
+		}
+	}
+
+	closed := make(map[domain.JobID]bool)
+	if !filters.IncludeClosed && len(allJobs) > 0 {
+		ids := make([]domain.JobID, 0, len(allJobs))
+		for _, j := range allJobs {
+			ids = append(ids, j.ID)
+		}
+		stored, err := s.repo.FindByIDs(ctx, ids)
+		if err != nil {
+			return domain.JobSearchResult{}, err
+		}
+		for _, j := range stored {
+			if j.Closed() {
+				closed[j.ID] = true
+			}
+		}
+	}
+
 	summaries := make([]domain.JobSummary, 0, len(allJobs))
 	for _, j := range allJobs {
+		if closed[j.ID] {
+			continue
+		}
 		summaries = append(summaries, domain.JobSummary{
 			ID:       j.ID,
 			Title:    j.Title,
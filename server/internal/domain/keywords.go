@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// keywordAliases folds common spellings of the same term onto one canonical value
+var keywordAliases = map[string]string{
+	"golang":              "go",
+	"k8s":                 "kubernetes",
+	"js":                  "javascript",
+	"ts":                  "typescript",
+	"postgres":            "postgresql",
+	"nodejs":              "node.js",
+	"node":                "node.js",
+	"reactjs":             "react",
+	"react.js":            "react",
+	"py":                  "python",
+	"ml":                  "machine learning",
+	"ai":                  "artificial intelligence",
+	"gcp":                 "google cloud",
+	"aws cloud":           "aws",
+	"amazon web services": "aws",
+}
+
+// KeywordTokens splits text into lowercase tokens, keeping symbols that are
+// part of technology names (c++, c#, node.js). Aliases are not applied: in
+// free text a token such as "node" or "ai" is often an ordinary word
+func KeywordTokens(s string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#' && r != '.'
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, f := range fields {
+		f = strings.TrimRight(f, ".")
+		if f == "" {
+			continue
+		}
+		tokens = append(tokens, f)
+	}
+	return tokens
+}
+
+// NormalizeKeyword returns the canonical form a keyword or skill is stored and
+// matched under: its tokens, folded onto the canonical value only when the
+// whole keyword is a known alias
+func NormalizeKeyword(s string) string {
+	normalized := strings.Join(KeywordTokens(s), " ")
+	if alias, ok := keywordAliases[normalized]; ok {
+		return alias
+	}
+	return normalized
+}
+
+// SkillID derives the Skill node identifier from a skill name
+func SkillID(name string) string {
+	return strings.ReplaceAll(NormalizeKeyword(name), " ", "-")
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNormalizeKeyword(t *testing.T) {
+	cases := []struct {
+		in, want string
+	}{
+		{"Go", "go"},
+		{"Golang", "go"},
+		{"  K8s ", "kubernetes"},
+		{"Node", "node.js"},
+		{"NodeJS", "node.js"},
+		{"ML", "machine learning"},
+		{"Amazon Web Services", "aws"},
+		{"C++", "c++"},
+		{"C#, .NET", "c# .net"},
+		{"Node.js.", "node.js"},
+		// Aliases apply to the whole keyword only
+		{"graph node", "graph node"},
+		{"AI safety", "ai safety"},
+		{"ts-node", "ts node"},
+		{"py spark", "py spark"},
+		{"", ""},
+		{" - ", ""},
+	}
+	for _, tc := range cases {
+		if got := NormalizeKeyword(tc.in); got != tc.want {
+			t.Errorf("NormalizeKeyword(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestKeywordTokens(t *testing.T) {
+	cases := []struct {
+		in   string
+		want []string
+	}{
+		{"Built services in Go and Node", []string{"built", "services", "in", "go", "and", "node"}},
+		{"ML/AI, TS and py.", []string{"ml", "ai", "ts", "and", "py"}},
+		{"C++ & C# on Node.js.", []string{"c++", "c#", "on", "node.js"}},
+	}
+	for _, tc := range cases {
+		if got := KeywordTokens(tc.in); !slices.Equal(got, tc.want) {
+			t.Errorf("KeywordTokens(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestSkillID(t *testing.T) {
+	if got := SkillID("Machine Learning"); got != "machine-learning" {
+		t.Errorf("SkillID = %q, want machine-learning", got)
+	}
+	if got := SkillID("ML"); got != "machine-learning" {
+		t.Errorf("SkillID(ML) = %q, want machine-learning", got)
+	}
+}
@@ -0,0 +1,191 @@
+// Package lifecycle tracks whether stored postings are still listed, from
+// refresh searches that stop returning them and from provider re-checks
+package lifecycle
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/domain/job"
+	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+	"github.com/honeycarbs/project-ets/internal/repository"
+)
+
+const (
+	defaultRecheckLimit = 25
+	maxRecheckLimit     = 200
+	// DefaultCloseAfterMisses is used when no threshold is configured
+	DefaultCloseAfterMisses = 3
+)
+
+// Service implements job.RefreshRecorder and tools.LifecycleService
+type Service struct {
+	repo       repository.LifecycleRepository
+	checkers   map[string]job.StatusChecker
+	closeAfter int
+	clock      func() time.Time
+}
+
+// NewService creates a lifecycle service. Providers that implement
+// job.StatusChecker are used for re-checks; closeAfter is the number of
+// consecutive refresh misses after which a job is closed
+func NewService(repo repository.LifecycleRepository, providers []job.Provider, closeAfter int) *Service {
+	if closeAfter <= 0 {
+		closeAfter = DefaultCloseAfterMisses
+	}
+	checkers := make(map[string]job.StatusChecker)
+	for _, p := range providers {
+		if checker, ok := p.(job.StatusChecker); ok {
+			checkers[p.Name()] = checker
+		}
+	}
+	return &Service{
+		repo:       repo,
+		checkers:   checkers,
+		closeAfter: closeAfter,
+		clock:      time.Now,
+	}
+}
+
+// RecordRefresh marks postings that source no longer returns for the query
+func (s *Service) RecordRefresh(ctx context.Context, source, query string, filters domain.JobSearchFilters, externalIDs []string) error {
+	_, err := s.repo.RecordRefresh(ctx, repository.Refresh{
+		Source:      source,
+		QueryKey:    refreshKey(query, filters),
+		ExternalIDs: externalIDs,
+		At:          s.clock().UTC(),
+		CloseAfter:  s.closeAfter,
+	})
+	if err != nil {
+		return fmt.Errorf("record refresh: %w", err)
+	}
+	return nil
+}
+
+// Recheck asks each job's provider whether the posting is still listed
+func (s *Service) Recheck(ctx context.Context, params tools.JobRecheckParams) (tools.JobRecheckResult, error) {
+	statuses, err := normalizeStatuses(params.Statuses)
+	if err != nil {
+		return tools.JobRecheckResult{}, err
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultRecheckLimit
+	}
+	if limit > maxRecheckLimit {
+		limit = maxRecheckLimit
+	}
+
+	filter := repository.StatusFilter{JobIDs: params.JobIDs, Limit: limit}
+	if len(params.JobIDs) == 0 {
+		filter.Statuses = statuses
+	}
+
+	jobs, err := s.repo.ListJobsByStatus(ctx, filter)
+	if err != nil {
+		return tools.JobRecheckResult{}, fmt.Errorf("list jobs: %w", err)
+	}
+
+	now := s.clock().UTC()
+	result := tools.JobRecheckResult{
+		Changes:   []tools.JobStatusChange{},
+		CheckedAt: now,
+	}
+
+	var updates []repository.StatusUpdate
+	for _, j := range jobs {
+		checker, ok := s.checkers[j.Source]
+		if !ok {
+			result.Unsupported++
+			continue
+		}
+
+		status, err := checker.CheckStatus(ctx, j)
+		if err != nil {
+			result.Failed++
+			continue
+		}
+		result.Checked++
+
+		switch status {
+		case domain.JobStatusOpen:
+			result.Open++
+		case domain.JobStatusClosed:
+			result.Closed++
+		default:
+			// An inconclusive answer leaves the stored status alone
+			result.Unknown++
+			continue
+		}
+
+		updates = append(updates, repository.StatusUpdate{JobID: j.ID.String(), Status: status, At: now})
+
+		previous := j.Status
+		if previous == "" {
+			previous = domain.JobStatusOpen
+		}
+		if previous != status {
+			result.Changes = append(result.Changes, tools.JobStatusChange{
+				JobID:   j.ID.String(),
+				Title:   j.Title,
+				Company: j.Company.Name,
+				From:    previous,
+				To:      status,
+			})
+		}
+	}
+
+	if err := s.repo.SetJobStatuses(ctx, updates); err != nil {
+		return tools.JobRecheckResult{}, fmt.Errorf("set job statuses: %w", err)
+	}
+
+	return result, nil
+}
+
+func normalizeStatuses(statuses []string) ([]string, error) {
+	if len(statuses) == 0 {
+		return []string{domain.JobStatusOpen, domain.JobStatusUnknown}, nil
+	}
+	out := make([]string, 0, len(statuses))
+	for _, st := range statuses {
+		switch st = strings.ToLower(strings.TrimSpace(st)); st {
+		case domain.JobStatusOpen, domain.JobStatusClosed, domain.JobStatusUnknown:
+			out = append(out, st)
+		default:
+			return nil, fmt.Errorf("unsupported status %q (use open, closed or unknown)", st)
+		}
+	}
+	return out, nil
+}
+
+// refreshKey identifies a search so that repeating it can be compared with
+// earlier runs. Skill order and letter case do not change the key
+func refreshKey(query string, filters domain.JobSearchFilters) string {
+	skills := make([]string, 0, len(filters.Skills))
+	for _, skill := range filters.Skills {
+		skills = append(skills, strings.ToLower(strings.TrimSpace(skill)))
+	}
+	sort.Strings(skills)
+
+	remote := ""
+	if filters.Remote != nil {
+		remote = strconv.FormatBool(*filters.Remote)
+	}
+
+	parts := []string{
+		strings.ToLower(strings.TrimSpace(query)),
+		strings.ToLower(strings.TrimSpace(filters.Location)),
+		remote,
+		strings.Join(skills, ","),
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:8])
+}
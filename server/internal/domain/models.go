@@ -9,6 +9,15 @@ import (
 // JobID uniquely identifies a job
 type JobID = uuid.UUID
 
+// jobIDNamespace scopes the name-based UUIDs made by StableJobID
+var jobIDNamespace = uuid.MustParse("3cda0b6f-dc93-4f21-9379-1d69f0db43b6")
+
+// StableJobID derives a job ID from the posting's source and external ID,
+// so every fetch of the same posting gets the ID it was first stored with
+func StableJobID(source, externalID string) JobID {
+	return uuid.NewSHA1(jobIDNamespace, []byte(source+":"+externalID))
+}
+
 // CompanyRef references a company
 type CompanyRef struct {
 	ID   string
@@ -21,6 +30,13 @@ type SkillRef struct {
 	Name string
 }
 
+// Job lifecycle statuses
+const (
+	JobStatusOpen    = "open"
+	JobStatusClosed  = "closed"
+	JobStatusUnknown = "unknown"
+)
+
 // Job is the normalized job posting entity
 type Job struct {
 	ID          JobID
@@ -36,13 +52,26 @@ type Job struct {
 	Skills      []SkillRef
 	Score       float64
 	FetchedAt   time.Time
+	// Posting history across upserts of the same (source, externalId)
+	FirstSeenAt time.Time
+	LastSeenAt  time.Time
+	SeenCount   int
+	// Lifecycle; Status is empty for jobs stored before tracking began
+	Status   string
+	ClosedAt time.Time
+}
+
+// Closed reports whether the posting is known to be gone
+func (j Job) Closed() bool {
+	return j.Status == JobStatusClosed
 }
 
 // JobSearchFilters describe allowed job query filters
 type JobSearchFilters struct {
-	Location string
-	Remote   *bool
-	Skills   []string
+	Location      string
+	Remote        *bool
+	Skills        []string
+	IncludeClosed bool
 }
 
 // JobSummary is the response-friendly job view
@@ -63,3 +92,50 @@ type JobSearchResult struct {
 	FetchedAt   time.Time
 	SourceCount int
 }
+
+// ExperienceEntry is a single position on a candidate's resume
+type ExperienceEntry struct {
+	Title       string   `json:"title"`
+	Company     string   `json:"company,omitempty"`
+	Start       string   `json:"start,omitempty"`
+	End         string   `json:"end,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Skills      []string `json:"skills,omitempty"`
+}
+
+// CandidatePreferences describe what a candidate is looking for
+type CandidatePreferences struct {
+	Locations   []string
+	SalaryFloor float64
+	Remote      *bool
+}
+
+// Candidate is a persisted job seeker profile
+type Candidate struct {
+	ID          string
+	Name        string
+	Headline    string
+	Summary     string
+	Skills      []SkillRef
+	Experience  []ExperienceEntry
+	Preferences CandidatePreferences
+	UpdatedAt   time.Time
+}
+
+// SavedQueryParam describes a typed parameter of a saved query
+type SavedQueryParam struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"` // string, int, float, bool, datetime or list
+	Required    bool   `json:"required,omitempty"`
+	Default     any    `json:"default,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// SavedQuery is a named, parameterized Cypher template
+type SavedQuery struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Cypher      string            `json:"cypher"`
+	Params      []SavedQueryParam `json:"params,omitempty"`
+	Source      string            `json:"source,omitempty"` // builtin, file or graph
+}
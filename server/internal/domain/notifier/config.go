@@ -0,0 +1,154 @@
+package notifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/honeycarbs/project-ets/pkg/notify"
+)
+
+// Channel types
+const (
+	ChannelWebhook = "webhook"
+	ChannelSlack   = "slack"
+	ChannelEmail   = "email"
+)
+
+// FileConfig is the JSON notification config. Values may reference
+// environment variables as ${NAME} so secrets stay out of the file
+type FileConfig struct {
+	// Default lists the channels used by saved searches that name none
+	Default  []string        `json:"default"`
+	SMTP     SMTPFileConfig  `json:"smtp"`
+	Channels []ChannelConfig `json:"channels"`
+}
+
+// SMTPFileConfig is the mail server shared by email channels
+type SMTPFileConfig struct {
+	Host        string `json:"host"`
+	Port        int    `json:"port"`
+	Username    string `json:"username"`
+	Password    string `json:"password"`
+	From        string `json:"from"`
+	ImplicitTLS bool   `json:"implicit_tls"`
+}
+
+// ChannelConfig defines one named destination
+type ChannelConfig struct {
+	Name    string            `json:"name"`
+	Type    string            `json:"type"`              // webhook, slack or email
+	URL     string            `json:"url,omitempty"`     // webhook and slack
+	Headers map[string]string `json:"headers,omitempty"` // webhook
+	To      []string          `json:"to,omitempty"`      // email
+	From    string            `json:"from,omitempty"`    // email, overrides smtp.from
+	// Subject and Body override the default digest templates (text/template
+	// over Digest)
+	Subject string `json:"subject,omitempty"`
+	Body    string `json:"body,omitempty"`
+}
+
+// Channel is a configured destination with its templates
+type Channel struct {
+	Name    string
+	Type    string
+	Sink    notify.Sink
+	Subject *template.Template
+	Body    *template.Template
+}
+
+// LoadFile reads and builds the channels of a notification config file
+func LoadFile(path string) ([]Channel, []string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read notification config: %w", err)
+	}
+
+	var cfg FileConfig
+	if err := json.Unmarshal([]byte(os.ExpandEnv(string(data))), &cfg); err != nil {
+		return nil, nil, fmt.Errorf("notification config %s: %w", path, err)
+	}
+
+	channels, err := BuildChannels(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("notification config %s: %w", path, err)
+	}
+	return channels, cfg.Default, nil
+}
+
+// BuildChannels validates the config and creates a sink per channel
+func BuildChannels(cfg FileConfig) ([]Channel, error) {
+	names := make(map[string]bool, len(cfg.Channels))
+	channels := make([]Channel, 0, len(cfg.Channels))
+	for _, c := range cfg.Channels {
+		name := strings.TrimSpace(c.Name)
+		if name == "" {
+			return nil, fmt.Errorf("channel name is required")
+		}
+		if names[name] {
+			return nil, fmt.Errorf("duplicate channel %q", name)
+		}
+		names[name] = true
+
+		channel := Channel{Name: name, Type: c.Type}
+		switch c.Type {
+		case ChannelWebhook:
+			if c.URL == "" {
+				return nil, fmt.Errorf("channel %q: url is required", name)
+			}
+			channel.Sink = notify.NewWebhookSink(c.URL, c.Headers, nil)
+		case ChannelSlack:
+			if c.URL == "" {
+				return nil, fmt.Errorf("channel %q: url is required", name)
+			}
+			channel.Sink = notify.NewSlackSink(c.URL, nil)
+		case ChannelEmail:
+			from := c.From
+			if from == "" {
+				from = cfg.SMTP.From
+			}
+			sink, err := notify.NewSMTPSink(notify.SMTPConfig{
+				Host:        cfg.SMTP.Host,
+				Port:        cfg.SMTP.Port,
+				Username:    cfg.SMTP.Username,
+				Password:    cfg.SMTP.Password,
+				ImplicitTLS: cfg.SMTP.ImplicitTLS,
+			}, from, c.To)
+			if err != nil {
+				return nil, fmt.Errorf("channel %q: %w", name, err)
+			}
+			channel.Sink = sink
+		default:
+			return nil, fmt.Errorf("channel %q: unsupported type %q (use webhook, slack or email)", name, c.Type)
+		}
+
+		var err error
+		if channel.Subject, err = parseTemplate(name+" subject", c.Subject, defaultSubject); err != nil {
+			return nil, err
+		}
+		if channel.Body, err = parseTemplate(name+" body", c.Body, defaultBody); err != nil {
+			return nil, err
+		}
+		channels = append(channels, channel)
+	}
+
+	for _, name := range cfg.Default {
+		if !names[name] {
+			return nil, fmt.Errorf("default channel %q is not defined", name)
+		}
+	}
+	return channels, nil
+}
+
+func parseTemplate(name, text, fallback string) (*template.Template, error) {
+	if strings.TrimSpace(text) == "" {
+		text = fallback
+	}
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("template %s: %w", name, err)
+	}
+	return tmpl, nil
+}
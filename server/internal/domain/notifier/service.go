@@ -0,0 +1,212 @@
+// Package notifier announces jobs found by saved search runs on the
+// channels each search routes to, at most once per job and channel
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/repository"
+	"github.com/honeycarbs/project-ets/pkg/logging"
+	"github.com/honeycarbs/project-ets/pkg/notify"
+)
+
+const (
+	defaultSubject = `{{.Count}} new job{{if ne .Count 1}}s{{end}} for {{.Search.Name}}`
+	defaultBody    = `{{range .Jobs}}• {{.Title}}{{if .Company}} at {{.Company}}{{end}}{{if .Location}} ({{.Location}}){{end}}{{if .Remote}}, remote{{end}}
+{{if .URL}}  {{.URL}}
+{{end}}{{end}}`
+)
+
+// DigestSearch identifies the saved search a digest belongs to
+type DigestSearch struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+// DigestJob is a job in a digest
+type DigestJob struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Company  string `json:"company,omitempty"`
+	Location string `json:"location,omitempty"`
+	Remote   bool   `json:"remote"`
+	URL      string `json:"url,omitempty"`
+	Source   string `json:"source,omitempty"`
+}
+
+// Digest is the template data and the JSON webhook payload
+type Digest struct {
+	Channel     string       `json:"channel"`
+	Search      DigestSearch `json:"search"`
+	Count       int          `json:"count"`
+	Jobs        []DigestJob  `json:"jobs"`
+	GeneratedAt time.Time    `json:"generated_at"`
+	Text        string       `json:"text"`
+}
+
+// Service routes new saved search results to notification channels
+type Service struct {
+	repo     repository.NotificationRepository
+	channels map[string]Channel
+	defaults []string
+	policy   notify.RetryPolicy
+	logger   *logging.Logger
+	clock    func() time.Time
+}
+
+// NewService creates a notifier. defaults are the channels used by saved
+// searches without their own routing on a server without authentication;
+// with no channels it does nothing
+func NewService(repo repository.NotificationRepository, channels []Channel, defaults []string, policy notify.RetryPolicy, logger *logging.Logger) *Service {
+	byName := make(map[string]Channel, len(channels))
+	for _, c := range channels {
+		byName[c.Name] = c
+	}
+	return &Service{
+		repo:     repo,
+		channels: byName,
+		defaults: defaults,
+		policy:   policy,
+		logger:   logger,
+		clock:    time.Now,
+	}
+}
+
+// HasChannel reports whether name is a configured channel
+func (s *Service) HasChannel(name string) bool {
+	_, ok := s.channels[name]
+	return ok
+}
+
+// NotifyNewJobs sends one digest per routed channel with the jobs not yet
+// announced there to the search's owner. Jobs are marked announced only
+// after a successful send, so a channel that stays down does not lose them
+// for other searches. Searches owned by a user only go to channels an
+// admin routed them to; the defaults would share one user's results with
+// everyone reading the channel
+func (s *Service) NotifyNewJobs(ctx context.Context, search domain.SavedSearch, jobs []domain.Job) error {
+	if len(jobs) == 0 || len(s.channels) == 0 {
+		return nil
+	}
+
+	routes := search.Channels
+	if len(routes) == 0 && search.OwnerID == "" {
+		routes = s.defaults
+	}
+
+	byID := make(map[string]domain.Job, len(jobs))
+	ids := make([]string, 0, len(jobs))
+	for _, j := range jobs {
+		id := j.ID.String()
+		if _, dup := byID[id]; dup {
+			continue
+		}
+		byID[id] = j
+		ids = append(ids, id)
+	}
+
+	var errs []error
+	for _, name := range routes {
+		channel, ok := s.channels[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("channel %q is not configured", name))
+			continue
+		}
+
+		pending, err := s.repo.Unannounced(ctx, name, ids)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("channel %q: check announced jobs: %w", name, err))
+			continue
+		}
+		if len(pending) == 0 {
+			continue
+		}
+
+		digest := s.digest(name, search, pending, byID)
+		msg, err := render(channel, digest)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("channel %q: %w", name, err))
+			continue
+		}
+
+		if err := notify.Send(ctx, channel.Sink, msg, s.policy); err != nil {
+			errs = append(errs, fmt.Errorf("channel %q: %w", name, err))
+			continue
+		}
+
+		if err := s.repo.MarkAnnounced(ctx, name, pending, digest.GeneratedAt); err != nil {
+			errs = append(errs, fmt.Errorf("channel %q: mark announced: %w", name, err))
+			continue
+		}
+
+		if s.logger != nil {
+			s.logger.Info("saved search digest sent",
+				"search_id", search.ID,
+				"channel", name,
+				"jobs", len(pending),
+			)
+		}
+	}
+
+	err := errors.Join(errs...)
+	if err != nil && s.logger != nil {
+		s.logger.Error("saved search notification failed", "search_id", search.ID, "err", err)
+	}
+	return err
+}
+
+func (s *Service) digest(channel string, search domain.SavedSearch, ids []string, jobs map[string]domain.Job) Digest {
+	digest := Digest{
+		Channel: channel,
+		Search: DigestSearch{
+			ID:    search.ID,
+			Name:  search.Name,
+			Query: search.Query,
+		},
+		Count:       len(ids),
+		Jobs:        make([]DigestJob, 0, len(ids)),
+		GeneratedAt: s.clock().UTC(),
+	}
+	for _, id := range ids {
+		j := jobs[id]
+		digest.Jobs = append(digest.Jobs, DigestJob{
+			ID:       id,
+			Title:    j.Title,
+			Company:  j.Company.Name,
+			Location: j.Location,
+			Remote:   j.Remote,
+			URL:      j.URL,
+			Source:   j.Source,
+		})
+	}
+	return digest
+}
+
+// render executes the channel's templates; webhooks receive the digest
+// itself with the rendered text included
+func render(channel Channel, digest Digest) (notify.Message, error) {
+	var subject, body bytes.Buffer
+	if err := channel.Subject.Execute(&subject, digest); err != nil {
+		return notify.Message{}, fmt.Errorf("render subject: %w", err)
+	}
+	if err := channel.Body.Execute(&body, digest); err != nil {
+		return notify.Message{}, fmt.Errorf("render body: %w", err)
+	}
+
+	msg := notify.Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimRight(body.String(), "\n"),
+	}
+	if channel.Type == ChannelWebhook {
+		digest.Text = msg.Text
+		msg.Payload = digest
+	}
+	return msg, nil
+}
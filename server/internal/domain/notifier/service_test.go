@@ -0,0 +1,137 @@
+package notifier
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/identity"
+	"github.com/honeycarbs/project-ets/pkg/notify"
+)
+
+// ownedAnnouncements keys announcements by channel and identity.OwnerID
+// like the ANNOUNCED edges of the Neo4j repository
+type ownedAnnouncements struct {
+	mu        sync.Mutex
+	announced map[[3]string]bool
+}
+
+func (r *ownedAnnouncements) key(ctx context.Context, channel, jobID string) [3]string {
+	return [3]string{identity.OwnerID(ctx), channel, jobID}
+}
+
+func (r *ownedAnnouncements) Unannounced(ctx context.Context, channel string, jobIDs []string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []string
+	for _, id := range jobIDs {
+		if !r.announced[r.key(ctx, channel, id)] {
+			out = append(out, id)
+		}
+	}
+	return out, nil
+}
+
+func (r *ownedAnnouncements) MarkAnnounced(ctx context.Context, channel string, jobIDs []string, _ time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.announced == nil {
+		r.announced = make(map[[3]string]bool)
+	}
+	for _, id := range jobIDs {
+		r.announced[r.key(ctx, channel, id)] = true
+	}
+	return nil
+}
+
+type recordingSink struct {
+	mu   sync.Mutex
+	sent []notify.Message
+}
+
+func (s *recordingSink) Send(_ context.Context, msg notify.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+// take returns and clears the sent subjects
+func (s *recordingSink) take() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var subjects []string
+	for _, msg := range s.sent {
+		subjects = append(subjects, msg.Subject)
+	}
+	s.sent = nil
+	return subjects
+}
+
+func newTestService(t *testing.T, sink notify.Sink, defaults []string) *Service {
+	t.Helper()
+	channels, err := BuildChannels(FileConfig{
+		Channels: []ChannelConfig{{Name: "team", Type: ChannelSlack, URL: "http://example.invalid"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range channels {
+		channels[i].Sink = sink
+	}
+	return NewService(&ownedAnnouncements{}, channels, defaults, notify.RetryPolicy{Attempts: 1}, nil)
+}
+
+func TestEachOwnerIsAnnouncedTheSameJob(t *testing.T) {
+	sink := &recordingSink{}
+	svc := newTestService(t, sink, nil)
+	jobs := []domain.Job{{ID: uuid.New(), Title: "Go Developer"}}
+
+	for _, owner := range []string{"alice", "bob"} {
+		search := domain.SavedSearch{ID: owner + "-search", Name: owner, Query: "go", Channels: []string{"team"}, OwnerID: owner}
+		ctx := identity.NewContext(context.Background(), domain.User{ID: owner})
+
+		if err := svc.NotifyNewJobs(ctx, search, jobs); err != nil {
+			t.Fatalf("notify %s: %v", owner, err)
+		}
+		if got, want := sink.take(), []string{"1 new job for " + owner}; !slices.Equal(got, want) {
+			t.Fatalf("first run for %s sent %v, want %v", owner, got, want)
+		}
+
+		// The owner's own announcement still dedups a later run
+		if err := svc.NotifyNewJobs(ctx, search, jobs); err != nil {
+			t.Fatalf("notify %s again: %v", owner, err)
+		}
+		if got := sink.take(); len(got) != 0 {
+			t.Fatalf("second run for %s sent %v, want nothing", owner, got)
+		}
+	}
+}
+
+func TestDefaultChannelsOnlyForUnownedSearches(t *testing.T) {
+	sink := &recordingSink{}
+	svc := newTestService(t, sink, []string{"team"})
+	jobs := []domain.Job{{ID: uuid.New(), Title: "Go Developer"}}
+
+	owned := domain.SavedSearch{ID: "s1", Name: "alice", Query: "go", OwnerID: "alice"}
+	ctx := identity.NewContext(context.Background(), domain.User{ID: "alice"})
+	if err := svc.NotifyNewJobs(ctx, owned, jobs); err != nil {
+		t.Fatal(err)
+	}
+	if got := sink.take(); len(got) != 0 {
+		t.Fatalf("owned search without routing sent %v, want nothing", got)
+	}
+
+	shared := domain.SavedSearch{ID: "s2", Name: "shared", Query: "go"}
+	if err := svc.NotifyNewJobs(context.Background(), shared, jobs); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := sink.take(), []string{"1 new job for shared"}; !slices.Equal(got, want) {
+		t.Fatalf("single-user search sent %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,202 @@
+// Package reminder raises follow-up tasks for applications that have not
+// moved for too long and collects upcoming interviews and deadlines
+package reminder
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+	"github.com/honeycarbs/project-ets/internal/repository"
+)
+
+const (
+	day = 24 * time.Hour
+	// DefaultFollowUpDays is used when no follow-up delay is configured
+	DefaultFollowUpDays = 10
+	defaultHorizonDays  = 3
+	maxHorizonDays      = 60
+	maxApplications     = 500
+	maxEvents           = 500
+)
+
+// Rule raises Task for applications that stayed in Status for After since
+// their last activity
+type Rule struct {
+	Name   string
+	Status string
+	After  time.Duration
+	Task   string
+}
+
+// DefaultRules returns the built-in reminder rules; followUpDays sets the
+// delay after applying
+func DefaultRules(followUpDays int) []Rule {
+	if followUpDays <= 0 {
+		followUpDays = DefaultFollowUpDays
+	}
+	return []Rule{
+		{Name: "stale_saved", Status: domain.ApplicationSaved, After: 14 * day, Task: "Apply or drop the saved job"},
+		{Name: "follow_up_applied", Status: domain.ApplicationApplied, After: time.Duration(followUpDays) * day, Task: "Follow up on the application"},
+		{Name: "follow_up_screening", Status: domain.ApplicationScreening, After: 7 * day, Task: "Check in after the screening"},
+		{Name: "follow_up_interview", Status: domain.ApplicationInterviewing, After: 7 * day, Task: "Check in after the interview"},
+		{Name: "offer_decision", Status: domain.ApplicationOffer, After: 5 * day, Task: "Respond to the offer"},
+	}
+}
+
+// Service implements tools.ReminderService
+type Service struct {
+	apps   repository.ApplicationRepository
+	events tools.EventService
+	rules  []Rule
+	clock  func() time.Time
+}
+
+// NewService creates a reminder service; events may be nil to skip
+// recorded follow-ups and upcoming events
+func NewService(apps repository.ApplicationRepository, events tools.EventService, rules []Rule) *Service {
+	return &Service{
+		apps:   apps,
+		events: events,
+		rules:  rules,
+		clock:  time.Now,
+	}
+}
+
+// Digest lists overdue tasks, most overdue first, and events starting
+// within the horizon. A follow_up event recorded for an application counts
+// as activity, so logging a follow-up postpones the next reminder
+func (s *Service) Digest(ctx context.Context, params tools.TodoDigestParams) (tools.TodoDigest, error) {
+	now := s.clock().UTC()
+	candidateID := strings.TrimSpace(params.CandidateID)
+
+	horizon := params.HorizonDays
+	if horizon <= 0 {
+		horizon = defaultHorizonDays
+	}
+	if horizon > maxHorizonDays {
+		horizon = maxHorizonDays
+	}
+
+	rules := make(map[string]Rule, len(s.rules))
+	statuses := make([]string, 0, len(s.rules))
+	for _, rule := range s.rules {
+		rules[rule.Status] = rule
+		statuses = append(statuses, rule.Status)
+	}
+
+	digest := tools.TodoDigest{
+		GeneratedAt: now,
+		Overdue:     []tools.TodoItem{},
+		Upcoming:    []tools.Event{},
+	}
+
+	apps, err := s.apps.ListApplications(ctx, repository.ApplicationFilter{
+		CandidateID: candidateID,
+		Statuses:    statuses,
+		Limit:       maxApplications,
+	})
+	if err != nil {
+		return tools.TodoDigest{}, fmt.Errorf("list applications: %w", err)
+	}
+
+	followUps, err := s.lastFollowUps(ctx, candidateID, now)
+	if err != nil {
+		return tools.TodoDigest{}, err
+	}
+
+	for _, app := range apps {
+		rule, ok := rules[app.Status]
+		if !ok {
+			continue
+		}
+		last := lastActivity(app)
+		if at, ok := followUps[followUpKey(app.CandidateID, app.Job.ID.String())]; ok && at.After(last) {
+			last = at
+		}
+		if last.IsZero() {
+			continue
+		}
+		due := last.Add(rule.After)
+		if due.After(now) {
+			continue
+		}
+		digest.Overdue = append(digest.Overdue, tools.TodoItem{
+			Rule:         rule.Name,
+			Task:         rule.Task,
+			CandidateID:  app.CandidateID,
+			JobID:        app.Job.ID.String(),
+			Title:        app.Job.Title,
+			Company:      app.Job.Company.Name,
+			Status:       app.Status,
+			LastActivity: last,
+			DueAt:        due,
+			DaysOverdue:  int(now.Sub(due) / day),
+		})
+	}
+	sort.SliceStable(digest.Overdue, func(i, j int) bool {
+		return digest.Overdue[i].DueAt.Before(digest.Overdue[j].DueAt)
+	})
+
+	if s.events != nil {
+		upcoming, err := s.events.ListEvents(ctx, tools.EventListParams{
+			CandidateID: candidateID,
+			Kinds:       []string{domain.EventInterview, domain.EventDeadline},
+			From:        now.Format(time.RFC3339),
+			To:          now.Add(time.Duration(horizon) * day).Format(time.RFC3339),
+			Limit:       maxEvents,
+		})
+		if err != nil {
+			return tools.TodoDigest{}, fmt.Errorf("list upcoming events: %w", err)
+		}
+		digest.Upcoming = upcoming.Events
+	}
+
+	return digest, nil
+}
+
+// lastFollowUps returns the latest past follow_up event per application
+func (s *Service) lastFollowUps(ctx context.Context, candidateID string, now time.Time) (map[string]time.Time, error) {
+	out := make(map[string]time.Time)
+	if s.events == nil {
+		return out, nil
+	}
+
+	events, err := s.events.ListEvents(ctx, tools.EventListParams{
+		CandidateID: candidateID,
+		Kinds:       []string{domain.EventFollowUp},
+		IncludePast: true,
+		To:          now.Format(time.RFC3339),
+		Limit:       maxEvents,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list follow-up events: %w", err)
+	}
+
+	for _, e := range events.Events {
+		if e.CandidateID == "" {
+			continue
+		}
+		key := followUpKey(e.CandidateID, e.JobID)
+		if e.Start.After(out[key]) {
+			out[key] = e.Start
+		}
+	}
+	return out, nil
+}
+
+// lastActivity is the time of the latest status change
+func lastActivity(app domain.Application) time.Time {
+	if n := len(app.History); n > 0 {
+		return app.History[n-1].At
+	}
+	return app.UpdatedAt
+}
+
+func followUpKey(candidateID, jobID string) string {
+	return candidateID + "\x00" + jobID
+}
@@ -0,0 +1,362 @@
+// Package savedquery provides the catalog of named, parameterized Cypher
+// templates that graph_tool can run by name
+package savedquery
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/identity"
+	"github.com/honeycarbs/project-ets/internal/repository"
+	"github.com/honeycarbs/project-ets/pkg/cypherguard"
+	"github.com/honeycarbs/project-ets/pkg/logging"
+)
+
+// Parameter types
+const (
+	TypeString   = "string"
+	TypeInt      = "int"
+	TypeFloat    = "float"
+	TypeBool     = "bool"
+	TypeDatetime = "datetime"
+	TypeList     = "list"
+)
+
+// Query sources, in increasing precedence
+const (
+	SourceBuiltin = "builtin"
+	SourceFile    = "file"
+	SourceGraph   = "graph"
+)
+
+// OwnerParam is bound to the caller's owner ID in every saved query, so
+// templates reading user-owned data can compare the ownerId property of
+// nodes and relationships with $ownerId. Queries cannot declare it
+const OwnerParam = "ownerId"
+
+// ErrNotFound is returned when no saved query has the requested name
+var ErrNotFound = errors.New("saved query not found")
+
+//go:embed builtin.json
+var builtinJSON []byte
+
+var paramNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Catalog merges built-in queries, queries loaded from a JSON file and
+// (:SavedQuery) nodes. Graph entries override file entries, which override
+// built-ins with the same name
+type Catalog struct {
+	static []domain.SavedQuery
+	repo   repository.SavedQueryRepository
+	logger *logging.Logger
+}
+
+// NewCatalog loads the built-in queries and, when path is set, the queries in
+// that JSON file. repo may be nil to disable (:SavedQuery) nodes
+func NewCatalog(repo repository.SavedQueryRepository, path string, logger *logging.Logger) (*Catalog, error) {
+	builtin, err := parseQueries(builtinJSON, SourceBuiltin)
+	if err != nil {
+		return nil, fmt.Errorf("built-in saved queries: %w", err)
+	}
+
+	static := builtin
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read saved queries file: %w", err)
+		}
+		fromFile, err := parseQueries(data, SourceFile)
+		if err != nil {
+			return nil, fmt.Errorf("saved queries file %s: %w", path, err)
+		}
+		static = merge(static, fromFile)
+	}
+
+	return &Catalog{
+		static: static,
+		repo:   repo,
+		logger: logger,
+	}, nil
+}
+
+// List returns every saved query sorted by name. Invalid or unreadable
+// (:SavedQuery) nodes are logged and skipped so the static catalog stays usable
+func (c *Catalog) List(ctx context.Context) ([]domain.SavedQuery, error) {
+	queries := c.static
+	if c.repo != nil {
+		stored, err := c.repo.ListSavedQueries(ctx)
+		if err != nil {
+			if c.logger != nil {
+				c.logger.Warn("failed to load saved queries from graph", "err", err)
+			}
+		} else {
+			valid := make([]domain.SavedQuery, 0, len(stored))
+			for _, q := range stored {
+				q.Source = SourceGraph
+				if err := validate(q); err != nil {
+					if c.logger != nil {
+						c.logger.Warn("skipping invalid saved query node", "name", q.Name, "err", err)
+					}
+					continue
+				}
+				valid = append(valid, q)
+			}
+			queries = merge(queries, valid)
+		}
+	}
+
+	out := make([]domain.SavedQuery, len(queries))
+	copy(out, queries)
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// Resolve looks up a saved query by name and binds the supplied parameters,
+// applying defaults and converting values to their declared types. $ownerId
+// is bound to the owner of ctx
+func (c *Catalog) Resolve(ctx context.Context, name string, params map[string]any) (domain.SavedQuery, map[string]any, error) {
+	queries, err := c.List(ctx)
+	if err != nil {
+		return domain.SavedQuery{}, nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	for _, q := range queries {
+		if q.Name != name {
+			continue
+		}
+		bound, err := Bind(q, params)
+		if err != nil {
+			return q, nil, err
+		}
+		bound[OwnerParam] = identity.OwnerID(ctx)
+		return q, bound, nil
+	}
+
+	return domain.SavedQuery{}, nil, fmt.Errorf("%w: %q", ErrNotFound, name)
+}
+
+// Bind validates params against the declared parameters of q. Unknown
+// parameters are rejected; optional parameters without a value or default are
+// bound to null so templates can test them with IS NULL
+func Bind(q domain.SavedQuery, params map[string]any) (map[string]any, error) {
+	declared := make(map[string]bool, len(q.Params))
+	for _, p := range q.Params {
+		declared[p.Name] = true
+	}
+	var unknown []string
+	for name := range params {
+		if !declared[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("saved query %q: unknown parameters: %s", q.Name, strings.Join(unknown, ", "))
+	}
+
+	bound := make(map[string]any, len(q.Params))
+	for _, p := range q.Params {
+		val, ok := params[p.Name]
+		if !ok || val == nil {
+			val = p.Default
+		}
+		if val == nil {
+			if p.Required {
+				return nil, fmt.Errorf("saved query %q: parameter %q is required", q.Name, p.Name)
+			}
+			bound[p.Name] = nil
+			continue
+		}
+
+		converted, err := convert(p.Type, val)
+		if err != nil {
+			return nil, fmt.Errorf("saved query %q: parameter %q: %w", q.Name, p.Name, err)
+		}
+		bound[p.Name] = converted
+	}
+	return bound, nil
+}
+
+func parseQueries(data []byte, source string) ([]domain.SavedQuery, error) {
+	var queries []domain.SavedQuery
+	if err := json.Unmarshal(data, &queries); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	seen := make(map[string]bool, len(queries))
+	for i := range queries {
+		queries[i].Source = source
+		if err := validate(queries[i]); err != nil {
+			return nil, err
+		}
+		if seen[queries[i].Name] {
+			return nil, fmt.Errorf("duplicate saved query %q", queries[i].Name)
+		}
+		seen[queries[i].Name] = true
+	}
+	return queries, nil
+}
+
+// validate checks a definition; templates must pass the read-only Cypher
+// guard. Limits are not enforced here since templates may use LIMIT $param
+func validate(q domain.SavedQuery) error {
+	if strings.TrimSpace(q.Name) == "" {
+		return fmt.Errorf("saved query name is required")
+	}
+	if strings.TrimSpace(q.Cypher) == "" {
+		return fmt.Errorf("saved query %q: cypher is required", q.Name)
+	}
+
+	seen := make(map[string]bool, len(q.Params))
+	for _, p := range q.Params {
+		if !paramNamePattern.MatchString(p.Name) {
+			return fmt.Errorf("saved query %q: invalid parameter name %q", q.Name, p.Name)
+		}
+		if p.Name == OwnerParam {
+			return fmt.Errorf("saved query %q: parameter %q is reserved for the caller's owner ID", q.Name, p.Name)
+		}
+		if seen[p.Name] {
+			return fmt.Errorf("saved query %q: duplicate parameter %q", q.Name, p.Name)
+		}
+		seen[p.Name] = true
+		if !validType(p.Type) {
+			return fmt.Errorf("saved query %q: parameter %q has unsupported type %q", q.Name, p.Name, p.Type)
+		}
+		if p.Default != nil {
+			if _, err := convert(p.Type, p.Default); err != nil {
+				return fmt.Errorf("saved query %q: parameter %q default: %w", q.Name, p.Name, err)
+			}
+		}
+	}
+
+	policy := cypherguard.DefaultPolicy(0)
+	if _, err := policy.Check(q.Cypher); err != nil {
+		return fmt.Errorf("saved query %q: %w", q.Name, err)
+	}
+	return nil
+}
+
+func validType(t string) bool {
+	switch t {
+	case TypeString, TypeInt, TypeFloat, TypeBool, TypeDatetime, TypeList:
+		return true
+	default:
+		return false
+	}
+}
+
+// merge returns base with entries from override replacing those with the same name
+func merge(base, override []domain.SavedQuery) []domain.SavedQuery {
+	index := make(map[string]int, len(base))
+	out := make([]domain.SavedQuery, 0, len(base)+len(override))
+	for _, q := range base {
+		index[q.Name] = len(out)
+		out = append(out, q)
+	}
+	for _, q := range override {
+		if i, ok := index[q.Name]; ok {
+			out[i] = q
+			continue
+		}
+		index[q.Name] = len(out)
+		out = append(out, q)
+	}
+	return out
+}
+
+// convert coerces a JSON-decoded value to the declared parameter type
+func convert(typ string, val any) (any, error) {
+	switch typ {
+	case TypeString:
+		s, ok := val.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %T", val)
+		}
+		return s, nil
+
+	case TypeInt:
+		switch v := val.(type) {
+		case float64:
+			if v != math.Trunc(v) {
+				return nil, fmt.Errorf("expected integer, got %v", v)
+			}
+			return int64(v), nil
+		case int:
+			return int64(v), nil
+		case int64:
+			return v, nil
+		case string:
+			n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("expected integer, got %q", v)
+			}
+			return n, nil
+		}
+		return nil, fmt.Errorf("expected integer, got %T", val)
+
+	case TypeFloat:
+		switch v := val.(type) {
+		case float64:
+			return v, nil
+		case int:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		case string:
+			f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return nil, fmt.Errorf("expected number, got %q", v)
+			}
+			return f, nil
+		}
+		return nil, fmt.Errorf("expected number, got %T", val)
+
+	case TypeBool:
+		switch v := val.(type) {
+		case bool:
+			return v, nil
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return nil, fmt.Errorf("expected boolean, got %q", v)
+			}
+			return b, nil
+		}
+		return nil, fmt.Errorf("expected boolean, got %T", val)
+
+	case TypeDatetime:
+		s, ok := val.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected RFC 3339 datetime string, got %T", val)
+		}
+		t, err := time.Parse(time.RFC3339, strings.TrimSpace(s))
+		if err != nil {
+			if d, derr := time.Parse(time.DateOnly, strings.TrimSpace(s)); derr == nil {
+				return d, nil
+			}
+			return nil, fmt.Errorf("expected RFC 3339 datetime, got %q", s)
+		}
+		return t, nil
+
+	case TypeList:
+		list, ok := val.([]any)
+		if !ok {
+			return nil, fmt.Errorf("expected list, got %T", val)
+		}
+		return list, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %q", typ)
+}
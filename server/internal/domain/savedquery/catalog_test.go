@@ -0,0 +1,70 @@
+package savedquery
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/identity"
+)
+
+// ownedData matches labels and relationship types holding user-owned data
+var ownedData = regexp.MustCompile(`:(Candidate|APPLIED_TO|Contact|Event|SavedSearch|HAS_KEYWORD)\b`)
+
+func TestResolveBindsCallerOwner(t *testing.T) {
+	catalog, err := NewCatalog(nil, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice := identity.NewContext(context.Background(), domain.User{ID: "user-a", Subject: "alice"})
+	bob := identity.NewContext(context.Background(), domain.User{ID: "user-b", Subject: "bob"})
+
+	_, bound, err := catalog.Resolve(alice, "new_since_last_run", map[string]any{"search": "go jobs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bound[OwnerParam] != "user-a" {
+		t.Errorf("alice's ownerId = %v", bound[OwnerParam])
+	}
+	_, bound, err = catalog.Resolve(bob, "new_since_last_run", map[string]any{"search": "go jobs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bound[OwnerParam] != "user-b" {
+		t.Errorf("bob's ownerId = %v", bound[OwnerParam])
+	}
+
+	// bob cannot ask for alice's data by passing her owner ID
+	if _, _, err := catalog.Resolve(bob, "top_keywords", map[string]any{OwnerParam: "user-a"}); err == nil || !strings.Contains(err.Error(), "unknown parameters") {
+		t.Errorf("ownerId argument accepted: %v", err)
+	}
+}
+
+func TestOwnerParamIsReserved(t *testing.T) {
+	q := domain.SavedQuery{
+		Name:   "by_owner",
+		Cypher: "MATCH (c:Candidate {ownerId: $ownerId}) RETURN c",
+		Params: []domain.SavedQueryParam{{Name: OwnerParam, Type: TypeString}},
+	}
+	if err := validate(q); err == nil {
+		t.Error("a query declaring ownerId was accepted")
+	}
+}
+
+func TestBuiltinQueriesScopeOwnedData(t *testing.T) {
+	catalog, err := NewCatalog(nil, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	queries, err := catalog.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, q := range queries {
+		if ownedData.MatchString(q.Cypher) && !strings.Contains(q.Cypher, "= $ownerId") {
+			t.Errorf("%s reads user-owned data without filtering on $ownerId", q.Name)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Saved search run outcomes
+const (
+	SearchRunOK     = "ok"
+	SearchRunFailed = "failed"
+)
+
+// MinSearchInterval is the shortest schedule a saved search may use
+const MinSearchInterval = 15 * time.Minute
+
+var scheduleAliases = map[string]time.Duration{
+	"@hourly": time.Hour,
+	"@daily":  24 * time.Hour,
+	"@weekly": 7 * 24 * time.Hour,
+}
+
+// ParseSchedule converts a schedule into its run interval. A schedule is
+// one of @hourly, @daily, @weekly or a Go duration such as 6h, no shorter
+// than MinSearchInterval
+func ParseSchedule(schedule string) (time.Duration, error) {
+	schedule = strings.ToLower(strings.TrimSpace(schedule))
+	if d, ok := scheduleAliases[schedule]; ok {
+		return d, nil
+	}
+	d, err := time.ParseDuration(schedule)
+	if err != nil {
+		return 0, fmt.Errorf("invalid schedule %q: use @hourly, @daily, @weekly or a duration such as 6h", schedule)
+	}
+	if d < MinSearchInterval {
+		return 0, fmt.Errorf("schedule %q is shorter than the minimum of %s", schedule, MinSearchInterval)
+	}
+	return d, nil
+}
+
+// SavedSearch is a job search re-run on a schedule
+type SavedSearch struct {
+	ID       string
+	OwnerID  string // user the search runs as; empty on a single-user server
+	Name     string
+	Query    string
+	Filters  JobSearchFilters
+	Schedule string
+	Enabled  bool
+	// Channels are the notification channels new jobs are announced on;
+	// empty means the configured defaults
+	Channels  []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	NextRunAt time.Time
+	// LastRunAt is when the latest finished run started; jobs first found at
+	// or after it are new since the last run
+	LastRunAt     time.Time
+	LastRunStatus string
+	LastError     string
+	LastFound     int // jobs returned by the latest run
+	LastNew       int // jobs the latest run found for the first time
+}
+
+// SearchRun is the outcome of one saved search run
+type SearchRun struct {
+	SearchID   string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	NextRunAt  time.Time
+	JobIDs     []string // jobs returned by the search, empty when it failed
+	Err        string
+}
@@ -0,0 +1,177 @@
+package savedsearch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/identity"
+	"github.com/honeycarbs/project-ets/pkg/logging"
+)
+
+// maxConcurrentRuns bounds how many searches run at once per process
+const maxConcurrentRuns = 2
+
+// Scheduler polls for due saved searches and runs them in the background.
+// A search is skipped while a run of it is in progress in this process, and
+// the repository lease keeps other replicas from running it at the same time
+type Scheduler struct {
+	service *Service
+	tick    time.Duration
+	logger  *logging.Logger
+
+	mu      sync.Mutex
+	running map[string]bool
+	slots   chan struct{}
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewScheduler creates a scheduler that checks for due searches every tick;
+// a tick of zero disables it
+func NewScheduler(service *Service, tick time.Duration, logger *logging.Logger) *Scheduler {
+	return &Scheduler{
+		service: service,
+		tick:    tick,
+		logger:  logger,
+		running: make(map[string]bool),
+		slots:   make(chan struct{}, maxConcurrentRuns),
+	}
+}
+
+// Start launches the polling loop; it returns immediately
+func (s *Scheduler) Start(ctx context.Context) {
+	if s == nil || s.service == nil || s.tick <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		return
+	}
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.wg.Add(1)
+	go s.loop(ctx)
+
+	if s.logger != nil {
+		s.logger.Info("saved search scheduler started", "tick", s.tick.String())
+	}
+}
+
+// Stop cancels in-flight runs and waits for them to record their outcome
+// or for ctx to expire
+func (s *Scheduler) Stop(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	cancel := s.cancel
+	s.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Scheduler) loop(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.tick)
+	defer ticker.Stop()
+
+	for {
+		s.runDue(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runDue starts a run for each due search that is not already running
+func (s *Scheduler) runDue(ctx context.Context) {
+	due, err := s.service.Due(ctx, s.service.clock().UTC())
+	if err != nil {
+		if s.logger != nil && ctx.Err() == nil {
+			s.logger.Error("saved search scheduler: listing due searches failed", "err", err)
+		}
+		return
+	}
+
+	for _, search := range due {
+		if !s.acquire(search.ID) {
+			continue
+		}
+		select {
+		case s.slots <- struct{}{}:
+		case <-ctx.Done():
+			s.release(search.ID)
+			return
+		}
+
+		s.wg.Add(1)
+		go func(search domain.SavedSearch) {
+			defer s.wg.Done()
+			defer func() { <-s.slots }()
+			defer s.release(search.ID)
+			s.run(ctx, search)
+		}(search)
+	}
+}
+
+// run executes the search as its owner, so the run only sees and updates
+// that user's data
+func (s *Scheduler) run(ctx context.Context, search domain.SavedSearch) {
+	ctx = identity.NewContext(ctx, domain.User{ID: search.OwnerID})
+	run, err := s.service.Run(ctx, search)
+	if s.logger == nil {
+		return
+	}
+	switch {
+	case errors.Is(err, ErrRunning):
+		s.logger.Debug("saved search skipped, already running", "search_id", search.ID)
+	case err != nil:
+		s.logger.Error("saved search run failed", "search_id", search.ID, "err", err)
+	default:
+		s.logger.Info("saved search run completed",
+			"search_id", search.ID,
+			"found", len(run.JobIDs),
+			"next_run_at", run.NextRunAt.Format(time.RFC3339),
+		)
+	}
+}
+
+func (s *Scheduler) acquire(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running[id] {
+		return false
+	}
+	s.running[id] = true
+	return true
+}
+
+func (s *Scheduler) release(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, id)
+}
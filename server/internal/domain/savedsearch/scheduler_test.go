@@ -0,0 +1,100 @@
+package savedsearch
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/identity"
+	"github.com/honeycarbs/project-ets/internal/repository"
+)
+
+// ownedSearches scopes searches by identity.OwnerID like the Neo4j
+// repository and records which owner each call ran as
+type ownedSearches struct {
+	mu       sync.Mutex
+	searches []domain.SavedSearch
+	ranAs    map[string][]string
+}
+
+func (r *ownedSearches) visible(ctx context.Context, id string) bool {
+	owner := identity.OwnerID(ctx)
+	for _, s := range r.searches {
+		if s.ID == id && s.OwnerID == owner {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ownedSearches) SaveSearch(ctx context.Context, search domain.SavedSearch) (domain.SavedSearch, error) {
+	return search, nil
+}
+
+func (r *ownedSearches) ListSearches(ctx context.Context, filter repository.SavedSearchFilter) ([]domain.SavedSearch, error) {
+	var out []domain.SavedSearch
+	for _, s := range r.searches {
+		if filter.AllOwners || s.OwnerID == identity.OwnerID(ctx) {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func (r *ownedSearches) DeleteSearch(ctx context.Context, id string) (bool, error) {
+	return false, nil
+}
+
+func (r *ownedSearches) ClaimRun(ctx context.Context, id string, now, leaseUntil time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ranAs[id] = append(r.ranAs[id], identity.OwnerID(ctx))
+	return r.visible(ctx, id), nil
+}
+
+func (r *ownedSearches) FinishRun(ctx context.Context, run domain.SearchRun) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ranAs[run.SearchID] = append(r.ranAs[run.SearchID], identity.OwnerID(ctx))
+	return 0, nil
+}
+
+func (r *ownedSearches) ListNewJobs(ctx context.Context, id string, since time.Time, limit int) ([]domain.Job, error) {
+	return nil, nil
+}
+
+type noJobs struct{}
+
+func (noJobs) Search(ctx context.Context, query string, filters domain.JobSearchFilters) (domain.JobSearchResult, error) {
+	return domain.JobSearchResult{}, nil
+}
+
+func TestSchedulerRunsSearchesAsTheirOwner(t *testing.T) {
+	repo := &ownedSearches{
+		searches: []domain.SavedSearch{
+			{ID: "a", OwnerID: "alice", Schedule: "@daily", Enabled: true},
+			{ID: "b", OwnerID: "bob", Schedule: "@daily", Enabled: true},
+			{ID: "legacy", Schedule: "@daily", Enabled: true},
+		},
+		ranAs: make(map[string][]string),
+	}
+	scheduler := NewScheduler(NewService(repo, noJobs{}, nil, 0, time.Minute), time.Hour, nil)
+
+	scheduler.runDue(context.Background())
+	scheduler.wg.Wait()
+
+	want := map[string]string{"a": "alice", "b": "bob", "legacy": ""}
+	for id, owner := range want {
+		calls := repo.ranAs[id]
+		if len(calls) != 2 {
+			t.Fatalf("search %s: %d repository calls, want claim and finish", id, len(calls))
+		}
+		for _, got := range calls {
+			if got != owner {
+				t.Errorf("search %s ran as %q, want %q", id, got, owner)
+			}
+		}
+	}
+}
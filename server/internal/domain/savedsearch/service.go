@@ -0,0 +1,369 @@
+// Package savedsearch stores job searches and re-runs them on a schedule,
+// remembering which jobs each run found for the first time
+package savedsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/domain/job"
+	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+	"github.com/honeycarbs/project-ets/internal/repository"
+)
+
+const (
+	defaultSchedule = "@daily"
+	defaultNewLimit = 50
+	maxNewLimit     = 500
+	maxSearches     = 500
+	// DefaultRunTimeout is used when no run timeout is configured
+	DefaultRunTimeout = 2 * time.Minute
+)
+
+// ErrRunning is returned when another run of the search holds its lease
+var ErrRunning = errors.New("saved search is already running")
+
+// Notifier announces jobs that a run found for the first time
+type Notifier interface {
+	HasChannel(name string) bool
+	NotifyNewJobs(ctx context.Context, search domain.SavedSearch, jobs []domain.Job) error
+}
+
+// Service implements tools.SavedSearchService and runs searches for the Scheduler
+type Service struct {
+	repo       repository.SavedSearchRepository
+	jobs       job.Service
+	notifier   Notifier
+	jitter     time.Duration
+	runTimeout time.Duration
+	clock      func() time.Time
+	rand       func(n int64) int64
+}
+
+// NewService creates a saved search service. notifier may be nil to skip
+// announcements. jitter is the most a scheduled run is delayed past its
+// interval, capped at a tenth of the interval; runTimeout bounds one run
+// and its lease
+func NewService(repo repository.SavedSearchRepository, jobs job.Service, notifier Notifier, jitter, runTimeout time.Duration) *Service {
+	if runTimeout <= 0 {
+		runTimeout = DefaultRunTimeout
+	}
+	return &Service{
+		repo:       repo,
+		jobs:       jobs,
+		notifier:   notifier,
+		jitter:     jitter,
+		runTimeout: runTimeout,
+		clock:      time.Now,
+		rand:       rand.Int63n,
+	}
+}
+
+// SaveSearch validates and stores a search. New and rescheduled searches
+// are due after one jittered interval
+func (s *Service) SaveSearch(ctx context.Context, params tools.SavedSearchSaveParams) (tools.SavedSearch, error) {
+	query := strings.TrimSpace(params.Query)
+	if query == "" {
+		return tools.SavedSearch{}, fmt.Errorf("query is required")
+	}
+	schedule := strings.ToLower(strings.TrimSpace(params.Schedule))
+	if schedule == "" {
+		schedule = defaultSchedule
+	}
+	interval, err := domain.ParseSchedule(schedule)
+	if err != nil {
+		return tools.SavedSearch{}, err
+	}
+
+	var channels []string
+	for _, name := range params.Notify {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if s.notifier == nil || !s.notifier.HasChannel(name) {
+			return tools.SavedSearch{}, fmt.Errorf("unknown notification channel %q", name)
+		}
+		channels = append(channels, name)
+	}
+
+	now := s.clock().UTC()
+	search := domain.SavedSearch{
+		ID:    strings.TrimSpace(params.SearchID),
+		Name:  strings.TrimSpace(params.Name),
+		Query: query,
+		Filters: domain.JobSearchFilters{
+			Location:      strings.TrimSpace(params.Location),
+			Remote:        params.Remote,
+			Skills:        params.Skills,
+			IncludeClosed: params.IncludeClosed,
+		},
+		Schedule:  schedule,
+		Enabled:   params.Enabled == nil || *params.Enabled,
+		Channels:  channels,
+		UpdatedAt: now,
+		NextRunAt: s.nextRun(now, interval),
+	}
+	if search.Name == "" {
+		search.Name = query
+	}
+
+	if search.ID == "" {
+		search.ID = uuid.NewString()
+	} else {
+		existing, found, err := s.get(ctx, search.ID)
+		if err != nil {
+			return tools.SavedSearch{}, err
+		}
+		if !found {
+			return tools.SavedSearch{}, fmt.Errorf("saved search %s not found", search.ID)
+		}
+		// Keep the pending run unless the schedule changed
+		if existing.Schedule == schedule && !existing.NextRunAt.IsZero() {
+			search.NextRunAt = existing.NextRunAt
+		}
+	}
+
+	stored, err := s.repo.SaveSearch(ctx, search)
+	if err != nil {
+		return tools.SavedSearch{}, fmt.Errorf("save search: %w", err)
+	}
+	return toSavedSearch(stored), nil
+}
+
+// ListSearches returns the caller's saved searches by name
+func (s *Service) ListSearches(ctx context.Context) (tools.SavedSearchListResult, error) {
+	searches, err := s.repo.ListSearches(ctx, repository.SavedSearchFilter{Limit: maxSearches})
+	if err != nil {
+		return tools.SavedSearchListResult{}, fmt.Errorf("list searches: %w", err)
+	}
+
+	result := tools.SavedSearchListResult{Searches: make([]tools.SavedSearch, 0, len(searches))}
+	for _, search := range searches {
+		result.Searches = append(result.Searches, toSavedSearch(search))
+	}
+	return result, nil
+}
+
+// DeleteSearch removes a saved search and its found-job history
+func (s *Service) DeleteSearch(ctx context.Context, searchID string) (bool, error) {
+	deleted, err := s.repo.DeleteSearch(ctx, strings.TrimSpace(searchID))
+	if err != nil {
+		return false, fmt.Errorf("delete search: %w", err)
+	}
+	return deleted, nil
+}
+
+// RunSearch runs a search immediately; it fails with ErrRunning when a
+// scheduled run is in progress
+func (s *Service) RunSearch(ctx context.Context, searchID string) (tools.SavedSearchRunResult, error) {
+	search, found, err := s.get(ctx, strings.TrimSpace(searchID))
+	if err != nil {
+		return tools.SavedSearchRunResult{}, err
+	}
+	if !found {
+		return tools.SavedSearchRunResult{}, fmt.Errorf("saved search %s not found", searchID)
+	}
+
+	run, err := s.Run(ctx, search)
+	if err != nil {
+		return tools.SavedSearchRunResult{}, err
+	}
+
+	updated, _, err := s.get(ctx, search.ID)
+	if err != nil {
+		return tools.SavedSearchRunResult{}, err
+	}
+	newJobs, err := s.repo.ListNewJobs(ctx, search.ID, run.StartedAt, maxNewLimit)
+	if err != nil {
+		return tools.SavedSearchRunResult{}, fmt.Errorf("list new jobs: %w", err)
+	}
+
+	return tools.SavedSearchRunResult{
+		Search: toSavedSearch(updated),
+		Found:  len(run.JobIDs),
+		New:    toJobs(newJobs),
+	}, nil
+}
+
+// NewSinceLastRun lists the jobs the latest successful run found first
+func (s *Service) NewSinceLastRun(ctx context.Context, params tools.NewSinceLastRunParams) (tools.NewSinceLastRunResult, error) {
+	search, found, err := s.get(ctx, strings.TrimSpace(params.SearchID))
+	if err != nil {
+		return tools.NewSinceLastRunResult{}, err
+	}
+	if !found {
+		return tools.NewSinceLastRunResult{}, fmt.Errorf("saved search %s not found", params.SearchID)
+	}
+
+	result := tools.NewSinceLastRunResult{
+		Search: toSavedSearch(search),
+		Jobs:   []tools.JobSearchJob{},
+	}
+	if search.LastRunAt.IsZero() {
+		return result, nil
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultNewLimit
+	}
+	if limit > maxNewLimit {
+		limit = maxNewLimit
+	}
+
+	jobs, err := s.repo.ListNewJobs(ctx, search.ID, search.LastRunAt, limit)
+	if err != nil {
+		return tools.NewSinceLastRunResult{}, fmt.Errorf("list new jobs: %w", err)
+	}
+	result.Jobs = toJobs(jobs)
+	return result, nil
+}
+
+// Due returns every user's enabled searches whose next run is at or before now
+func (s *Service) Due(ctx context.Context, now time.Time) ([]domain.SavedSearch, error) {
+	searches, err := s.repo.ListSearches(ctx, repository.SavedSearchFilter{DueBefore: now, AllOwners: true, Limit: maxSearches})
+	if err != nil {
+		return nil, fmt.Errorf("list due searches: %w", err)
+	}
+	return searches, nil
+}
+
+// Run claims the search's lease, runs job.Service.Search and records the
+// outcome with the next jittered run time. A failed search is recorded and
+// returned; ErrRunning means another run holds the lease
+func (s *Service) Run(ctx context.Context, search domain.SavedSearch) (domain.SearchRun, error) {
+	interval, err := domain.ParseSchedule(search.Schedule)
+	if err != nil {
+		return domain.SearchRun{}, err
+	}
+
+	started := s.clock().UTC()
+	claimed, err := s.repo.ClaimRun(ctx, search.ID, started, started.Add(s.runTimeout))
+	if err != nil {
+		return domain.SearchRun{}, fmt.Errorf("claim run: %w", err)
+	}
+	if !claimed {
+		return domain.SearchRun{}, ErrRunning
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, s.runTimeout)
+	defer cancel()
+
+	run := domain.SearchRun{SearchID: search.ID, StartedAt: started}
+	result, searchErr := s.jobs.Search(runCtx, search.Query, search.Filters)
+	if searchErr != nil {
+		run.Err = searchErr.Error()
+	} else {
+		for _, j := range result.Jobs {
+			run.JobIDs = append(run.JobIDs, j.ID.String())
+		}
+	}
+
+	run.FinishedAt = s.clock().UTC()
+	run.NextRunAt = s.nextRun(run.FinishedAt, interval)
+
+	// Record the outcome even when the caller's context is done, so the
+	// lease is released and the next run scheduled
+	newJobs, err := s.repo.FinishRun(context.WithoutCancel(ctx), run)
+	if err != nil {
+		return run, fmt.Errorf("finish run: %w", err)
+	}
+	if searchErr != nil {
+		return run, fmt.Errorf("search: %w", searchErr)
+	}
+
+	// Announcements are best effort: the notifier logs its own failures and
+	// a failed channel does not fail the run
+	if s.notifier != nil && newJobs > 0 {
+		jobs, err := s.repo.ListNewJobs(ctx, search.ID, run.StartedAt, maxNewLimit)
+		if err != nil {
+			return run, fmt.Errorf("list new jobs: %w", err)
+		}
+		_ = s.notifier.NotifyNewJobs(ctx, search, jobs)
+	}
+	return run, nil
+}
+
+// nextRun adds up to jitter (at most a tenth of the interval) so searches
+// saved at the same time do not all hit the providers together
+func (s *Service) nextRun(from time.Time, interval time.Duration) time.Time {
+	jitter := s.jitter
+	if limit := interval / 10; jitter > limit {
+		jitter = limit
+	}
+	next := from.Add(interval)
+	if jitter > 0 {
+		next = next.Add(time.Duration(s.rand(int64(jitter))))
+	}
+	return next
+}
+
+func (s *Service) get(ctx context.Context, id string) (domain.SavedSearch, bool, error) {
+	if id == "" {
+		return domain.SavedSearch{}, false, fmt.Errorf("search_id is required")
+	}
+	searches, err := s.repo.ListSearches(ctx, repository.SavedSearchFilter{IDs: []string{id}, Limit: 1})
+	if err != nil {
+		return domain.SavedSearch{}, false, fmt.Errorf("get search: %w", err)
+	}
+	if len(searches) == 0 {
+		return domain.SavedSearch{}, false, nil
+	}
+	return searches[0], true, nil
+}
+
+func toSavedSearch(s domain.SavedSearch) tools.SavedSearch {
+	out := tools.SavedSearch{
+		ID:            s.ID,
+		Name:          s.Name,
+		Query:         s.Query,
+		Location:      s.Filters.Location,
+		Remote:        s.Filters.Remote,
+		Skills:        s.Filters.Skills,
+		IncludeClosed: s.Filters.IncludeClosed,
+		Schedule:      s.Schedule,
+		Enabled:       s.Enabled,
+		Notify:        s.Channels,
+		NextRunAt:     s.NextRunAt,
+		LastRunStatus: s.LastRunStatus,
+		LastError:     s.LastError,
+		LastFound:     s.LastFound,
+		LastNew:       s.LastNew,
+	}
+	if !s.LastRunAt.IsZero() {
+		at := s.LastRunAt
+		out.LastRunAt = &at
+	}
+	return out
+}
+
+func toJobs(jobs []domain.Job) []tools.JobSearchJob {
+	out := make([]tools.JobSearchJob, 0, len(jobs))
+	for _, j := range jobs {
+		skills := make([]string, 0, len(j.Skills))
+		for _, skill := range j.Skills {
+			skills = append(skills, skill.Name)
+		}
+		out = append(out, tools.JobSearchJob{
+			ID:        j.ID.String(),
+			Title:     j.Title,
+			Company:   j.Company.Name,
+			Location:  j.Location,
+			Remote:    j.Remote,
+			URL:       j.URL,
+			Source:    j.Source,
+			Score:     j.Score,
+			Skills:    skills,
+			FetchedAt: j.FetchedAt,
+		})
+	}
+	return out
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JobSnapshot is a stored version of a job's material content
+type JobSnapshot struct {
+	Hash        string
+	CapturedAt  time.Time
+	Title       string
+	Description string
+	Location    string
+	Remote      bool
+	URL         string
+	Score       float64
+}
+
+// ContentHash fingerprints the fields whose change is worth a snapshot.
+// Whitespace differences in the description are ignored
+func (j Job) ContentHash() string {
+	parts := []string{
+		strings.TrimSpace(j.Title),
+		strings.Join(strings.Fields(j.Description), " "),
+		strings.TrimSpace(j.Location),
+		strconv.FormatBool(j.Remote),
+		strings.TrimSpace(j.URL),
+		strconv.FormatFloat(j.Score, 'f', -1, 64),
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
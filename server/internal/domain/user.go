@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// User is an authenticated caller. Each (Issuer, Subject) pair maps to one
+// user; API keys use the issuer "apikey" and the key name as subject
+type User struct {
+	ID        string
+	Issuer    string
+	Subject   string
+	Email     string
+	Name      string
+	CreatedAt time.Time
+	LastSeen  time.Time
+}
@@ -0,0 +1,94 @@
+// Package identity carries the authenticated user of a request and maps
+// token subjects to stored users. Repositories read the user from the
+// context to scope user-owned data, while job postings stay shared
+package identity
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/repository"
+)
+
+// IssuerAPIKey is the issuer recorded for users authenticated by API key
+const IssuerAPIKey = "apikey"
+
+type userKey struct{}
+
+// NewContext returns a context carrying user
+func NewContext(ctx context.Context, user domain.User) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// FromContext returns the user of the request, if it was authenticated
+func FromContext(ctx context.Context) (domain.User, bool) {
+	user, ok := ctx.Value(userKey{}).(domain.User)
+	return user, ok
+}
+
+// OwnerID returns the ID that scopes user-owned data for the request. It is
+// empty when authentication is disabled, so a single-user server keeps one
+// shared data set, including data stored before ownership was tracked
+func OwnerID(ctx context.Context) string {
+	user, _ := FromContext(ctx)
+	return user.ID
+}
+
+type cachedUser struct {
+	user    domain.User
+	expires time.Time
+}
+
+// Resolver maps (issuer, subject) pairs to users. Resolved users are cached
+// for ttl so authenticated requests do not write to the graph every time
+type Resolver struct {
+	repo  repository.UserRepository
+	ttl   time.Duration
+	clock func() time.Time
+
+	mu    sync.Mutex
+	cache map[[2]string]cachedUser
+}
+
+// NewResolver creates a resolver over repo
+func NewResolver(repo repository.UserRepository, ttl time.Duration) *Resolver {
+	return &Resolver{
+		repo:  repo,
+		ttl:   ttl,
+		clock: time.Now,
+		cache: make(map[[2]string]cachedUser),
+	}
+}
+
+// Resolve returns the user for the token subject, creating it on first use
+func (r *Resolver) Resolve(ctx context.Context, issuer, subject, email, name string) (domain.User, error) {
+	key := [2]string{issuer, subject}
+	now := r.clock()
+
+	r.mu.Lock()
+	cached, ok := r.cache[key]
+	r.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.user, nil
+	}
+
+	user, err := r.repo.ResolveUser(ctx, domain.User{
+		ID:      uuid.NewString(),
+		Issuer:  issuer,
+		Subject: subject,
+		Email:   email,
+		Name:    name,
+	}, now)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	r.mu.Lock()
+	r.cache[key] = cachedUser{user: user, expires: now.Add(r.ttl)}
+	r.mu.Unlock()
+	return user, nil
+}
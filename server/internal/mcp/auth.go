@@ -0,0 +1,311 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/identity"
+	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+	"github.com/honeycarbs/project-ets/pkg/apikey"
+	"github.com/honeycarbs/project-ets/pkg/jwtauth"
+	"github.com/honeycarbs/project-ets/pkg/logging"
+)
+
+// staticKeyTTL is the expiration reported for keys without one; keys are
+// looked up on every request, so it only satisfies the SDK's expiry check
+const staticKeyTTL = time.Hour
+
+// protectedResourcePath is the RFC 9728 well-known metadata location
+const protectedResourcePath = "/.well-known/oauth-protected-resource"
+
+// ResourceMetadata is the OAuth protected resource metadata (RFC 9728) MCP
+// clients read to find the authorization server
+type ResourceMetadata struct {
+	Resource               string   `json:"resource"`
+	AuthorizationServers   []string `json:"authorization_servers"`
+	ScopesSupported        []string `json:"scopes_supported"`
+	BearerMethodsSupported []string `json:"bearer_methods_supported"`
+	ResourceName           string   `json:"resource_name,omitempty"`
+}
+
+// Authenticator verifies bearer tokens on the HTTP endpoints. It accepts
+// static API keys and, when an issuer is configured, JWT access tokens, and
+// maps both to a user. A nil Authenticator means authentication is disabled
+type Authenticator struct {
+	keys     *apikey.Store
+	tokens   *jwtauth.Validator
+	users    *identity.Resolver
+	metadata *ResourceMetadata
+}
+
+// NewAuthenticator creates an authenticator. keys, tokens and metadata may
+// be nil; metadata is required for clients to discover the OAuth flow
+func NewAuthenticator(keys *apikey.Store, tokens *jwtauth.Validator, users *identity.Resolver, metadata *ResourceMetadata) *Authenticator {
+	return &Authenticator{keys: keys, tokens: tokens, users: users, metadata: metadata}
+}
+
+// verify implements auth.TokenVerifier. Tokens shaped like a JWS go to the
+// JWT validator when one is configured; everything else is an API key
+func (a *Authenticator) verify(ctx context.Context, token string, _ *http.Request) (*auth.TokenInfo, error) {
+	if a.tokens != nil && strings.Count(token, ".") == 2 {
+		claims, err := a.tokens.Validate(ctx, token)
+		if errors.Is(err, jwtauth.ErrInvalidToken) {
+			return nil, fmt.Errorf("%w: %v", auth.ErrInvalidToken, err)
+		}
+		if err != nil {
+			return nil, err
+		}
+		user, err := a.resolve(ctx, claims.Issuer, claims.Subject, claims.Email, claims.Name)
+		if err != nil {
+			return nil, err
+		}
+		return &auth.TokenInfo{
+			Scopes:     claims.Scopes,
+			Expiration: claims.ExpiresAt,
+			Extra:      map[string]any{"user": user},
+		}, nil
+	}
+
+	key, ok := a.keys.Lookup(token)
+	if !ok {
+		return nil, auth.ErrInvalidToken
+	}
+	user, err := a.resolve(ctx, identity.IssuerAPIKey, key.Name, "", key.Name)
+	if err != nil {
+		return nil, err
+	}
+	expires := key.ExpiresAt
+	if expires.IsZero() {
+		expires = time.Now().Add(staticKeyTTL)
+	}
+	return &auth.TokenInfo{
+		Scopes:     key.Scopes,
+		Expiration: expires,
+		Extra:      map[string]any{"user": user},
+	}, nil
+}
+
+func (a *Authenticator) resolve(ctx context.Context, issuer, subject, email, name string) (domain.User, error) {
+	if a.users == nil {
+		return domain.User{Issuer: issuer, Subject: subject, Email: email, Name: name}, nil
+	}
+	user, err := a.users.Resolve(ctx, issuer, subject, email, name)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("resolve user: %w", err)
+	}
+	return user, nil
+}
+
+// require rejects requests without a valid token holding every scope in
+// scopes and puts the caller's user on the request context. With
+// authentication disabled it passes requests through
+func (a *Authenticator) require(scopes ...string) func(http.Handler) http.Handler {
+	if a == nil {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	opts := &auth.RequireBearerTokenOptions{Scopes: scopes}
+	if a.metadata != nil {
+		// The SDK writes the value as is; the URL must be quoted to be a
+		// valid auth-param
+		opts.ResourceMetadataURL = `"` + a.metadataURL() + `"`
+	}
+	bearer := auth.RequireBearerToken(a.verify, opts)
+
+	return func(next http.Handler) http.Handler {
+		return bearer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if user, ok := tokenUser(auth.TokenInfoFromContext(r.Context())); ok {
+				r = r.WithContext(identity.NewContext(r.Context(), user))
+			}
+			next.ServeHTTP(w, r)
+		}))
+	}
+}
+
+// metadataURL inserts the well-known path before the resource path
+func (a *Authenticator) metadataURL() string {
+	u, err := url.Parse(a.metadata.Resource)
+	if err != nil {
+		return a.metadata.Resource
+	}
+	u.Path = protectedResourcePath + strings.TrimSuffix(u.Path, "/")
+	return u.String()
+}
+
+// metadataHandler serves the protected resource metadata
+func (a *Authenticator) metadataHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(a.metadata)
+	})
+}
+
+// handleMetadata registers the metadata document at the root well-known
+// path and at the path derived from the resource URL
+func (a *Authenticator) handleMetadata(mux *http.ServeMux, origins []string) {
+	if a == nil || a.metadata == nil {
+		return
+	}
+	handler := cors(origins, a.metadataHandler())
+	mux.Handle(protectedResourcePath, handler)
+	if u, err := url.Parse(a.metadataURL()); err == nil && u.Path != protectedResourcePath {
+		mux.Handle(u.Path, handler)
+	}
+}
+
+func tokenUser(info *auth.TokenInfo) (domain.User, bool) {
+	if info == nil {
+		return domain.User{}, false
+	}
+	user, ok := info.Extra["user"].(domain.User)
+	return user, ok
+}
+
+// queryToken lets clients that cannot set headers, such as calendar apps,
+// pass the API key as an access_token query parameter (RFC 6750 section 2.3)
+func queryToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token := r.URL.Query().Get("access_token"); token != "" && r.Header.Get("Authorization") == "" {
+			r = r.Clone(r.Context())
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authMiddleware puts the caller's user on the context of every MCP request
+// and checks requests against the token's scopes: tools/list only shows
+// callable tools, and resource reads and prompts outside the scopes are
+// refused. Tool calls are checked by requireScopes in the tool chain.
+// enforce is false when authentication is disabled
+func authMiddleware(enforce bool) sdkmcp.Middleware {
+	return func(next sdkmcp.MethodHandler) sdkmcp.MethodHandler {
+		return func(ctx context.Context, method string, req sdkmcp.Request) (sdkmcp.Result, error) {
+			if !enforce {
+				return next(ctx, method, req)
+			}
+
+			var info *auth.TokenInfo
+			if extra := req.GetExtra(); extra != nil {
+				info = extra.TokenInfo
+			}
+			if user, ok := tokenUser(info); ok {
+				ctx = identity.NewContext(ctx, user)
+			}
+			allowed := func(scope string) bool {
+				return hasScope(info, scope)
+			}
+
+			switch r := req.(type) {
+			case *sdkmcp.ReadResourceRequest:
+				if scope := tools.ResourceScope(r.Params.URI); !allowed(scope) {
+					return nil, fmt.Errorf("forbidden: reading %s requires the %q scope", r.Params.URI, scope)
+				}
+			case *sdkmcp.GetPromptRequest:
+				if !allowed(tools.ScopeRead) {
+					return nil, fmt.Errorf("forbidden: prompts require the %q scope", tools.ScopeRead)
+				}
+			}
+
+			result, err := next(ctx, method, req)
+			if list, ok := result.(*sdkmcp.ListToolsResult); ok && err == nil {
+				visible := list.Tools[:0:0]
+				for _, tool := range list.Tools {
+					if allowed(tools.ToolScope(tool.Name)) {
+						visible = append(visible, tool)
+					}
+				}
+				list.Tools = visible
+			}
+			return result, err
+		}
+	}
+}
+
+// requireScopes refuses tool calls whose token lacks a scope the call needs,
+// see tools.CallScopes. It runs inside authMiddleware, which has put the
+// caller on the context
+func requireScopes(enforce bool, log *logging.Logger) tools.ToolMiddleware {
+	return func(next tools.ToolHandler) tools.ToolHandler {
+		if !enforce {
+			return next
+		}
+		return func(ctx context.Context, call *tools.ToolCall) (*sdkmcp.CallToolResult, any, error) {
+			var info *auth.TokenInfo
+			if call.Request != nil && call.Request.Extra != nil {
+				info = call.Request.Extra.TokenInfo
+			}
+			for _, scope := range tools.CallScopes(call.Tool, call.Params) {
+				if !hasScope(info, scope) {
+					return refuseScope(ctx, log, call, scope), nil, nil
+				}
+			}
+			return next(ctx, call)
+		}
+	}
+}
+
+func refuseScope(ctx context.Context, log *logging.Logger, call *tools.ToolCall, scope string) *sdkmcp.CallToolResult {
+	user, _ := identity.FromContext(ctx)
+	log.Warn("tool call refused: missing scope", "tool", call.Tool, "request_id", call.RequestID, "scope", scope, "user", user.ID, "subject", user.Subject)
+	return &sdkmcp.CallToolResult{
+		Content: []sdkmcp.Content{
+			&sdkmcp.TextContent{Text: fmt.Sprintf("[%s] forbidden: this token lacks the %q scope", call.Tool, scope)},
+		},
+		IsError: true,
+	}
+}
+
+func hasScope(info *auth.TokenInfo, scope string) bool {
+	return info != nil && slices.Contains(info.Scopes, scope)
+}
+
+// cors applies the origin allowlist. Requests without an Origin header come
+// from non-browser clients and pass unchanged; browser requests from other
+// origins are refused
+func cors(allowed []string, next http.Handler) http.Handler {
+	anyOrigin := slices.Contains(allowed, "*")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !anyOrigin && !slices.Contains(allowed, origin) {
+			http.Error(w, "origin not allowed", http.StatusForbidden)
+			return
+		}
+
+		h := w.Header()
+		if anyOrigin {
+			h.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			h.Set("Access-Control-Allow-Origin", origin)
+			h.Add("Vary", "Origin")
+		}
+		h.Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+		h.Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Accept, Mcp-Session-Id, Mcp-Protocol-Version, Last-Event-ID")
+		h.Set("Access-Control-Expose-Headers", "Mcp-Session-Id, WWW-Authenticate")
+
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
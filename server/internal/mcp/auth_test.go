@@ -0,0 +1,249 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/honeycarbs/project-ets/internal/config"
+	"github.com/honeycarbs/project-ets/internal/identity"
+	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+	"github.com/honeycarbs/project-ets/pkg/apikey"
+	"github.com/honeycarbs/project-ets/pkg/jwtauth"
+	"github.com/honeycarbs/project-ets/pkg/jwtauth/jwtauthtest"
+	"github.com/honeycarbs/project-ets/pkg/logging"
+)
+
+type bearerTransport struct{ token string }
+
+func (t bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+type whoamiParams struct{}
+
+// newAuthTestServer serves a whoami tool (read scope, via profile_get), a
+// job_search tool (write scope) and a graph_tool stub (graph scope) behind an authenticator trusting issuer
+// and one read-only API key
+func newAuthTestServer(t *testing.T, issuer *jwtauthtest.Issuer) *httptest.Server {
+	t.Helper()
+	log := logging.New("error")
+
+	var ts *httptest.Server
+	ts = httptest.NewUnstartedServer(nil)
+	resource := "http://" + ts.Listener.Addr().String() + "/mcp/stream"
+
+	meta, err := jwtauth.Discover(context.Background(), issuer.URL, nil)
+	if err != nil {
+		t.Fatalf("discover: %v", err)
+	}
+	validator := jwtauth.NewValidator(jwtauth.Config{Issuer: issuer.URL, Audience: resource}, jwtauth.NewKeySet(meta.JWKSURI, nil))
+	keys, err := apikey.NewStore([]apikey.Key{{Name: "reader", Hash: apikey.Hash("reader-key"), Scopes: []string{"read"}}})
+	if err != nil {
+		t.Fatalf("keys: %v", err)
+	}
+
+	res := &Resources{Auth: NewAuthenticator(keys, validator, nil, &ResourceMetadata{
+		Resource:               resource,
+		AuthorizationServers:   []string{issuer.URL},
+		ScopesSupported:        []string{"read", "write", "graph"},
+		BearerMethodsSupported: []string{"header"},
+	})}
+
+	server := sdkmcp.NewServer(&sdkmcp.Implementation{Name: "test", Version: "0"}, nil)
+	server.AddReceivingMiddleware(authMiddleware(true))
+	whoami := func(ctx context.Context, _ *whoamiParams) (*sdkmcp.CallToolResult, any, error) {
+		user, ok := identity.FromContext(ctx)
+		if !ok {
+			return textContent("anonymous"), nil, nil
+		}
+		return textContent(user.Issuer + " " + user.Subject), nil, nil
+	}
+	toolServer := tools.NewToolServer(server, log, requireScopes(true, log))
+	tools.AddTool(toolServer, &sdkmcp.Tool{Name: "profile_get"}, whoami)
+	tools.AddTool(toolServer, &sdkmcp.Tool{Name: "job_search"}, whoami)
+	tools.AddTool(toolServer, &sdkmcp.Tool{Name: "graph_tool"}, func(ctx context.Context, _ *tools.GraphToolParams) (*sdkmcp.CallToolResult, any, error) {
+		return textContent("ran"), nil, nil
+	})
+	tools.AddTool(toolServer, &sdkmcp.Tool{Name: "saved_search_save"}, func(ctx context.Context, _ *tools.SavedSearchSaveParams) (*sdkmcp.CallToolResult, any, error) {
+		return textContent("saved"), nil, nil
+	})
+
+	ts.Config.Handler = newMux(log, config.Config{}, server, res)
+	ts.Start()
+	// Registered before the client sessions, so it runs after they close
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func textContent(text string) *sdkmcp.CallToolResult {
+	return &sdkmcp.CallToolResult{Content: []sdkmcp.Content{&sdkmcp.TextContent{Text: text}}}
+}
+
+func connect(t *testing.T, url, token string) *sdkmcp.ClientSession {
+	t.Helper()
+	client := sdkmcp.NewClient(&sdkmcp.Implementation{Name: "test-client", Version: "0"}, nil)
+	session, err := client.Connect(context.Background(), &sdkmcp.StreamableClientTransport{
+		Endpoint:   url + "/mcp/stream",
+		HTTPClient: &http.Client{Transport: bearerTransport{token: token}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+func callText(t *testing.T, session *sdkmcp.ClientSession, tool string) (string, bool) {
+	t.Helper()
+	return callTextWith(t, session, tool, map[string]any{})
+}
+
+func callTextWith(t *testing.T, session *sdkmcp.ClientSession, tool string, args map[string]any) (string, bool) {
+	t.Helper()
+	res, err := session.CallTool(context.Background(), &sdkmcp.CallToolParams{Name: tool, Arguments: args})
+	if err != nil {
+		t.Fatalf("call %s: %v", tool, err)
+	}
+	return res.Content[0].(*sdkmcp.TextContent).Text, res.IsError
+}
+
+func TestProtectedResourceMetadata(t *testing.T) {
+	issuer := jwtauthtest.NewIssuer()
+	defer issuer.Close()
+	ts := newAuthTestServer(t, issuer)
+
+	resp, err := http.Post(ts.URL+"/mcp/stream", "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	want := `Bearer resource_metadata="` + ts.URL + `/.well-known/oauth-protected-resource/mcp/stream"`
+	if resp.StatusCode != http.StatusUnauthorized || resp.Header.Get("WWW-Authenticate") != want {
+		t.Fatalf("unauthenticated request: %d %q, want 401 %q", resp.StatusCode, resp.Header.Get("WWW-Authenticate"), want)
+	}
+
+	for _, path := range []string{"/.well-known/oauth-protected-resource", "/.well-known/oauth-protected-resource/mcp/stream"} {
+		resp, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var meta ResourceMetadata
+		err = json.NewDecoder(resp.Body).Decode(&meta)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		if meta.Resource != ts.URL+"/mcp/stream" || len(meta.AuthorizationServers) != 1 || meta.AuthorizationServers[0] != issuer.URL {
+			t.Errorf("%s: unexpected metadata %+v", path, meta)
+		}
+	}
+}
+
+func TestAccessTokenIdentityAndScopes(t *testing.T) {
+	issuer := jwtauthtest.NewIssuer()
+	defer issuer.Close()
+	ts := newAuthTestServer(t, issuer)
+
+	reader := connect(t, ts.URL, issuer.Token("alice", ts.URL+"/mcp/stream", "read"))
+	if text, isErr := callText(t, reader, "profile_get"); isErr || text != issuer.URL+" alice" {
+		t.Errorf("profile_get = %q (error %v), want the token subject", text, isErr)
+	}
+	if text, isErr := callText(t, reader, "job_search"); !isErr || !strings.Contains(text, "forbidden") {
+		t.Errorf("job_search with read scope = %q (error %v), want forbidden", text, isErr)
+	}
+	list, err := reader.ListTools(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Tools) != 1 || list.Tools[0].Name != "profile_get" {
+		t.Errorf("tools/list shows %d tools, want only profile_get", len(list.Tools))
+	}
+
+	writer := connect(t, ts.URL, issuer.Token("bob", ts.URL+"/mcp/stream", "read", "write"))
+	if text, isErr := callText(t, writer, "job_search"); isErr || text != issuer.URL+" bob" {
+		t.Errorf("job_search with write scope = %q (error %v)", text, isErr)
+	}
+
+	key := connect(t, ts.URL, "reader-key")
+	if text, _ := callText(t, key, "profile_get"); text != identity.IssuerAPIKey+" reader" {
+		t.Errorf("api key identity = %q", text)
+	}
+}
+
+func TestFreeFormCypherNeedsAdmin(t *testing.T) {
+	issuer := jwtauthtest.NewIssuer()
+	defer issuer.Close()
+	ts := newAuthTestServer(t, issuer)
+
+	// Cypher is not scoped to the caller, so a graph token could read every
+	// user's candidates, applications and keywords with it
+	cypher := map[string]any{"cypher": "MATCH (c:Candidate) RETURN c"}
+	saved := map[string]any{"query_name": "top_keywords"}
+
+	graph := connect(t, ts.URL, issuer.Token("bob", ts.URL+"/mcp/stream", "graph"))
+	if text, isErr := callTextWith(t, graph, "graph_tool", cypher); !isErr || !strings.Contains(text, `lacks the "admin" scope`) {
+		t.Errorf("cypher with graph scope = %q (error %v), want forbidden", text, isErr)
+	}
+	if text, isErr := callTextWith(t, graph, "graph_tool", saved); isErr {
+		t.Errorf("saved query with graph scope = %q, want it to run", text)
+	}
+
+	admin := connect(t, ts.URL, issuer.Token("carol", ts.URL+"/mcp/stream", "graph", "admin"))
+	if text, isErr := callTextWith(t, admin, "graph_tool", cypher); isErr {
+		t.Errorf("cypher with admin scope = %q, want it to run", text)
+	}
+}
+
+func TestNotificationRoutingNeedsAdmin(t *testing.T) {
+	issuer := jwtauthtest.NewIssuer()
+	defer issuer.Close()
+	ts := newAuthTestServer(t, issuer)
+
+	// Channels are server-wide, so a write token could otherwise send its
+	// results to any configured webhook or mailbox
+	routed := map[string]any{"query": "go developer", "notify": []any{"team"}}
+	plain := map[string]any{"query": "go developer"}
+
+	writer := connect(t, ts.URL, issuer.Token("bob", ts.URL+"/mcp/stream", "write"))
+	if text, isErr := callTextWith(t, writer, "saved_search_save", routed); !isErr || !strings.Contains(text, `lacks the "admin" scope`) {
+		t.Errorf("routed search with write scope = %q (error %v), want forbidden", text, isErr)
+	}
+	if text, isErr := callTextWith(t, writer, "saved_search_save", plain); isErr {
+		t.Errorf("unrouted search with write scope = %q, want it to save", text)
+	}
+
+	admin := connect(t, ts.URL, issuer.Token("carol", ts.URL+"/mcp/stream", "write", "admin"))
+	if text, isErr := callTextWith(t, admin, "saved_search_save", routed); isErr {
+		t.Errorf("routed search with admin scope = %q, want it to save", text)
+	}
+}
+
+func TestRejectsTokensForOtherResources(t *testing.T) {
+	issuer := jwtauthtest.NewIssuer()
+	defer issuer.Close()
+	ts := newAuthTestServer(t, issuer)
+
+	for name, token := range map[string]string{
+		"other audience": issuer.Token("alice", "https://other.example.com/mcp", "read"),
+		"unknown key":    "not-a-key",
+	} {
+		req, _ := http.NewRequest(http.MethodPost, ts.URL+"/mcp/stream", strings.NewReader("{}"))
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("%s: status %d, want 401", name, resp.StatusCode)
+		}
+	}
+}
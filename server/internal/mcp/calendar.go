@@ -0,0 +1,41 @@
+package mcp
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+	"github.com/honeycarbs/project-ets/pkg/logging"
+)
+
+// calendarHandler serves events as an iCalendar feed that calendar apps can
+// subscribe to. candidate_id narrows the feed to one candidate's events
+func calendarHandler(events tools.EventService, log *logging.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if events == nil {
+			http.Error(w, "calendar not configured", http.StatusServiceUnavailable)
+			return
+		}
+
+		candidateID := strings.TrimSpace(r.URL.Query().Get("candidate_id"))
+		feed, err := events.CalendarFeed(r.Context(), candidateID)
+		if err != nil {
+			log.Error("calendar feed failed", "candidate_id", candidateID, "err", err)
+			http.Error(w, "failed to build calendar", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		w.Header().Set("Content-Disposition", `inline; filename="events.ics"`)
+		w.Header().Set("Cache-Control", "no-cache")
+		if r.Method == http.MethodHead {
+			return
+		}
+		_, _ = w.Write(feed)
+	})
+}
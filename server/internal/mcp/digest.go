@@ -0,0 +1,51 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+	"github.com/honeycarbs/project-ets/pkg/logging"
+)
+
+// digestHandler serves the todo digest as JSON. candidate_id narrows the
+// digest to one candidate and horizon_days sets the upcoming window
+func digestHandler(reminders tools.ReminderService, log *logging.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", "GET")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if reminders == nil {
+			http.Error(w, "reminders not configured", http.StatusServiceUnavailable)
+			return
+		}
+
+		query := r.URL.Query()
+		params := tools.TodoDigestParams{CandidateID: strings.TrimSpace(query.Get("candidate_id"))}
+		if raw := strings.TrimSpace(query.Get("horizon_days")); raw != "" {
+			days, err := strconv.Atoi(raw)
+			if err != nil || days < 0 {
+				http.Error(w, "invalid horizon_days", http.StatusBadRequest)
+				return
+			}
+			params.HorizonDays = days
+		}
+
+		digest, err := reminders.Digest(r.Context(), params)
+		if err != nil {
+			log.Error("todo digest failed", "candidate_id", params.CandidateID, "err", err)
+			http.Error(w, "failed to build digest", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-cache")
+		if err := json.NewEncoder(w).Encode(digest); err != nil {
+			log.Error("todo digest encode failed", "err", err)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/honeycarbs/project-ets/internal/identity"
+	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+	"github.com/honeycarbs/project-ets/pkg/logging"
+	"github.com/honeycarbs/project-ets/pkg/ratelimit"
+)
+
+// anonymousClient is the rate limit client of every call when
+// authentication is disabled
+const anonymousClient = "anonymous"
+
+// RateLimitError is the structured content of a refused tool call
+type RateLimitError struct {
+	Error             string  `json:"error"`  // always "rate_limited"
+	Reason            string  `json:"reason"` // "rate" or "quota"
+	Tool              string  `json:"tool"`
+	Client            string  `json:"client"`
+	Limit             string  `json:"limit"` // the policy that refused, such as "job_search: 10 calls per minute"
+	RetryAfterSeconds int     `json:"retry_after_seconds"`
+	PerMinute         float64 `json:"per_minute,omitempty"`
+	Daily             int     `json:"daily,omitempty"`
+}
+
+// rateLimit refuses tool calls over the caller's rate limits or daily
+// quotas with a tool error telling the client when to retry. It reads the
+// caller from the context, which authMiddleware sets
+func rateLimit(limiter *ratelimit.Limiter, log *logging.Logger) tools.ToolMiddleware {
+	return func(next tools.ToolHandler) tools.ToolHandler {
+		return func(ctx context.Context, call *tools.ToolCall) (*sdkmcp.CallToolResult, any, error) {
+			key, client := rateLimitClient(ctx)
+			decision := limiter.Allow(key, client, call.Tool)
+			if decision.Allowed {
+				return next(ctx, call)
+			}
+
+			retryAfter := int(math.Ceil(decision.RetryAfter.Seconds()))
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+			limit := describeLimit(decision)
+			log.Warn("tool call rate limited", "tool", call.Tool, "request_id", call.RequestID, "client", client, "reason", decision.Reason, "limit", limit, "retry_after_s", retryAfter)
+
+			refusal := RateLimitError{
+				Error:             "rate_limited",
+				Reason:            decision.Reason,
+				Tool:              call.Tool,
+				Client:            client,
+				Limit:             limit,
+				RetryAfterSeconds: retryAfter,
+				PerMinute:         decision.Policy.PerMinute,
+				Daily:             decision.Policy.Daily,
+			}
+			return &sdkmcp.CallToolResult{
+				Content: []sdkmcp.Content{
+					&sdkmcp.TextContent{Text: fmt.Sprintf("[%s] rate limited (%s), retry after %d seconds", call.Tool, limit, retryAfter)},
+				},
+				StructuredContent: refusal,
+				IsError:           true,
+			}, refusal, nil
+		}
+	}
+}
+
+// rateLimitClient returns the key of the caller's counters and the name
+// policies refer to it by: the API key name or the token subject. Counters
+// are kept per user, the owner ID of identity.OwnerID, since a subject is
+// only unique within its issuer
+func rateLimitClient(ctx context.Context) (key, name string) {
+	user, ok := identity.FromContext(ctx)
+	if !ok || user.Subject == "" {
+		return anonymousClient, anonymousClient
+	}
+	if user.ID == "" {
+		// Without a user store the user has no ID; the issuer and subject
+		// are what the ID would be resolved from
+		return user.Issuer + " " + user.Subject, user.Subject
+	}
+	return user.ID, user.Subject
+}
+
+func describeLimit(d ratelimit.Decision) string {
+	subject := "all tools"
+	if d.Tool != "" {
+		subject = d.Tool
+	}
+	if d.Reason == ratelimit.ReasonQuota {
+		return fmt.Sprintf("%s: %d calls per day", subject, d.Policy.Daily)
+	}
+	return fmt.Sprintf("%s: %g calls per minute", subject, d.Policy.PerMinute)
+}
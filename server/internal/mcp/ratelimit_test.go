@@ -0,0 +1,110 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/identity"
+	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+	"github.com/honeycarbs/project-ets/pkg/logging"
+	"github.com/honeycarbs/project-ets/pkg/ratelimit"
+)
+
+type searchParams struct {
+	As     string `json:"as"`
+	Issuer string `json:"issuer,omitempty"`
+}
+
+// asCaller stands in for authMiddleware, taking the caller from the call's
+// "as" and "issuer" arguments and deriving a user ID from both the way the
+// identity resolver keeps one user per issuer and subject
+func asCaller(next sdkmcp.MethodHandler) sdkmcp.MethodHandler {
+	return func(ctx context.Context, method string, req sdkmcp.Request) (sdkmcp.Result, error) {
+		if call, ok := req.(*sdkmcp.CallToolRequest); ok {
+			var params searchParams
+			_ = json.Unmarshal(call.Params.Arguments, &params)
+			if params.As != "" {
+				ctx = identity.NewContext(ctx, domain.User{
+					ID:      params.Issuer + "/" + params.As,
+					Issuer:  params.Issuer,
+					Subject: params.As,
+				})
+			}
+		}
+		return next(ctx, method, req)
+	}
+}
+
+func TestRateLimitedToolCalls(t *testing.T) {
+	limiter, err := ratelimit.New(ratelimit.Config{Policies: ratelimit.Policies{
+		Tools: map[string]ratelimit.Policy{"job_search": {PerMinute: 1}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := sdkmcp.NewServer(&sdkmcp.Implementation{Name: "test", Version: "0"}, nil)
+	server.AddReceivingMiddleware(asCaller)
+	log := logging.New("error")
+	toolServer := tools.NewToolServer(server, log, rateLimit(limiter, log))
+	tools.AddTool(toolServer, &sdkmcp.Tool{Name: "job_search"}, func(ctx context.Context, _ *searchParams) (*sdkmcp.CallToolResult, any, error) {
+		return textContent("ok"), nil, nil
+	})
+
+	serverTransport, clientTransport := sdkmcp.NewInMemoryTransports()
+	if _, err := server.Connect(context.Background(), serverTransport, nil); err != nil {
+		t.Fatal(err)
+	}
+	client := sdkmcp.NewClient(&sdkmcp.Implementation{Name: "test-client", Version: "0"}, nil)
+	session, err := client.Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+
+	callFrom := func(issuer, as string) *sdkmcp.CallToolResult {
+		t.Helper()
+		res, err := session.CallTool(context.Background(), &sdkmcp.CallToolParams{Name: "job_search", Arguments: map[string]any{"as": as, "issuer": issuer}})
+		if err != nil {
+			t.Fatalf("call as %s: %v", as, err)
+		}
+		return res
+	}
+	call := func(as string) *sdkmcp.CallToolResult { return callFrom("https://issuer", as) }
+
+	if res := call("alice"); res.IsError {
+		t.Fatal("first call refused")
+	}
+	res := call("alice")
+	text := res.Content[0].(*sdkmcp.TextContent).Text
+	if !res.IsError || !strings.Contains(text, "rate limited") || !strings.Contains(text, "retry after 60 seconds") {
+		t.Fatalf("second call = %q (error %v), want rate limited with a retry delay", text, res.IsError)
+	}
+	data, _ := json.Marshal(res.StructuredContent)
+	var refusal RateLimitError
+	if err := json.Unmarshal(data, &refusal); err != nil {
+		t.Fatal(err)
+	}
+	if refusal.Error != "rate_limited" || refusal.Client != "alice" || refusal.RetryAfterSeconds != 60 {
+		t.Errorf("structured refusal = %+v", refusal)
+	}
+
+	if res := call("bob"); res.IsError {
+		t.Error("bob was limited by alice's calls")
+	}
+	if res := callFrom(identity.IssuerAPIKey, "alice"); res.IsError {
+		t.Error("an API key named alice was limited by the token subject alice")
+	}
+	if res := call(""); res.IsError {
+		t.Error("first anonymous call refused")
+	}
+	usage := limiter.Usage(anonymousClient)
+	if len(usage) == 0 || usage[0].Allowed != 1 {
+		t.Errorf("anonymous usage = %+v", usage)
+	}
+}
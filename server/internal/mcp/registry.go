@@ -1,53 +1,161 @@
 package mcp
 
 import (
+	"time"
+
 	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/honeycarbs/project-ets/internal/domain/job"
+	"github.com/honeycarbs/project-ets/internal/domain/savedsearch"
 	"github.com/honeycarbs/project-ets/internal/mcp/tools"
 	"github.com/honeycarbs/project-ets/internal/repository"
 	"github.com/honeycarbs/project-ets/pkg/logging"
 	n4j "github.com/honeycarbs/project-ets/pkg/neo4j"
+	"github.com/honeycarbs/project-ets/pkg/ratelimit"
 )
 
 type ToolRegistry struct {
-	logger *logging.Logger
+	logger  *logging.Logger
+	timeout time.Duration
 }
 
 type Resources struct {
-	JobService   job.Service
-	JobRepo      repository.JobRepository
-	KeywordRepo  tools.KeywordRepository
-	AnalysisSvc  tools.AnalysisService
-	SheetsClient tools.SheetsClient
-	Neo4jClient  *n4j.Client
+	JobService     job.Service
+	JobRepo        repository.JobRepository
+	KeywordRepo    tools.KeywordRepository
+	CandidateRepo  repository.CandidateRepository
+	AnalysisSvc    tools.AnalysisService
+	EmployerSvc    tools.EmployerService
+	GhostSvc       tools.GhostService
+	LifecycleSvc   tools.LifecycleService
+	HistorySvc     tools.JobHistoryService
+	ApplicationSvc tools.ApplicationService
+	EventSvc       tools.EventService
+	ContactSvc     tools.ContactService
+	ReminderSvc    tools.ReminderService
+	SavedSearchSvc tools.SavedSearchService
+	Scheduler      *savedsearch.Scheduler
+	SheetsClient   tools.SheetsClient
+	Neo4jClient    *n4j.Client
+	GraphLimits    tools.GraphToolLimits
+	SavedQueries   tools.SavedQueryCatalog
+	GraphSchema    tools.GraphSchemaProvider
+	GraphExporter  tools.GraphExporter
+	Auth           *Authenticator
+	Limiter        *ratelimit.Limiter
 }
 
-func NewToolRegistry(logger *logging.Logger) *ToolRegistry {
-	return &ToolRegistry{logger: logger}
+// NewToolRegistry creates a registry whose tool calls are each bounded by
+// timeout; zero leaves them unbounded
+func NewToolRegistry(logger *logging.Logger, timeout time.Duration) *ToolRegistry {
+	return &ToolRegistry{logger: logger, timeout: timeout}
 }
 
+// RegisterAll registers every tool behind the same middleware chain, from
+// outermost: request IDs, logging, metrics, panic recovery, scope checks,
+// rate limits and the call timeout
 func (r *ToolRegistry) RegisterAll(server *sdkmcp.Server, res Resources) error {
-	if err := tools.RegisterJobTools(server, res.JobService, r.logger); err != nil {
+	metrics := tools.NewToolMetrics()
+	chain := []tools.ToolMiddleware{
+		tools.RequestIDs(),
+		tools.LogCalls(),
+		metrics.Middleware(),
+		tools.Recover(),
+		requireScopes(res.Auth != nil, r.logger),
+	}
+	var usage tools.UsageReporter
+	if res.Limiter != nil {
+		chain = append(chain, rateLimit(res.Limiter, r.logger))
+		usage = res.Limiter
+	}
+	chain = append(chain, tools.Timeout(r.timeout))
+	ts := tools.NewToolServer(server, r.logger, chain...)
+
+	if err := tools.RegisterJobTools(ts, res.JobService, res.GhostSvc); err != nil {
 		r.logger.Error("failed to register job tools", "err", err)
 		return err
 	}
 
-	if err := tools.RegisterAnalysisTools(server, res.KeywordRepo, res.AnalysisSvc, r.logger); err != nil {
+	if err := tools.RegisterAnalysisTools(ts, res.KeywordRepo, res.AnalysisSvc); err != nil {
 		r.logger.Error("failed to register analysis tools", "err", err)
 		return err
 	}
 
-	if err := tools.RegisterExportTools(server, res.SheetsClient, res.JobRepo, r.logger); err != nil {
+	if err := tools.RegisterProfileTools(ts, res.CandidateRepo); err != nil {
+		r.logger.Error("failed to register profile tools", "err", err)
+		return err
+	}
+
+	if err := tools.RegisterEmployerTools(ts, res.EmployerSvc); err != nil {
+		r.logger.Error("failed to register employer tools", "err", err)
+		return err
+	}
+
+	if err := tools.RegisterGhostTools(ts, res.GhostSvc); err != nil {
+		r.logger.Error("failed to register ghost tools", "err", err)
+		return err
+	}
+
+	if err := tools.RegisterLifecycleTools(ts, res.LifecycleSvc); err != nil {
+		r.logger.Error("failed to register lifecycle tools", "err", err)
+		return err
+	}
+
+	if err := tools.RegisterHistoryTools(ts, res.HistorySvc); err != nil {
+		r.logger.Error("failed to register history tools", "err", err)
+		return err
+	}
+
+	if err := tools.RegisterApplicationTools(ts, res.ApplicationSvc); err != nil {
+		r.logger.Error("failed to register application tools", "err", err)
+		return err
+	}
+
+	if err := tools.RegisterEventTools(ts, res.EventSvc); err != nil {
+		r.logger.Error("failed to register event tools", "err", err)
+		return err
+	}
+
+	if err := tools.RegisterContactTools(ts, res.ContactSvc); err != nil {
+		r.logger.Error("failed to register contact tools", "err", err)
+		return err
+	}
+
+	if err := tools.RegisterReminderTools(ts, res.ReminderSvc); err != nil {
+		r.logger.Error("failed to register reminder tools", "err", err)
+		return err
+	}
+
+	if err := tools.RegisterSavedSearchTools(ts, res.SavedSearchSvc); err != nil {
+		r.logger.Error("failed to register saved search tools", "err", err)
+		return err
+	}
+
+	if err := tools.RegisterExportTools(ts, res.SheetsClient, res.JobRepo); err != nil {
 		r.logger.Error("failed to register export tools", "err", err)
 		return err
 	}
 
-	if err := tools.RegisterGraphTool(server, res.Neo4jClient, r.logger); err != nil {
+	if err := tools.RegisterGraphTool(ts, res.Neo4jClient, res.SavedQueries, res.GraphSchema, res.GraphExporter, res.GraphLimits); err != nil {
 		r.logger.Error("failed to register graph tool", "err", err)
 		return err
 	}
 
+	if err := tools.RegisterUsageTools(ts, usage, metrics); err != nil {
+		r.logger.Error("failed to register usage tools", "err", err)
+		return err
+	}
+
+	if err := tools.RegisterSavedQueryResource(server, res.SavedQueries, r.logger); err != nil {
+		r.logger.Error("failed to register saved query resource", "err", err)
+		return err
+	}
+
+	if err := tools.RegisterGraphSchemaResource(server, res.GraphSchema, r.logger); err != nil {
+		r.logger.Error("failed to register graph schema resource", "err", err)
+		return err
+	}
+
 	r.logger.Info("all MCP tools registered successfully")
 	return nil
 }
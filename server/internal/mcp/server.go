@@ -12,9 +12,12 @@ import (
 
 	"github.com/honeycarbs/project-ets/internal/config"
 	"github.com/honeycarbs/project-ets/internal/domain/job"
+	"github.com/honeycarbs/project-ets/internal/domain/savedsearch"
 	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+	"github.com/honeycarbs/project-ets/internal/repository"
 	"github.com/honeycarbs/project-ets/pkg/logging"
 	n4j "github.com/honeycarbs/project-ets/pkg/neo4j"
+	"github.com/honeycarbs/project-ets/pkg/ratelimit"
 )
 
 // Server wraps the MCP SDK with an HTTP listener
@@ -25,6 +28,7 @@ type Server struct {
 	srv         *http.Server
 	started     atomic.Bool
 	neo4jClient *n4j.Client
+	scheduler   *savedsearch.Scheduler
 }
 
 // flushWriter wraps ResponseWriter to force immediate flushing for SSE streaming
@@ -42,6 +46,14 @@ func (fw *flushWriter) Write(p []byte) (n int, err error) {
 	return
 }
 
+// Flush lets the SDK flush SSE headers before the first event; without it the
+// wrapper hides http.Flusher and clients wait on the standalone GET stream
+func (fw *flushWriter) Flush() {
+	if fw.flusher != nil {
+		fw.flusher.Flush()
+	}
+}
+
 // Option allows callers to customize server resources
 type Option func(*Resources)
 
@@ -63,6 +75,15 @@ func WithKeywordRepository(repo tools.KeywordRepository) Option {
 	}
 }
 
+// WithCandidateRepository injects the candidate repository used by profile tools
+func WithCandidateRepository(repo repository.CandidateRepository) Option {
+	return func(res *Resources) {
+		if repo != nil {
+			res.CandidateRepo = repo
+		}
+	}
+}
+
 // WithAnalysisService injects the analysis service used by job_analysis
 func WithAnalysisService(service tools.AnalysisService) Option {
 	return func(res *Resources) {
@@ -72,6 +93,114 @@ func WithAnalysisService(service tools.AnalysisService) Option {
 	}
 }
 
+// WithEmployerService injects the employer service used by employer_profile and company_merge
+func WithEmployerService(service tools.EmployerService) Option {
+	return func(res *Resources) {
+		if service != nil {
+			res.EmployerSvc = service
+		}
+	}
+}
+
+// WithGhostService injects the ghost-posting service used by job_search, job_analysis and ghost_report
+func WithGhostService(service tools.GhostService) Option {
+	return func(res *Resources) {
+		if service != nil {
+			res.GhostSvc = service
+		}
+	}
+}
+
+// WithLifecycleService injects the lifecycle service used by job_recheck
+func WithLifecycleService(service tools.LifecycleService) Option {
+	return func(res *Resources) {
+		if service != nil {
+			res.LifecycleSvc = service
+		}
+	}
+}
+
+// WithHistoryService injects the history service used by job_history
+func WithHistoryService(service tools.JobHistoryService) Option {
+	return func(res *Resources) {
+		if service != nil {
+			res.HistorySvc = service
+		}
+	}
+}
+
+// WithApplicationService injects the application service used by application_update and application_list
+func WithApplicationService(service tools.ApplicationService) Option {
+	return func(res *Resources) {
+		if service != nil {
+			res.ApplicationSvc = service
+		}
+	}
+}
+
+// WithEventService injects the event service used by event_add, event_list and the calendar feed
+func WithEventService(service tools.EventService) Option {
+	return func(res *Resources) {
+		if service != nil {
+			res.EventSvc = service
+		}
+	}
+}
+
+// WithContactService injects the contact service used by contact_add and contact_search
+func WithContactService(service tools.ContactService) Option {
+	return func(res *Resources) {
+		if service != nil {
+			res.ContactSvc = service
+		}
+	}
+}
+
+// WithReminderService injects the reminder service used by todo_digest and the digest endpoint
+func WithReminderService(service tools.ReminderService) Option {
+	return func(res *Resources) {
+		if service != nil {
+			res.ReminderSvc = service
+		}
+	}
+}
+
+// WithSavedSearchService injects the saved search service used by the saved_search_* tools and new_since_last_run
+func WithSavedSearchService(service tools.SavedSearchService) Option {
+	return func(res *Resources) {
+		if service != nil {
+			res.SavedSearchSvc = service
+		}
+	}
+}
+
+// WithSavedSearchScheduler injects the scheduler that runs saved searches in the background
+func WithSavedSearchScheduler(scheduler *savedsearch.Scheduler) Option {
+	return func(res *Resources) {
+		if scheduler != nil {
+			res.Scheduler = scheduler
+		}
+	}
+}
+
+// WithAuthenticator injects the authenticator that verifies API keys and OAuth access tokens
+func WithAuthenticator(auth *Authenticator) Option {
+	return func(res *Resources) {
+		if auth != nil {
+			res.Auth = auth
+		}
+	}
+}
+
+// WithRateLimiter injects the limiter applied to tool calls and reported by usage_report
+func WithRateLimiter(limiter *ratelimit.Limiter) Option {
+	return func(res *Resources) {
+		if limiter != nil {
+			res.Limiter = limiter
+		}
+	}
+}
+
 // WithSheetsClient injects the sheets client used by sheets_export
 func WithSheetsClient(client tools.SheetsClient) Option {
 	return func(res *Resources) {
@@ -81,6 +210,33 @@ func WithSheetsClient(client tools.SheetsClient) Option {
 	}
 }
 
+// WithSavedQueryCatalog injects the saved query catalog used by graph_tool
+func WithSavedQueryCatalog(catalog tools.SavedQueryCatalog) Option {
+	return func(res *Resources) {
+		if catalog != nil {
+			res.SavedQueries = catalog
+		}
+	}
+}
+
+// WithGraphSchemaProvider injects the schema provider used by graph_tool and the schema resource
+func WithGraphSchemaProvider(provider tools.GraphSchemaProvider) Option {
+	return func(res *Resources) {
+		if provider != nil {
+			res.GraphSchema = provider
+		}
+	}
+}
+
+// WithGraphExporter injects the exporter used by graph_tool mode=export
+func WithGraphExporter(exporter tools.GraphExporter) Option {
+	return func(res *Resources) {
+		if exporter != nil {
+			res.GraphExporter = exporter
+		}
+	}
+}
+
 // NewServer builds the MCP HTTP server
 func NewServer(log *logging.Logger, cfg config.Config, opts ...Option) (*Server, error) {
 	impl := &sdkmcp.Implementation{
@@ -102,22 +258,38 @@ func NewServer(log *logging.Logger, cfg config.Config, opts ...Option) (*Server,
 		}
 	}
 
-	registry := NewToolRegistry(log)
+	mcpServer.AddReceivingMiddleware(authMiddleware(res.Auth != nil))
+
+	registry := NewToolRegistry(log, cfg.Tools.Timeout)
 	if err := registry.RegisterAll(mcpServer, *res); err != nil {
 		return nil, err
 	}
 
+	mux := newMux(log, cfg, mcpServer, res)
+
+	httpSrv := &http.Server{
+		Addr:              net.JoinHostPort(cfg.Host, cfg.Port),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	return &Server{
+		logger:      log,
+		config:      cfg,
+		srv:         httpSrv,
+		neo4jClient: res.Neo4jClient,
+		scheduler:   res.Scheduler,
+	}, nil
+}
+
+// newMux routes the MCP stream and the HTTP endpoints through CORS and authentication
+func newMux(log *logging.Logger, cfg config.Config, mcpServer *sdkmcp.Server, res *Resources) *http.ServeMux {
 	handler := sdkmcp.NewStreamableHTTPHandler(func(req *http.Request) *sdkmcp.Server {
 		return mcpServer
 	}, nil)
 
-	// Wrap handler with CORS support and SSE optimization for Cloud Run
-	corsHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Set CORS headers
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Accept")
-		
+	// Wrap handler with SSE optimization for Cloud Run; CORS and authentication are applied on the mux
+	streamHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Critical headers to disable buffering for SSE on Cloud Run
 		// Based on: https://docs.cloud.google.com/appengine/docs/flexible/how-requests-are-handled
 		w.Header().Set("X-Accel-Buffering", "no")
@@ -128,12 +300,6 @@ func NewServer(log *logging.Logger, cfg config.Config, opts ...Option) (*Server,
 		// Reference: https://www.googlecloudcommunity.com/gc/Serverless/Fastapi-StreamingResponse-on-Cloud-Run/td-p/874965
 		w.Header().Set("Transfer-Encoding", "chunked")
 		
-		// Handle preflight OPTIONS request
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
-		}
-		
 		// Log connection attempt for debugging
 		log.Info("MCP stream connection attempt",
 			"method", r.Method,
@@ -153,24 +319,17 @@ func NewServer(log *logging.Logger, cfg config.Config, opts ...Option) (*Server,
 	})
 
 	mux := http.NewServeMux()
-	mux.Handle("/mcp/stream", corsHandler)
+	origins := cfg.CORS.AllowedOrigins
+	mux.Handle("/mcp/stream", cors(origins, res.Auth.require()(streamHandler)))
+	mux.Handle("/calendar.ics", cors(origins, queryToken(res.Auth.require(tools.ScopeRead)(calendarHandler(res.EventSvc, log)))))
+	mux.Handle("/todo/digest", cors(origins, res.Auth.require(tools.ScopeRead)(digestHandler(res.ReminderSvc, log))))
+	res.Auth.handleMetadata(mux, origins)
 	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("ok"))
 	})
 
-	httpSrv := &http.Server{
-		Addr:              net.JoinHostPort(cfg.Host, cfg.Port),
-		Handler:           mux,
-		ReadHeaderTimeout: 5 * time.Second,
-	}
-
-	return &Server{
-		logger:      log,
-		config:      cfg,
-		srv:         httpSrv,
-		neo4jClient: res.Neo4jClient,
-	}, nil
+	return mux
 }
 
 // Run starts the HTTP server until shutdown
@@ -179,6 +338,8 @@ func (s *Server) Run() error {
 		return nil
 	}
 
+	s.scheduler.Start(context.Background())
+
 	s.logger.Info("MCP HTTP server listening", "addr", s.srv.Addr)
 
 	if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -191,6 +352,11 @@ func (s *Server) Run() error {
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.logger.Info("shutdown requested for MCP HTTP server")
 
+	// Stop scheduled runs first so they can record their outcome before Neo4j closes
+	if err := s.scheduler.Stop(ctx); err != nil {
+		s.logger.Warn("saved search scheduler did not stop in time", "err", err)
+	}
+
 	if s.neo4jClient != nil {
 		if err := s.neo4jClient.Close(ctx); err != nil {
 			s.logger.Warn("error during Neo4j cleanup", "err", err)
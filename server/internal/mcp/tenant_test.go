@@ -0,0 +1,217 @@
+package mcp
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/domain/application"
+	"github.com/honeycarbs/project-ets/internal/domain/contact"
+	"github.com/honeycarbs/project-ets/internal/domain/event"
+	"github.com/honeycarbs/project-ets/internal/domain/graphio"
+	"github.com/honeycarbs/project-ets/internal/domain/savedsearch"
+	"github.com/honeycarbs/project-ets/internal/identity"
+	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+	"github.com/honeycarbs/project-ets/internal/repository"
+	"github.com/honeycarbs/project-ets/pkg/logging"
+	n4j "github.com/honeycarbs/project-ets/pkg/neo4j"
+)
+
+// ownerRepos implements every user-owned repository in memory and records
+// the owner each call ran as, which the Neo4j repositories read from the
+// context to scope their queries
+type ownerRepos struct {
+	mu    sync.Mutex
+	ranAs map[string][]string
+}
+
+func (r *ownerRepos) record(ctx context.Context, method string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.ranAs == nil {
+		r.ranAs = make(map[string][]string)
+	}
+	r.ranAs[method] = append(r.ranAs[method], identity.OwnerID(ctx))
+}
+
+// take returns and clears the recorded calls
+func (r *ownerRepos) take() map[string][]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	calls := r.ranAs
+	r.ranAs = nil
+	return calls
+}
+
+func (r *ownerRepos) PersistKeywords(ctx context.Context, _ []tools.KeywordRecord) error {
+	r.record(ctx, "PersistKeywords")
+	return nil
+}
+
+func (r *ownerRepos) GetApplication(ctx context.Context, _, _ string) (domain.Application, bool, error) {
+	r.record(ctx, "GetApplication")
+	return domain.Application{}, false, nil
+}
+
+func (r *ownerRepos) SaveApplication(ctx context.Context, _ domain.Application, _ string) (bool, error) {
+	r.record(ctx, "SaveApplication")
+	return true, nil
+}
+
+func (r *ownerRepos) ListApplications(ctx context.Context, _ repository.ApplicationFilter) ([]domain.Application, error) {
+	r.record(ctx, "ListApplications")
+	return nil, nil
+}
+
+func (r *ownerRepos) CreateEvent(ctx context.Context, e domain.Event) (domain.Event, bool, error) {
+	r.record(ctx, "CreateEvent")
+	return e, true, nil
+}
+
+func (r *ownerRepos) ListEvents(ctx context.Context, _ repository.EventFilter) ([]domain.Event, error) {
+	r.record(ctx, "ListEvents")
+	return nil, nil
+}
+
+func (r *ownerRepos) UpsertContact(ctx context.Context, c domain.Contact, _ []domain.ContactLink) (domain.Contact, error) {
+	r.record(ctx, "UpsertContact")
+	return c, nil
+}
+
+func (r *ownerRepos) SearchContacts(ctx context.Context, _ repository.ContactFilter) ([]domain.Contact, error) {
+	r.record(ctx, "SearchContacts")
+	return nil, nil
+}
+
+func (r *ownerRepos) SaveSearch(ctx context.Context, s domain.SavedSearch) (domain.SavedSearch, error) {
+	r.record(ctx, "SaveSearch")
+	return s, nil
+}
+
+func (r *ownerRepos) ListSearches(ctx context.Context, filter repository.SavedSearchFilter) ([]domain.SavedSearch, error) {
+	r.record(ctx, "ListSearches")
+	var out []domain.SavedSearch
+	for _, id := range filter.IDs {
+		out = append(out, domain.SavedSearch{ID: id, Query: "go", LastRunAt: time.Now()})
+	}
+	return out, nil
+}
+
+func (r *ownerRepos) DeleteSearch(ctx context.Context, _ string) (bool, error) {
+	r.record(ctx, "DeleteSearch")
+	return true, nil
+}
+
+func (r *ownerRepos) ClaimRun(ctx context.Context, _ string, _, _ time.Time) (bool, error) {
+	r.record(ctx, "ClaimRun")
+	return false, nil
+}
+
+func (r *ownerRepos) FinishRun(ctx context.Context, _ domain.SearchRun) (int, error) {
+	r.record(ctx, "FinishRun")
+	return 0, nil
+}
+
+func (r *ownerRepos) ListNewJobs(ctx context.Context, _ string, _ time.Time, _ int) ([]domain.Job, error) {
+	r.record(ctx, "ListNewJobs")
+	return nil, nil
+}
+
+func (r *ownerRepos) ExportJobs(ctx context.Context, _ repository.ExportFilter) ([]repository.ExportedJob, error) {
+	r.record(ctx, "ExportJobs")
+	return nil, nil
+}
+
+// connectAs serves the registered tools over an in-memory transport, with
+// every request running as user the way authMiddleware sets it
+func connectAs(t *testing.T, res Resources, user domain.User) *sdkmcp.ClientSession {
+	t.Helper()
+	server := sdkmcp.NewServer(&sdkmcp.Implementation{Name: "test", Version: "0"}, nil)
+	server.AddReceivingMiddleware(func(next sdkmcp.MethodHandler) sdkmcp.MethodHandler {
+		return func(ctx context.Context, method string, req sdkmcp.Request) (sdkmcp.Result, error) {
+			return next(identity.NewContext(ctx, user), method, req)
+		}
+	})
+	if err := NewToolRegistry(logging.New("error"), 0).RegisterAll(server, res); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	serverTransport, clientTransport := sdkmcp.NewInMemoryTransports()
+	if _, err := server.Connect(context.Background(), serverTransport, nil); err != nil {
+		t.Fatal(err)
+	}
+	client := sdkmcp.NewClient(&sdkmcp.Implementation{Name: "test-client", Version: "0"}, nil)
+	session, err := client.Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+func TestToolsPassCallerToRepositories(t *testing.T) {
+	repos := &ownerRepos{}
+	res := Resources{
+		KeywordRepo:    repos,
+		ApplicationSvc: application.NewService(repos),
+		EventSvc:       event.NewService(repos),
+		ContactSvc:     contact.NewService(repos, nil),
+		SavedSearchSvc: savedsearch.NewService(repos, nil, nil, 0, 0),
+		GraphExporter:  graphio.NewExporter(repos),
+		// graph_tool needs a client to register; the export mode never uses it
+		Neo4jClient: &n4j.Client{},
+	}
+
+	jobID := uuid.NewString()
+	calls := []struct {
+		tool string
+		args map[string]any
+	}{
+		{"persist_keywords", map[string]any{"records": []any{map[string]any{"job_id": jobID, "keywords": []any{map[string]any{"value": "go"}}}}}},
+		{"application_update", map[string]any{"candidate_id": "cand-1", "job_id": jobID, "status": "saved"}},
+		{"application_list", map[string]any{"candidate_id": "cand-1"}},
+		{"event_add", map[string]any{"job_id": jobID, "kind": "interview", "start": "2026-11-02T10:00:00Z"}},
+		{"event_list", map[string]any{"include_past": true}},
+		{"contact_add", map[string]any{"name": "Dana Recruiter", "email": "dana@example.com"}},
+		{"contact_search", map[string]any{"query": "dana"}},
+		{"saved_search_save", map[string]any{"query": "go developer"}},
+		{"saved_search_list", map[string]any{}},
+		{"saved_search_delete", map[string]any{"search_id": "search-1"}},
+		{"new_since_last_run", map[string]any{"search_id": "search-1"}},
+		{"graph_tool", map[string]any{"mode": "export", "export": map[string]any{"format": "jsonl"}}},
+	}
+	want := []string{
+		"CreateEvent", "DeleteSearch", "ExportJobs", "GetApplication", "ListApplications", "ListEvents",
+		"ListNewJobs", "ListSearches", "PersistKeywords", "SaveApplication", "SaveSearch", "SearchContacts", "UpsertContact",
+	}
+
+	for _, user := range []domain.User{{ID: "alice"}, {ID: "bob"}} {
+		session := connectAs(t, res, user)
+		for _, c := range calls {
+			if text, isErr := callTextWith(t, session, c.tool, c.args); isErr {
+				t.Fatalf("%s as %s: %s", c.tool, user.ID, text)
+			}
+		}
+
+		ranAs := repos.take()
+		var methods []string
+		for method, owners := range ranAs {
+			methods = append(methods, method)
+			for _, owner := range owners {
+				if owner != user.ID {
+					t.Errorf("%s ran as %q, want %q", method, owner, user.ID)
+				}
+			}
+		}
+		slices.Sort(methods)
+		if !slices.Equal(methods, want) {
+			t.Fatalf("repository calls = %v, want %v", methods, want)
+		}
+	}
+}
@@ -3,137 +3,121 @@ package tools
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
-
-	"github.com/honeycarbs/project-ets/pkg/logging"
 )
 
 // AnalysisService encapsulates graph/keyword reasoning logic
 type AnalysisService interface {
 	Analyze(ctx context.Context, params JobAnalysisParams) (JobAnalysisResult, error)
+	RelatedJobs(ctx context.Context, params RelatedJobsParams) (RelatedJobsResult, error)
+	SkillInsights(ctx context.Context, params SkillInsightsParams) (SkillInsightsResult, error)
+	KeywordGapReport(ctx context.Context, params KeywordGapParams) (KeywordGapResult, error)
 }
 
 // JobAnalysisParams defines the arguments for the job_analysis tool
 type JobAnalysisParams struct {
-	JobIDs  []string `json:"job_ids,omitempty" jsonschema:"Job identifiers stored in Neo4j"`
-	Profile string   `json:"profile,omitempty" jsonschema:"Free-form resume/profile to compare"`
-	Focus   string   `json:"focus,omitempty" jsonschema:"Optional analysis instruction"`
+	JobIDs        []string `json:"job_ids,omitempty" jsonschema:"Job identifiers stored in Neo4j"`
+	CandidateID   string   `json:"candidate_id,omitempty" jsonschema:"Stored candidate profile to compare (see profile_upsert)"`
+	Profile       string   `json:"profile,omitempty" jsonschema:"Free-form resume/profile to compare when no candidate_id is given"`
+	Focus         string   `json:"focus,omitempty" jsonschema:"Optional analysis instruction"`
+	IncludeClosed bool     `json:"include_closed,omitempty" jsonschema:"Also analyze jobs whose posting is closed"`
+}
+
+// JobMatch is the deterministic profile-to-job keyword coverage
+type JobMatch struct {
+	Score           float64  `json:"score" jsonschema:"Weighted share of job skills/keywords found in the profile (0-1)"`
+	Rank            int      `json:"rank" jsonschema:"1-based position among analyzed jobs by score"`
+	SkillCoverage   float64  `json:"skill_coverage" jsonschema:"Share of required skills found in the profile (0-1)"`
+	KeywordCoverage float64  `json:"keyword_coverage" jsonschema:"Share of stored keywords found in the profile (0-1)"`
+	MatchedKeywords []string `json:"matched_keywords" jsonschema:"Normalized job terms present in the profile"`
+	MissingKeywords []string `json:"missing_keywords" jsonschema:"Normalized job terms absent from the profile, most important first"`
 }
 
 // JobAnalysisSummary captures per-job graph context for LLM analysis
 type JobAnalysisSummary struct {
-	JobID               string         `json:"job_id" jsonschema:"Job identifier"`
-	Summary             string         `json:"summary,omitempty" jsonschema:"Job title and company"`
-	RecommendedKeywords []KeywordEntry `json:"keywords,omitempty" jsonschema:"Extracted keywords from graph"`
-	SupportingData      map[string]any `json:"data,omitempty" jsonschema:"Full job context for LLM analysis"`
+	JobID               string           `json:"job_id" jsonschema:"Job identifier"`
+	Summary             string           `json:"summary,omitempty" jsonschema:"Job title and company"`
+	RecommendedKeywords []KeywordEntry   `json:"keywords,omitempty" jsonschema:"Extracted keywords from graph"`
+	Match               *JobMatch        `json:"match,omitempty" jsonschema:"Profile match, present when a profile is provided"`
+	Ghost               *GhostAssessment `json:"ghost,omitempty" jsonschema:"Ghost-posting likelihood of the stored job"`
+	SupportingData      map[string]any   `json:"data,omitempty" jsonschema:"Full job context for LLM analysis"`
 }
 
 // JobAnalysisResult is the structured response of job_analysis
 type JobAnalysisResult struct {
-	Jobs        []JobAnalysisSummary `json:"jobs" jsonschema:"Per-job analysis results"`
-	GeneratedAt time.Time            `json:"generated_at" jsonschema:"Timestamp when analysis completed"`
-	Notes       string               `json:"notes,omitempty" jsonschema:"Global summary or caveats"`
+	Jobs          []JobAnalysisSummary `json:"jobs" jsonschema:"Per-job analysis results, ranked by match score when a profile is provided"`
+	ProfileSkills []string             `json:"profile_skills,omitempty" jsonschema:"Skills parsed from the profile's skills lines"`
+	GeneratedAt   time.Time            `json:"generated_at" jsonschema:"Timestamp when analysis completed"`
+	Notes         string               `json:"notes,omitempty" jsonschema:"Global summary or caveats"`
 }
 
 type jobAnalysisTool struct {
 	service AnalysisService
-	logger  *logging.Logger
 }
 
 // WithJobAnalysis registers the job_analysis tool
 func WithJobAnalysis(service AnalysisService) Option {
 	return func(reg *registry) {
 		handler := jobAnalysisTool{service: service}
-		sdkmcp.AddTool(reg.server, &sdkmcp.Tool{
+		AddTool(reg.server, &sdkmcp.Tool{
 			Name:        "job_analysis",
 			Description: "Summarize stored job graphs against a candidate profile using Graph RAG",
-		}, handler.handle)
+		}, handler.handle, requires(service, "analysis service"))
 	}
 }
 
-func RegisterAnalysisTools(server *sdkmcp.Server, repo KeywordRepository, svc AnalysisService, logger *logging.Logger) error {
-	handler := jobAnalysisTool{service: svc, logger: logger}
-	sdkmcp.AddTool(server, &sdkmcp.Tool{
+func RegisterAnalysisTools(server *ToolServer, repo KeywordRepository, svc AnalysisService) error {
+	handler := jobAnalysisTool{service: svc}
+	AddTool(server, &sdkmcp.Tool{
 		Name:        "job_analysis",
 		Description: "Summarize stored job graphs against a candidate profile using Graph RAG",
-	}, handler.handle)
-
-	persistHandler := persistKeywordsTool{repo: repo, logger: logger}
-	sdkmcp.AddTool(server, &sdkmcp.Tool{
+	}, handler.handle, requires(svc, "analysis service"))
+
+	relatedHandler := relatedJobsTool{service: svc}
+	AddTool(server, &sdkmcp.Tool{
+		Name:        "related_jobs",
+		Description: "Find stored jobs related to a job through shared skills, keywords, company and title",
+	}, relatedHandler.handle, requires(svc, "analysis service"))
+
+	insightsHandler := skillInsightsTool{service: svc}
+	AddTool(server, &sdkmcp.Tool{
+		Name:        "skill_insights",
+		Description: "Skill co-occurrence and time-windowed skill/keyword market trends from stored jobs",
+	}, insightsHandler.handle, requires(svc, "analysis service"))
+
+	gapHandler := keywordGapTool{service: svc}
+	AddTool(server, &sdkmcp.Tool{
+		Name:        "keyword_gap_report",
+		Description: "Report ATS keywords demanded by a batch of jobs that the candidate profile lacks",
+	}, gapHandler.handle, requires(svc, "analysis service"))
+
+	persistHandler := persistKeywordsTool{repo: repo}
+	AddTool(server, &sdkmcp.Tool{
 		Name:        "persist_keywords",
 		Description: "Store agent-extracted keywords against existing job nodes",
-	}, persistHandler.handle)
+	}, persistHandler.handle, requires(repo, "keyword repository"))
 
-	if logger != nil {
-		logger.Info("analysis tools registered", "tools", []string{"job_analysis", "persist_keywords"})
-	}
 	return nil
 }
 
-func (t jobAnalysisTool) handle(ctx context.Context, req *sdkmcp.CallToolRequest, params *JobAnalysisParams) (*sdkmcp.CallToolResult, any, error) {
-	if t.logger != nil {
-		t.logger.Debug("job_analysis called")
-	}
-
-	if params == nil {
-		params = &JobAnalysisParams{}
-		if t.logger != nil {
-			t.logger.Debug("job_analysis: params is nil, using empty params")
-		}
-	}
-
-	if t.logger != nil {
-		t.logger.Info("job_analysis request",
-			"job_ids_count", len(params.JobIDs),
-			"job_ids", params.JobIDs,
-			"has_profile", params.Profile != "",
-			"focus", params.Focus,
-		)
-	}
-
-	if t.service == nil {
-		err := fmt.Errorf("analysis service not configured")
-		if t.logger != nil {
-			t.logger.Error("job_analysis: service not available", "err", err)
-		}
-		return nil, nil, err
-	}
-
+func (t jobAnalysisTool) handle(ctx context.Context, params *JobAnalysisParams) (*sdkmcp.CallToolResult, any, error) {
 	result, err := t.service.Analyze(ctx, *params)
 	if err != nil {
-		if t.logger != nil {
-			t.logger.Error("job_analysis: analysis failed",
-				"err", err,
-				"job_ids", params.JobIDs,
-			)
-		}
-		return nil, nil, fmt.Errorf("analysis failed: %w", err)
-	}
-
-	if t.logger != nil {
-		t.logger.Info("job_analysis completed successfully",
-			"jobs_analyzed", len(result.Jobs),
-			"generated_at", result.GeneratedAt,
-			"has_notes", result.Notes != "",
-		)
-		for i, job := range result.Jobs {
-			t.logger.Debug("job_analysis result",
-				"index", i,
-				"job_id", job.JobID,
-				"keywords_count", len(job.RecommendedKeywords),
-				"has_summary", job.Summary != "",
-			)
-		}
+		return nil, nil, err
 	}
 
-	msg := t.formatResponse(result)
-	return textResult(msg), result, nil
+	annotate(ctx, "jobs_analyzed", len(result.Jobs), "has_notes", result.Notes != "")
+	return textResult(t.formatResponse(result)), result, nil
 }
 
 func (t jobAnalysisTool) formatResponse(result JobAnalysisResult) string {
 	if len(result.Jobs) == 0 {
+		if result.Notes != "" {
+			return "[job_analysis] No jobs to analyze: " + result.Notes
+		}
 		return "[job_analysis] No jobs found for provided IDs"
 	}
 
@@ -142,6 +126,30 @@ func (t jobAnalysisTool) formatResponse(result JobAnalysisResult) string {
 	for _, job := range result.Jobs {
 		msg += fmt.Sprintf("\n• %s\n", job.Summary)
 
+		if job.Match != nil {
+			msg += fmt.Sprintf("  Match: #%d, score %.2f (skills %.0f%%, keywords %.0f%%)\n",
+				job.Match.Rank, job.Match.Score, job.Match.SkillCoverage*100, job.Match.KeywordCoverage*100)
+			if len(job.Match.MissingKeywords) > 0 {
+				msg += fmt.Sprintf("  Missing: %s\n", strings.Join(job.Match.MissingKeywords, ", "))
+			}
+		}
+
+		if job.Ghost != nil {
+			msg += "  " + formatGhost(job.Ghost) + "\n"
+		}
+
+		if contacts, ok := job.SupportingData["contacts"].([]map[string]any); ok && len(contacts) > 0 {
+			names := make([]string, 0, len(contacts))
+			for _, c := range contacts {
+				name, _ := c["name"].(string)
+				if role, ok := c["role"].(string); ok {
+					name += " (" + role + ")"
+				}
+				names = append(names, name)
+			}
+			msg += fmt.Sprintf("  Contacts: %s\n", strings.Join(names, ", "))
+		}
+
 		if len(job.RecommendedKeywords) > 0 {
 			msg += fmt.Sprintf("  Keywords (%d):\n", len(job.RecommendedKeywords))
 			for _, kw := range job.RecommendedKeywords {
@@ -156,5 +164,9 @@ func (t jobAnalysisTool) formatResponse(result JobAnalysisResult) string {
 		}
 	}
 
+	if result.Notes != "" {
+		msg += "\nNotes: " + result.Notes + "\n"
+	}
+
 	return msg
 }
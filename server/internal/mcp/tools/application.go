@@ -0,0 +1,159 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ApplicationService tracks candidates' applications through the pipeline
+type ApplicationService interface {
+	UpdateApplication(ctx context.Context, params ApplicationUpdateParams) (ApplicationUpdateResult, error)
+	ListApplications(ctx context.Context, params ApplicationListParams) (ApplicationListResult, error)
+}
+
+// ApplicationUpdateParams defines the arguments for the application_update tool
+type ApplicationUpdateParams struct {
+	CandidateID string `json:"candidate_id" jsonschema:"Candidate identifier"`
+	JobID       string `json:"job_id" jsonschema:"Job identifier"`
+	Status      string `json:"status" jsonschema:"New status: saved, applied, screening, interviewing, offer, rejected or withdrawn"`
+	Note        string `json:"note,omitempty" jsonschema:"Note recorded with the transition"`
+	At          string `json:"at,omitempty" jsonschema:"RFC 3339 time of the change when backfilling (default: now)"`
+}
+
+// ApplicationListParams defines the arguments for the application_list tool
+type ApplicationListParams struct {
+	CandidateID    string   `json:"candidate_id" jsonschema:"Candidate identifier"`
+	Statuses       []string `json:"statuses,omitempty" jsonschema:"Only applications in these statuses"`
+	JobIDs         []string `json:"job_ids,omitempty" jsonschema:"Only applications to these jobs"`
+	IncludeHistory bool     `json:"include_history,omitempty" jsonschema:"Include the full transition history"`
+	Limit          int      `json:"limit,omitempty" jsonschema:"Maximum applications to return (default 50)"`
+}
+
+// ApplicationTransition is one recorded status change
+type ApplicationTransition struct {
+	From string    `json:"from,omitempty" jsonschema:"Previous status; empty when the application was created"`
+	To   string    `json:"to" jsonschema:"New status"`
+	At   time.Time `json:"at" jsonschema:"When the change happened"`
+	Note string    `json:"note,omitempty" jsonschema:"Note recorded with the change"`
+}
+
+// Application is a candidate's application to a job
+type Application struct {
+	CandidateID  string                  `json:"candidate_id" jsonschema:"Candidate identifier"`
+	JobID        string                  `json:"job_id" jsonschema:"Job identifier"`
+	Title        string                  `json:"title" jsonschema:"Job title"`
+	Company      string                  `json:"company" jsonschema:"Company name"`
+	URL          string                  `json:"url,omitempty" jsonschema:"Job URL"`
+	Status       string                  `json:"status" jsonschema:"Current status"`
+	AppliedAt    time.Time               `json:"applied_at,omitempty" jsonschema:"When the application reached applied; zero before that"`
+	UpdatedAt    time.Time               `json:"updated_at" jsonschema:"Time of the last status change"`
+	NextStatuses []string                `json:"next_statuses" jsonschema:"Statuses the application may move to"`
+	History      []ApplicationTransition `json:"history,omitempty" jsonschema:"Transition history, oldest first"`
+}
+
+// ApplicationUpdateResult is the structured response of application_update
+type ApplicationUpdateResult struct {
+	Application Application `json:"application" jsonschema:"Application after the update"`
+	Previous    string      `json:"previous,omitempty" jsonschema:"Status before the update; empty for a new application"`
+}
+
+// ApplicationListResult is the structured response of application_list
+type ApplicationListResult struct {
+	CandidateID  string         `json:"candidate_id" jsonschema:"Candidate identifier"`
+	Applications []Application  `json:"applications" jsonschema:"Applications, most recently updated first"`
+	Counts       map[string]int `json:"counts" jsonschema:"Number of returned applications per status"`
+}
+
+type applicationTool struct {
+	service ApplicationService
+}
+
+// WithApplicationTools registers the application_update and application_list tools
+func WithApplicationTools(service ApplicationService) Option {
+	return func(reg *registry) {
+		handler := applicationTool{service: service}
+		AddTool(reg.server, &sdkmcp.Tool{
+			Name:        "application_update",
+			Description: "Create an application to a job or move it to a new pipeline status (saved → applied → screening → interviewing → offer/rejected/withdrawn)",
+		}, handler.handleUpdate, requires(service, "application service"))
+		AddTool(reg.server, &sdkmcp.Tool{
+			Name:        "application_list",
+			Description: "List a candidate's job applications with their pipeline status and transition history",
+		}, handler.handleList, requires(service, "application service"))
+	}
+}
+
+func RegisterApplicationTools(server *ToolServer, service ApplicationService) error {
+	Register(server, WithApplicationTools(service))
+	return nil
+}
+
+func (t applicationTool) handleUpdate(ctx context.Context, params *ApplicationUpdateParams) (*sdkmcp.CallToolResult, any, error) {
+	if strings.TrimSpace(params.CandidateID) == "" || strings.TrimSpace(params.JobID) == "" {
+		return nil, nil, invalidParams("candidate_id and job_id are required")
+	}
+
+	result, err := t.service.UpdateApplication(ctx, *params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	annotate(ctx,
+		"candidate_id", result.Application.CandidateID,
+		"job_id", result.Application.JobID,
+		"status", result.Application.Status,
+	)
+	return textResult(formatApplicationUpdate(result)), result, nil
+}
+
+func (t applicationTool) handleList(ctx context.Context, params *ApplicationListParams) (*sdkmcp.CallToolResult, any, error) {
+	if strings.TrimSpace(params.CandidateID) == "" {
+		return nil, nil, invalidParams("candidate_id is required")
+	}
+
+	result, err := t.service.ListApplications(ctx, *params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	annotate(ctx, "candidate_id", result.CandidateID, "applications", len(result.Applications))
+	return textResult(formatApplicationList(result)), result, nil
+}
+
+func formatApplicationUpdate(r ApplicationUpdateResult) string {
+	a := r.Application
+	from := r.Previous
+	if from == "" {
+		from = "new"
+	}
+	text := fmt.Sprintf("[application_update] %s at %s: %s → %s", a.Title, a.Company, from, a.Status)
+	if len(a.NextStatuses) > 0 {
+		text += fmt.Sprintf(" (next: %s)", strings.Join(a.NextStatuses, ", "))
+	}
+	return text
+}
+
+func formatApplicationList(r ApplicationListResult) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("[application_list] %d application(s) for %s\n", len(r.Applications), r.CandidateID))
+	for _, a := range r.Applications {
+		sb.WriteString(fmt.Sprintf("  • %-12s %s at %s, updated %s %s\n",
+			a.Status, a.Title, a.Company, a.UpdatedAt.Format("2006-01-02"), a.JobID))
+		for _, h := range a.History {
+			from := h.From
+			if from == "" {
+				from = "new"
+			}
+			line := fmt.Sprintf("      %s %s → %s", h.At.Format("2006-01-02"), from, h.To)
+			if h.Note != "" {
+				line += ": " + h.Note
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,162 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/honeycarbs/project-ets/pkg/logging"
+)
+
+// ToolCall is one tool invocation as middleware sees it
+type ToolCall struct {
+	Tool      string
+	RequestID string // set by RequestIDs
+	Request   *sdkmcp.CallToolRequest
+	Params    any // the decoded arguments, a pointer to the tool's params type
+
+	logger *logging.Logger
+	fields []any
+}
+
+// ToolHandler handles a call whose arguments are already decoded
+type ToolHandler func(ctx context.Context, call *ToolCall) (*sdkmcp.CallToolResult, any, error)
+
+// ToolMiddleware wraps a tool handler with a cross-cutting concern
+type ToolMiddleware func(next ToolHandler) ToolHandler
+
+// ToolServer registers tools on an MCP server behind a middleware chain,
+// so every tool gets the same logging, recovery, limits and error handling
+// and handlers only hold the tool's own logic
+type ToolServer struct {
+	server *sdkmcp.Server
+	chain  []ToolMiddleware
+	logger *logging.Logger
+}
+
+// NewToolServer wraps server. The first middleware in chain is outermost
+func NewToolServer(server *sdkmcp.Server, logger *logging.Logger, chain ...ToolMiddleware) *ToolServer {
+	if logger == nil {
+		logger = logging.Nop()
+	}
+	return &ToolServer{server: server, chain: chain, logger: logger}
+}
+
+// MCP returns the wrapped server, for resources and prompts
+func (s *ToolServer) MCP() *sdkmcp.Server {
+	return s.server
+}
+
+// ToolFunc is the business logic of a tool. params is never nil
+type ToolFunc[In any] func(ctx context.Context, params *In) (*sdkmcp.CallToolResult, any, error)
+
+// dependency is a service a tool cannot work without
+type dependency struct {
+	name      string
+	available bool
+}
+
+// requires declares that a tool needs dep, described by name in errors. A
+// nil pointer counts as missing, so concrete clients can be passed as is
+func requires(dep any, name string) dependency {
+	available := dep != nil
+	if v := reflect.ValueOf(dep); available && v.Kind() == reflect.Pointer {
+		available = !v.IsNil()
+	}
+	return dependency{name: name, available: available}
+}
+
+// AddTool registers fn behind the server's middleware chain. A tool missing
+// a required dependency is still listed, but every call reports what is not
+// configured. Errors returned by the chain become tool errors: invalid
+// arguments are shown as is, other failures prefixed with "<tool> failed"
+func AddTool[In any](s *ToolServer, tool *sdkmcp.Tool, fn ToolFunc[In], deps ...dependency) {
+	name := tool.Name
+	handler := func(ctx context.Context, call *ToolCall) (*sdkmcp.CallToolResult, any, error) {
+		for _, dep := range deps {
+			if !dep.available {
+				return nil, nil, fmt.Errorf("%s not configured", dep.name)
+			}
+		}
+		return fn(ctx, call.Params.(*In))
+	}
+	for i := len(s.chain) - 1; i >= 0; i-- {
+		handler = s.chain[i](handler)
+	}
+
+	sdkmcp.AddTool(s.server, tool, func(ctx context.Context, req *sdkmcp.CallToolRequest, params *In) (*sdkmcp.CallToolResult, any, error) {
+		if params == nil {
+			params = new(In)
+		}
+		call := &ToolCall{Tool: name, Request: req, Params: params, logger: s.logger.With("tool", name)}
+		res, out, err := handler(withCall(ctx, call), call)
+		if err != nil {
+			return errorResult(toolErrorText(name, err)), nil, nil
+		}
+		return res, out, nil
+	})
+	s.logger.Debug("tool registered", "tool", name)
+}
+
+func toolErrorText(tool string, err error) string {
+	if IsInvalidParams(err) {
+		return fmt.Sprintf("[%s] %v", tool, err)
+	}
+	return fmt.Sprintf("[%s] failed: %v", tool, err)
+}
+
+// paramError reports arguments a tool cannot work with
+type paramError struct{ msg string }
+
+func (e *paramError) Error() string { return e.msg }
+
+// invalidParams returns an error for unusable arguments. It is logged at
+// debug level, since the client is at fault
+func invalidParams(format string, args ...any) error {
+	return &paramError{msg: fmt.Sprintf(format, args...)}
+}
+
+// IsInvalidParams reports whether err is about the call's arguments
+func IsInvalidParams(err error) bool {
+	var invalid *paramError
+	return errors.As(err, &invalid)
+}
+
+type callKey struct{}
+
+func withCall(ctx context.Context, call *ToolCall) context.Context {
+	return context.WithValue(ctx, callKey{}, call)
+}
+
+func callFrom(ctx context.Context) (*ToolCall, bool) {
+	call, ok := ctx.Value(callKey{}).(*ToolCall)
+	return call, ok
+}
+
+// RequestID returns the ID of the tool call handled on ctx, if any
+func RequestID(ctx context.Context) string {
+	if call, ok := callFrom(ctx); ok {
+		return call.RequestID
+	}
+	return ""
+}
+
+// annotate adds key/value pairs to the log line written when the call
+// completes
+func annotate(ctx context.Context, keyvals ...any) {
+	if call, ok := callFrom(ctx); ok {
+		call.fields = append(call.fields, keyvals...)
+	}
+}
+
+// callLogger returns a logger tagged with the current tool and request, for
+// problems a tool works around instead of failing
+func callLogger(ctx context.Context) *logging.Logger {
+	if call, ok := callFrom(ctx); ok {
+		return call.logger
+	}
+	return logging.Nop()
+}
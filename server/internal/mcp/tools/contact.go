@@ -0,0 +1,155 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ContactService records recruiters, referrers and interviewers
+type ContactService interface {
+	AddContact(ctx context.Context, params ContactAddParams) (ContactAddResult, error)
+	SearchContacts(ctx context.Context, params ContactSearchParams) (ContactSearchResult, error)
+}
+
+// ContactAddParams defines the arguments for the contact_add tool
+type ContactAddParams struct {
+	ContactID         string   `json:"contact_id,omitempty" jsonschema:"Contact to update; otherwise matched by email or created"`
+	Name              string   `json:"name,omitempty" jsonschema:"Full name (required for new contacts)"`
+	Role              string   `json:"role,omitempty" jsonschema:"Role, e.g. recruiter or engineering manager"`
+	Email             string   `json:"email,omitempty" jsonschema:"Email address"`
+	LinkedInURL       string   `json:"linkedin_url,omitempty" jsonschema:"LinkedIn profile URL"`
+	Company           string   `json:"company,omitempty" jsonschema:"Company the contact works at"`
+	Notes             string   `json:"notes,omitempty" jsonschema:"Free-form notes"`
+	CandidateID       string   `json:"candidate_id,omitempty" jsonschema:"Candidate whose applications the links below belong to"`
+	ReferredJobIDs    []string `json:"referred_job_ids,omitempty" jsonschema:"Jobs the contact referred the candidate to"`
+	InterviewedJobIDs []string `json:"interviewed_job_ids,omitempty" jsonschema:"Jobs the contact interviewed the candidate for"`
+}
+
+// ContactSearchParams defines the arguments for the contact_search tool
+type ContactSearchParams struct {
+	Query       string `json:"query,omitempty" jsonschema:"Text matched against name, role, email and notes"`
+	Company     string `json:"company,omitempty" jsonschema:"Only contacts at this company"`
+	JobID       string `json:"job_id,omitempty" jsonschema:"Only contacts linked to this job"`
+	CandidateID string `json:"candidate_id,omitempty" jsonschema:"Only contacts linked to this candidate's applications"`
+	Limit       int    `json:"limit,omitempty" jsonschema:"Maximum contacts to return (default 25)"`
+}
+
+// ContactLink ties a contact to a job
+type ContactLink struct {
+	Kind        string    `json:"kind" jsonschema:"referred or interviewed"`
+	JobID       string    `json:"job_id" jsonschema:"Job identifier"`
+	JobTitle    string    `json:"job_title,omitempty" jsonschema:"Job title"`
+	CandidateID string    `json:"candidate_id,omitempty" jsonschema:"Candidate whose application the link belongs to"`
+	At          time.Time `json:"at" jsonschema:"When the link was recorded"`
+}
+
+// Contact is a recruiter, referrer or interviewer
+type Contact struct {
+	ID          string        `json:"id" jsonschema:"Contact identifier"`
+	Name        string        `json:"name" jsonschema:"Full name"`
+	Role        string        `json:"role,omitempty" jsonschema:"Role at the company"`
+	Email       string        `json:"email,omitempty" jsonschema:"Email address"`
+	LinkedInURL string        `json:"linkedin_url,omitempty" jsonschema:"LinkedIn profile URL"`
+	Company     string        `json:"company,omitempty" jsonschema:"Company name"`
+	CompanyID   string        `json:"company_id,omitempty" jsonschema:"Company identifier"`
+	Notes       string        `json:"notes,omitempty" jsonschema:"Free-form notes"`
+	Links       []ContactLink `json:"links,omitempty" jsonschema:"Jobs the contact referred or interviewed for"`
+}
+
+// ContactAddResult is the structured response of contact_add
+type ContactAddResult struct {
+	Contact     Contact  `json:"contact" jsonschema:"Stored contact"`
+	Created     bool     `json:"created" jsonschema:"Whether a new contact was created"`
+	MissingJobs []string `json:"missing_jobs,omitempty" jsonschema:"Job IDs that were not linked because they do not exist"`
+}
+
+// ContactSearchResult is the structured response of contact_search
+type ContactSearchResult struct {
+	Contacts []Contact `json:"contacts" jsonschema:"Matching contacts, by name"`
+}
+
+type contactTool struct {
+	service ContactService
+}
+
+// WithContactTools registers the contact_add and contact_search tools
+func WithContactTools(service ContactService) Option {
+	return func(reg *registry) {
+		handler := contactTool{service: service}
+		AddTool(reg.server, &sdkmcp.Tool{
+			Name:        "contact_add",
+			Description: "Add or update a recruiter, referrer or interviewer and link them to a company and jobs",
+		}, handler.handleAdd, requires(service, "contact service"))
+		AddTool(reg.server, &sdkmcp.Tool{
+			Name:        "contact_search",
+			Description: "Search contacts by text, company, job or candidate",
+		}, handler.handleSearch, requires(service, "contact service"))
+	}
+}
+
+func RegisterContactTools(server *ToolServer, service ContactService) error {
+	Register(server, WithContactTools(service))
+	return nil
+}
+
+func (t contactTool) handleAdd(ctx context.Context, params *ContactAddParams) (*sdkmcp.CallToolResult, any, error) {
+	if strings.TrimSpace(params.ContactID) == "" && strings.TrimSpace(params.Name) == "" {
+		return nil, nil, invalidParams("a name or contact_id is required")
+	}
+
+	result, err := t.service.AddContact(ctx, *params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	annotate(ctx, "contact_id", result.Contact.ID, "created", result.Created)
+	verb := "updated"
+	if result.Created {
+		verb = "added"
+	}
+	text := fmt.Sprintf("[contact_add] %s %s", verb, formatContact(result.Contact))
+	if len(result.MissingJobs) > 0 {
+		text += fmt.Sprintf("\n  not linked, unknown jobs: %s", strings.Join(result.MissingJobs, ", "))
+	}
+	return textResult(text), result, nil
+}
+
+func (t contactTool) handleSearch(ctx context.Context, params *ContactSearchParams) (*sdkmcp.CallToolResult, any, error) {
+	result, err := t.service.SearchContacts(ctx, *params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	annotate(ctx, "contacts", len(result.Contacts))
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("[contact_search] %d contact(s)\n", len(result.Contacts)))
+	for _, c := range result.Contacts {
+		sb.WriteString("  • " + formatContact(c) + "\n")
+	}
+	return textResult(sb.String()), result, nil
+}
+
+func formatContact(c Contact) string {
+	text := c.Name
+	if c.Role != "" {
+		text += ", " + c.Role
+	}
+	if c.Company != "" {
+		text += " at " + c.Company
+	}
+	if c.Email != "" {
+		text += " <" + c.Email + ">"
+	}
+	for _, link := range c.Links {
+		title := link.JobTitle
+		if title == "" {
+			title = link.JobID
+		}
+		text += fmt.Sprintf("; %s %s", link.Kind, title)
+	}
+	return text + " " + c.ID
+}
@@ -0,0 +1,179 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// EmployerService aggregates and maintains company data
+type EmployerService interface {
+	EmployerProfile(ctx context.Context, params EmployerProfileParams) (EmployerProfile, error)
+	MergeCompanies(ctx context.Context, params CompanyMergeParams) (CompanyMergeResult, error)
+}
+
+// EmployerProfileParams defines the arguments for the employer_profile tool
+type EmployerProfileParams struct {
+	Company     string `json:"company" jsonschema:"Company name or ID; legal suffixes, case and punctuation are ignored"`
+	RecentLimit int    `json:"recent_limit,omitempty" jsonschema:"Number of recent postings to list (default 10)"`
+}
+
+// TermCount is a value with the number of postings it appears on
+type TermCount struct {
+	Term  string `json:"term" jsonschema:"Location or skill"`
+	Count int    `json:"count" jsonschema:"Number of postings"`
+}
+
+// HiringVelocity compares posting volume across recent 30-day windows
+type HiringVelocity struct {
+	Last30Days     int     `json:"last_30_days" jsonschema:"Postings in the last 30 days"`
+	Previous30Days int     `json:"previous_30_days" jsonschema:"Postings in the 30 days before that"`
+	PerWeek        float64 `json:"per_week" jsonschema:"Average postings per week over the last 90 days"`
+	Trend          string  `json:"trend" jsonschema:"growing, steady, slowing or inactive"`
+}
+
+// SalaryRange summarizes advertised salaries
+type SalaryRange struct {
+	Min    float64 `json:"min" jsonschema:"Lowest advertised salary"`
+	Median float64 `json:"median" jsonschema:"Median advertised salary"`
+	Max    float64 `json:"max" jsonschema:"Highest advertised salary"`
+	Count  int     `json:"count" jsonschema:"Postings that advertise a salary"`
+}
+
+// EmployerPosting is a posting listed in an employer profile
+type EmployerPosting struct {
+	JobID    string    `json:"job_id" jsonschema:"Job ID"`
+	Title    string    `json:"title" jsonschema:"Job title"`
+	Location string    `json:"location,omitempty" jsonschema:"Job location"`
+	Salary   float64   `json:"salary,omitempty" jsonschema:"Advertised salary"`
+	PostedAt time.Time `json:"posted_at" jsonschema:"Posting date"`
+}
+
+// EmployerProfile is the structured response of employer_profile
+type EmployerProfile struct {
+	CompanyID      string            `json:"company_id" jsonschema:"Canonical company ID"`
+	Name           string            `json:"name" jsonschema:"Display name"`
+	Names          []string          `json:"names,omitempty" jsonschema:"Name spellings seen on postings"`
+	Aliases        []string          `json:"aliases,omitempty" jsonschema:"Company IDs merged into this company"`
+	Postings       int               `json:"postings" jsonschema:"Stored postings"`
+	RemotePostings int               `json:"remote_postings" jsonschema:"Postings marked remote"`
+	FirstPosted    *time.Time        `json:"first_posted,omitempty" jsonschema:"Oldest posting date"`
+	LastPosted     *time.Time        `json:"last_posted,omitempty" jsonschema:"Newest posting date"`
+	Velocity       HiringVelocity    `json:"velocity" jsonschema:"Hiring velocity"`
+	Locations      []TermCount       `json:"locations" jsonschema:"Locations by number of postings"`
+	Salary         *SalaryRange      `json:"salary,omitempty" jsonschema:"Advertised salary range; omitted when no posting lists one"`
+	TopSkills      []TermCount       `json:"top_skills" jsonschema:"Most requested skills"`
+	RecentPostings []EmployerPosting `json:"recent_postings" jsonschema:"Newest postings"`
+}
+
+// CompanyMergeParams defines the arguments for the company_merge tool
+type CompanyMergeParams struct {
+	Target  string   `json:"target" jsonschema:"Company name or ID to keep"`
+	Sources []string `json:"sources" jsonschema:"Company names or IDs to fold into target; unknown names are recorded as aliases"`
+}
+
+// CompanyMergeResult is the structured response of company_merge
+type CompanyMergeResult struct {
+	CompanyID string   `json:"company_id" jsonschema:"Canonical company ID"`
+	Name      string   `json:"name" jsonschema:"Display name"`
+	Names     []string `json:"names,omitempty" jsonschema:"Name spellings seen on postings"`
+	Aliases   []string `json:"aliases" jsonschema:"Company IDs that now resolve to this company"`
+	Postings  int      `json:"postings" jsonschema:"Postings linked to the company after the merge"`
+}
+
+type employerProfileTool struct {
+	service EmployerService
+}
+
+type companyMergeTool struct {
+	service EmployerService
+}
+
+// WithEmployerTools registers employer_profile and company_merge
+func WithEmployerTools(service EmployerService) Option {
+	return func(reg *registry) {
+		profileHandler := employerProfileTool{service: service}
+		AddTool(reg.server, &sdkmcp.Tool{
+			Name:        "employer_profile",
+			Description: "Aggregate a company's postings: hiring velocity, locations, salary range and most requested skills",
+		}, profileHandler.handle, requires(service, "employer service"))
+
+		mergeHandler := companyMergeTool{service: service}
+		AddTool(reg.server, &sdkmcp.Tool{
+			Name:        "company_merge",
+			Description: "Merge duplicate Company nodes into one and record their IDs as aliases",
+		}, mergeHandler.handle, requires(service, "employer service"))
+	}
+}
+
+func RegisterEmployerTools(server *ToolServer, service EmployerService) error {
+	Register(server, WithEmployerTools(service))
+	return nil
+}
+
+func (t employerProfileTool) handle(ctx context.Context, params *EmployerProfileParams) (*sdkmcp.CallToolResult, any, error) {
+	if strings.TrimSpace(params.Company) == "" {
+		return nil, nil, invalidParams("company is required")
+	}
+
+	profile, err := t.service.EmployerProfile(ctx, *params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	annotate(ctx, "company_id", profile.CompanyID, "postings", profile.Postings)
+	return textResult(formatEmployerProfile(profile)), profile, nil
+}
+
+func (t companyMergeTool) handle(ctx context.Context, params *CompanyMergeParams) (*sdkmcp.CallToolResult, any, error) {
+	if strings.TrimSpace(params.Target) == "" || len(params.Sources) == 0 {
+		return nil, nil, invalidParams("a target and at least one source are required")
+	}
+
+	result, err := t.service.MergeCompanies(ctx, *params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	annotate(ctx, "company_id", result.CompanyID, "aliases", len(result.Aliases), "postings", result.Postings)
+	msg := fmt.Sprintf("[company_merge] %s (%s) now has %d postings; aliases: %s",
+		result.Name, result.CompanyID, result.Postings, strings.Join(result.Aliases, ", "))
+	return textResult(msg), result, nil
+}
+
+func formatEmployerProfile(p EmployerProfile) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("[employer_profile] %s (%s): %d postings, %d remote\n", p.Name, p.CompanyID, p.Postings, p.RemotePostings))
+	if p.FirstPosted != nil && p.LastPosted != nil {
+		sb.WriteString(fmt.Sprintf("Posting since %s, latest %s\n", p.FirstPosted.Format("2006-01-02"), p.LastPosted.Format("2006-01-02")))
+	}
+	sb.WriteString(fmt.Sprintf("Hiring velocity: %d in last 30 days (prev %d), %.1f/week, %s\n",
+		p.Velocity.Last30Days, p.Velocity.Previous30Days, p.Velocity.PerWeek, p.Velocity.Trend))
+	if p.Salary != nil {
+		sb.WriteString(fmt.Sprintf("Salary: %.0f – %.0f (median %.0f, %d postings)\n", p.Salary.Min, p.Salary.Max, p.Salary.Median, p.Salary.Count))
+	}
+	if len(p.Locations) > 0 {
+		sb.WriteString("Locations: " + formatTermCounts(p.Locations) + "\n")
+	}
+	if len(p.TopSkills) > 0 {
+		sb.WriteString("Top skills: " + formatTermCounts(p.TopSkills) + "\n")
+	}
+	if len(p.RecentPostings) > 0 {
+		sb.WriteString("Recent postings:\n")
+		for _, posting := range p.RecentPostings {
+			sb.WriteString(fmt.Sprintf("  • %s – %s (%s) %s\n", posting.PostedAt.Format("2006-01-02"), posting.Title, posting.Location, posting.JobID))
+		}
+	}
+	return sb.String()
+}
+
+func formatTermCounts(terms []TermCount) string {
+	parts := make([]string, 0, len(terms))
+	for _, t := range terms {
+		parts = append(parts, fmt.Sprintf("%s (%d)", t.Term, t.Count))
+	}
+	return strings.Join(parts, ", ")
+}
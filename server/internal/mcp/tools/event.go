@@ -0,0 +1,137 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// EventService schedules interviews, follow-ups and deadlines and renders
+// them as an iCalendar feed
+type EventService interface {
+	AddEvent(ctx context.Context, params EventAddParams) (Event, error)
+	ListEvents(ctx context.Context, params EventListParams) (EventListResult, error)
+	// CalendarFeed renders the candidate's events, or all events when
+	// candidateID is empty, as an RFC 5545 calendar
+	CalendarFeed(ctx context.Context, candidateID string) ([]byte, error)
+}
+
+// EventAddParams defines the arguments for the event_add tool
+type EventAddParams struct {
+	JobID           string   `json:"job_id" jsonschema:"Job the event belongs to"`
+	CandidateID     string   `json:"candidate_id,omitempty" jsonschema:"Candidate whose application the event belongs to"`
+	Kind            string   `json:"kind" jsonschema:"Event kind: interview, follow_up or deadline"`
+	Title           string   `json:"title,omitempty" jsonschema:"Event title (default derived from kind and job)"`
+	Start           string   `json:"start" jsonschema:"RFC 3339 start time, or YYYY-MM-DD for an all-day event"`
+	End             string   `json:"end,omitempty" jsonschema:"RFC 3339 end time, or YYYY-MM-DD last day for all-day events"`
+	DurationMinutes int      `json:"duration_minutes,omitempty" jsonschema:"Length of a timed event when end is omitted (default 60)"`
+	Location        string   `json:"location,omitempty" jsonschema:"Address or meeting link"`
+	Notes           string   `json:"notes,omitempty" jsonschema:"Free-form notes"`
+	Round           int      `json:"round,omitempty" jsonschema:"Interview round number"`
+	Interviewers    []string `json:"interviewers,omitempty" jsonschema:"Interviewer names"`
+}
+
+// EventListParams defines the arguments for the event_list tool
+type EventListParams struct {
+	CandidateID string   `json:"candidate_id,omitempty" jsonschema:"Only events of this candidate"`
+	JobID       string   `json:"job_id,omitempty" jsonschema:"Only events of this job"`
+	Kinds       []string `json:"kinds,omitempty" jsonschema:"Only these kinds: interview, follow_up, deadline"`
+	From        string   `json:"from,omitempty" jsonschema:"RFC 3339 or YYYY-MM-DD; events ending after this (default: now)"`
+	To          string   `json:"to,omitempty" jsonschema:"RFC 3339 or YYYY-MM-DD; events starting before this"`
+	IncludePast bool     `json:"include_past,omitempty" jsonschema:"Include past events when from is omitted"`
+	Limit       int      `json:"limit,omitempty" jsonschema:"Maximum events to return (default 50)"`
+}
+
+// Event is a scheduled interview, follow-up or deadline
+type Event struct {
+	ID           string    `json:"id" jsonschema:"Event identifier"`
+	Kind         string    `json:"kind" jsonschema:"interview, follow_up or deadline"`
+	Title        string    `json:"title" jsonschema:"Event title"`
+	Start        time.Time `json:"start" jsonschema:"Start time; midnight UTC of the first day for all-day events"`
+	End          time.Time `json:"end,omitempty" jsonschema:"Exclusive end time"`
+	AllDay       bool      `json:"all_day,omitempty" jsonschema:"Whether the event spans whole days"`
+	Location     string    `json:"location,omitempty" jsonschema:"Address or meeting link"`
+	Notes        string    `json:"notes,omitempty" jsonschema:"Free-form notes"`
+	Round        int       `json:"round,omitempty" jsonschema:"Interview round number"`
+	Interviewers []string  `json:"interviewers,omitempty" jsonschema:"Interviewer names"`
+	CandidateID  string    `json:"candidate_id,omitempty" jsonschema:"Candidate the event belongs to"`
+	JobID        string    `json:"job_id" jsonschema:"Job identifier"`
+	JobTitle     string    `json:"job_title" jsonschema:"Job title"`
+	Company      string    `json:"company" jsonschema:"Company name"`
+}
+
+// EventListResult is the structured response of event_list
+type EventListResult struct {
+	Events []Event `json:"events" jsonschema:"Events, earliest first"`
+}
+
+type eventTool struct {
+	service EventService
+}
+
+// WithEventTools registers the event_add and event_list tools
+func WithEventTools(service EventService) Option {
+	return func(reg *registry) {
+		handler := eventTool{service: service}
+		AddTool(reg.server, &sdkmcp.Tool{
+			Name:        "event_add",
+			Description: "Schedule an interview, follow-up or deadline for a job and optionally a candidate's application",
+		}, handler.handleAdd, requires(service, "event service"))
+		AddTool(reg.server, &sdkmcp.Tool{
+			Name:        "event_list",
+			Description: "List scheduled interviews, follow-ups and deadlines, upcoming first",
+		}, handler.handleList, requires(service, "event service"))
+	}
+}
+
+func RegisterEventTools(server *ToolServer, service EventService) error {
+	Register(server, WithEventTools(service))
+	return nil
+}
+
+func (t eventTool) handleAdd(ctx context.Context, params *EventAddParams) (*sdkmcp.CallToolResult, any, error) {
+	if strings.TrimSpace(params.JobID) == "" || strings.TrimSpace(params.Start) == "" {
+		return nil, nil, invalidParams("job_id and start are required")
+	}
+
+	event, err := t.service.AddEvent(ctx, *params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	annotate(ctx, "event_id", event.ID, "kind", event.Kind)
+	return textResult("[event_add] scheduled " + formatEvent(event)), event, nil
+}
+
+func (t eventTool) handleList(ctx context.Context, params *EventListParams) (*sdkmcp.CallToolResult, any, error) {
+	result, err := t.service.ListEvents(ctx, *params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	annotate(ctx, "events", len(result.Events))
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("[event_list] %d event(s)\n", len(result.Events)))
+	for _, event := range result.Events {
+		sb.WriteString("  • " + formatEvent(event) + "\n")
+	}
+	return textResult(sb.String()), result, nil
+}
+
+func formatEvent(e Event) string {
+	when := e.Start.Format("2006-01-02 15:04 MST")
+	if e.AllDay {
+		when = e.Start.Format("2006-01-02")
+	}
+	text := fmt.Sprintf("%s %s: %s (%s at %s)", when, e.Kind, e.Title, e.JobTitle, e.Company)
+	if e.Round > 0 {
+		text += fmt.Sprintf(", round %d", e.Round)
+	}
+	if len(e.Interviewers) > 0 {
+		text += ", with " + strings.Join(e.Interviewers, ", ")
+	}
+	return text + " " + e.ID
+}
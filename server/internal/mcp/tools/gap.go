@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// KeywordGapParams defines the arguments for the keyword_gap_report tool
+type KeywordGapParams struct {
+	JobIDs      []string `json:"job_ids" jsonschema:"Jobs whose stored keywords are aggregated"`
+	CandidateID string   `json:"candidate_id,omitempty" jsonschema:"Stored candidate profile to compare (see profile_upsert)"`
+	Profile     string   `json:"profile,omitempty" jsonschema:"Free-form resume text when no candidate_id is given"`
+	Limit       int      `json:"limit,omitempty" jsonschema:"Maximum missing terms to return (default 25)"`
+}
+
+// KeywordGapTerm is an aggregated ATS keyword across the selected jobs
+type KeywordGapTerm struct {
+	Term          string   `json:"term" jsonschema:"Normalized keyword"`
+	Priority      int      `json:"priority" jsonschema:"1-based priority, highest weight first"`
+	Weight        float64  `json:"weight" jsonschema:"Sum of per-job keyword confidence"`
+	Frequency     int      `json:"frequency" jsonschema:"Number of selected jobs demanding the keyword"`
+	AvgConfidence float64  `json:"avg_confidence" jsonschema:"Mean confidence across those jobs"`
+	JobIDs        []string `json:"job_ids" jsonschema:"Jobs that demand the keyword"`
+}
+
+// KeywordGapResult is the structured response of keyword_gap_report
+type KeywordGapResult struct {
+	JobCount    int              `json:"job_count" jsonschema:"Number of jobs found for the provided IDs"`
+	Coverage    float64          `json:"coverage" jsonschema:"Weighted share of keywords already present in the profile (0-1)"`
+	Missing     []KeywordGapTerm `json:"missing" jsonschema:"Keywords absent from the profile, most important first"`
+	Covered     []KeywordGapTerm `json:"covered" jsonschema:"Keywords already present in the profile"`
+	Suggestions []string         `json:"suggestions,omitempty" jsonschema:"Resume tailoring suggestions for the top gaps"`
+	GeneratedAt time.Time        `json:"generated_at" jsonschema:"Timestamp when the report was computed"`
+}
+
+// ErrNoProfile is returned when neither a candidate nor a profile text
+// contributes terms, which would report every keyword as missing
+var ErrNoProfile = invalidParams("candidate_id or profile is required")
+
+type keywordGapTool struct {
+	service AnalysisService
+}
+
+func WithKeywordGapReport(service AnalysisService) Option {
+	return func(reg *registry) {
+		handler := keywordGapTool{service: service}
+		AddTool(reg.server, &sdkmcp.Tool{
+			Name:        "keyword_gap_report",
+			Description: "Report ATS keywords demanded by a batch of jobs that the candidate profile lacks",
+		}, handler.handle, requires(service, "analysis service"))
+	}
+}
+
+func (t keywordGapTool) handle(ctx context.Context, params *KeywordGapParams) (*sdkmcp.CallToolResult, any, error) {
+	if len(params.JobIDs) == 0 {
+		return nil, nil, invalidParams("job_ids are required")
+	}
+	if params.CandidateID == "" && strings.TrimSpace(params.Profile) == "" {
+		return nil, nil, ErrNoProfile
+	}
+
+	result, err := t.service.KeywordGapReport(ctx, *params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	annotate(ctx,
+		"job_count", result.JobCount,
+		"missing", len(result.Missing),
+		"coverage", result.Coverage,
+	)
+	return textResult(formatKeywordGap(result)), result, nil
+}
+
+func formatKeywordGap(result KeywordGapResult) string {
+	if result.JobCount == 0 {
+		return "[keyword_gap_report] No jobs found for provided IDs"
+	}
+
+	msg := fmt.Sprintf("[keyword_gap_report] %d job(s), keyword coverage %.0f%%\n", result.JobCount, result.Coverage*100)
+	if len(result.Missing) == 0 {
+		return msg + "  No missing keywords\n"
+	}
+
+	msg += "Missing keywords:\n"
+	for _, term := range result.Missing {
+		msg += fmt.Sprintf("  %d. %s (weight %.2f, %d job(s): %s)\n",
+			term.Priority, term.Term, term.Weight, term.Frequency, strings.Join(term.JobIDs, ", "))
+	}
+	if len(result.Suggestions) > 0 {
+		msg += "Suggestions:\n"
+		for _, s := range result.Suggestions {
+			msg += fmt.Sprintf("  - %s\n", s)
+		}
+	}
+	return msg
+}
@@ -0,0 +1,128 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Ghost likelihood levels
+const (
+	GhostLevelLow    = "low"
+	GhostLevelMedium = "medium"
+	GhostLevelHigh   = "high"
+)
+
+// GhostService detects re-listed postings and scores ghost-posting likelihood
+type GhostService interface {
+	// DetectReposts links near-identical postings of the same company and
+	// returns how many repost pairs were found
+	DetectReposts(ctx context.Context, jobIDs []string) (int, error)
+	// Assess scores stored jobs, keyed by job ID
+	Assess(ctx context.Context, jobIDs []string) (map[string]GhostAssessment, error)
+	GhostReport(ctx context.Context, params GhostReportParams) (GhostReportResult, error)
+}
+
+// GhostAssessment is the ghost-posting likelihood of a job and the signals behind it
+type GhostAssessment struct {
+	Score       float64    `json:"score" jsonschema:"Ghost-posting likelihood (0-1)"`
+	Level       string     `json:"level" jsonschema:"low, medium or high"`
+	Reasons     []string   `json:"reasons,omitempty" jsonschema:"Signals that raised the score"`
+	FirstSeenAt *time.Time `json:"first_seen_at,omitempty" jsonschema:"First time a provider returned the posting"`
+	LastSeenAt  *time.Time `json:"last_seen_at,omitempty" jsonschema:"Last time a provider returned the posting"`
+	SeenCount   int        `json:"seen_count" jsonschema:"Number of refreshes that returned the posting"`
+	AgeDays     int        `json:"age_days" jsonschema:"Days the posting has been listed"`
+	Reposts     []string   `json:"reposts,omitempty" jsonschema:"IDs of near-identical postings by the same company"`
+}
+
+// GhostReportParams defines the arguments for the ghost_report tool
+type GhostReportParams struct {
+	JobIDs   []string `json:"job_ids,omitempty" jsonschema:"Jobs to assess; all stored jobs when empty"`
+	Company  string   `json:"company,omitempty" jsonschema:"Restrict to a company name or ID"`
+	MinScore float64  `json:"min_score,omitempty" jsonschema:"Only report jobs scoring at least this much (0-1)"`
+	Limit    int      `json:"limit,omitempty" jsonschema:"Maximum jobs to list (default 25)"`
+	Rescan   bool     `json:"rescan,omitempty" jsonschema:"Re-run repost detection on the selected jobs first"`
+}
+
+// GhostReportJob is a job listed in a ghost report
+type GhostReportJob struct {
+	JobID   string          `json:"job_id" jsonschema:"Job identifier"`
+	Title   string          `json:"title" jsonschema:"Job title"`
+	Company string          `json:"company" jsonschema:"Company name"`
+	URL     string          `json:"url,omitempty" jsonschema:"Posting URL"`
+	Ghost   GhostAssessment `json:"ghost" jsonschema:"Ghost-posting assessment"`
+}
+
+// GhostReportResult is the structured response of ghost_report
+type GhostReportResult struct {
+	Jobs          []GhostReportJob `json:"jobs" jsonschema:"Jobs ordered by ghost score, highest first"`
+	Assessed      int              `json:"assessed" jsonschema:"Jobs assessed before filtering"`
+	LevelCounts   map[string]int   `json:"level_counts" jsonschema:"Assessed jobs per level"`
+	RepostsLinked int              `json:"reposts_linked,omitempty" jsonschema:"Repost pairs found by rescan"`
+	GeneratedAt   time.Time        `json:"generated_at" jsonschema:"Timestamp the report was generated"`
+}
+
+type ghostReportTool struct {
+	service GhostService
+}
+
+// WithGhostReport registers the ghost_report tool
+func WithGhostReport(service GhostService) Option {
+	return func(reg *registry) {
+		handler := ghostReportTool{service: service}
+		AddTool(reg.server, &sdkmcp.Tool{
+			Name:        "ghost_report",
+			Description: "Rank stored jobs by ghost-posting likelihood from listing age, reposts, missing salary and vague descriptions",
+		}, handler.handle, requires(service, "ghost service"))
+	}
+}
+
+func RegisterGhostTools(server *ToolServer, service GhostService) error {
+	Register(server, WithGhostReport(service))
+	return nil
+}
+
+func (t ghostReportTool) handle(ctx context.Context, params *GhostReportParams) (*sdkmcp.CallToolResult, any, error) {
+	if params.MinScore < 0 || params.MinScore > 1 {
+		return nil, nil, invalidParams("min_score must be between 0 and 1")
+	}
+
+	result, err := t.service.GhostReport(ctx, *params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	annotate(ctx, "assessed", result.Assessed, "listed", len(result.Jobs), "reposts_linked", result.RepostsLinked)
+	return textResult(formatGhostReport(result)), result, nil
+}
+
+func formatGhostReport(r GhostReportResult) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("[ghost_report] assessed %d job(s): %d high, %d medium, %d low\n",
+		r.Assessed, r.LevelCounts[GhostLevelHigh], r.LevelCounts[GhostLevelMedium], r.LevelCounts[GhostLevelLow]))
+	if r.RepostsLinked > 0 {
+		sb.WriteString(fmt.Sprintf("Rescan found %d repost pair(s)\n", r.RepostsLinked))
+	}
+	for _, job := range r.Jobs {
+		sb.WriteString(fmt.Sprintf("  • %.2f %s | %s at %s %s\n", job.Ghost.Score, job.Ghost.Level, job.Title, job.Company, job.JobID))
+		if len(job.Ghost.Reasons) > 0 {
+			sb.WriteString("    " + strings.Join(job.Ghost.Reasons, "; ") + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// formatGhost renders an assessment on one line for job_search and job_analysis
+func formatGhost(g *GhostAssessment) string {
+	if g == nil {
+		return ""
+	}
+	line := fmt.Sprintf("ghost %.2f (%s)", g.Score, g.Level)
+	if len(g.Reasons) > 0 {
+		line += ": " + strings.Join(g.Reasons, "; ")
+	}
+	return line
+}
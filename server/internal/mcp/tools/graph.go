@@ -3,205 +3,260 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 
-	"github.com/honeycarbs/project-ets/pkg/logging"
+	"github.com/honeycarbs/project-ets/internal/identity"
+	"github.com/honeycarbs/project-ets/pkg/cypherguard"
 	pkgneo4j "github.com/honeycarbs/project-ets/pkg/neo4j"
 )
 
 // GraphToolParams defines the arguments for the graph_tool tool
 type GraphToolParams struct {
-	Cypher  string                 `json:"cypher,omitempty" jsonschema:"Custom Cypher query to run"`
-	JobID   string                 `json:"job_id,omitempty"`
-	UserID  string                 `json:"user_id,omitempty"`
-	Filters map[string]interface{} `json:"filters,omitempty" jsonschema:"Optional label/relation filters"`
+	Mode      string                 `json:"mode,omitempty" jsonschema:"query (default) runs cypher, query_name, job_id or node statistics; schema returns labels, relationship types, properties and constraints; export writes the job subgraph as GraphML, Cypher or JSON Lines"`
+	Refresh   bool                   `json:"refresh,omitempty" jsonschema:"In schema mode, reload the schema instead of using the cached copy"`
+	Export    *GraphExportParams     `json:"export,omitempty" jsonschema:"Export options for mode=export"`
+	Cypher    string                 `json:"cypher,omitempty" jsonschema:"Custom Cypher query to run"`
+	JobID     string                 `json:"job_id,omitempty"`
+	UserID    string                 `json:"user_id,omitempty"`
+	Filters   map[string]interface{} `json:"filters,omitempty" jsonschema:"Optional label/relation filters"`
+	Format    string                 `json:"format,omitempty" jsonschema:"Text rendering: text (default), json, table (TSV) or graph (nodes and relationships)"`
+	QueryName string                 `json:"query_name,omitempty" jsonschema:"Name of a saved query to run instead of cypher; see the graph://saved-queries resource"`
+	Params    map[string]any         `json:"params,omitempty" jsonschema:"Parameters for the saved query named by query_name"`
 }
 
+// GraphToolLimits bounds the cost and size of graph_tool queries
+type GraphToolLimits struct {
+	MaxRows  int
+	MaxBytes int
+	Timeout  time.Duration
+}
+
+// GraphQueryRefusal is the structured error returned when a query is refused
+type GraphQueryRefusal struct {
+	Refused bool   `json:"refused" jsonschema:"Always true"`
+	Code    string `json:"code" jsonschema:"Refusal category e.g. write_clause, procedure, limit"`
+	Clause  string `json:"clause,omitempty" jsonschema:"Offending clause, procedure or function"`
+	Reason  string `json:"reason" jsonschema:"Human-readable explanation"`
+	Query   string `json:"query" jsonschema:"The refused query"`
+}
+
+// graph_tool modes
+const (
+	GraphModeQuery  = "query"
+	GraphModeSchema = "schema"
+	GraphModeExport = "export"
+)
+
 type graphToolHandler struct {
-	client *pkgneo4j.Client
-	logger *logging.Logger
+	client   *pkgneo4j.Client
+	catalog  SavedQueryCatalog
+	schema   GraphSchemaProvider
+	exporter GraphExporter
+	limits   GraphToolLimits
+	guard    cypherguard.Policy
+}
+
+func newGraphToolHandler(client *pkgneo4j.Client, catalog SavedQueryCatalog, schema GraphSchemaProvider, exporter GraphExporter, limits GraphToolLimits) *graphToolHandler {
+	return &graphToolHandler{
+		client:   client,
+		catalog:  catalog,
+		schema:   schema,
+		exporter: exporter,
+		limits:   limits,
+		guard:    cypherguard.DefaultPolicy(limits.MaxRows),
+	}
 }
 
 // WithGraphTool registers the graph_tool
-func WithGraphTool(client *pkgneo4j.Client) Option {
+func WithGraphTool(client *pkgneo4j.Client, catalog SavedQueryCatalog, schema GraphSchemaProvider, exporter GraphExporter, limits GraphToolLimits) Option {
 	return func(reg *registry) {
-		handler := graphToolHandler{client: client}
-		sdkmcp.AddTool(reg.server, &sdkmcp.Tool{
+		handler := newGraphToolHandler(client, catalog, schema, exporter, limits)
+		AddTool(reg.server, &sdkmcp.Tool{
 			Name:        "graph_tool",
-			Description: "Developer tool for inspecting and debugging the Neo4j knowledge graph",
-		}, handler.handle)
+			Description: "Developer tool for inspecting and debugging the Neo4j knowledge graph. Run a saved query with query_name and params (listed in the graph://saved-queries resource), pass custom read-only cypher, use mode=schema to discover labels, relationship types and properties, or mode=export to export the job subgraph",
+		}, handler.handle, requires(client, "Neo4j client"))
 	}
 }
 
-func RegisterGraphTool(server *sdkmcp.Server, client *pkgneo4j.Client, logger *logging.Logger) error {
-	handler := graphToolHandler{client: client, logger: logger}
-	sdkmcp.AddTool(server, &sdkmcp.Tool{
-		Name:        "graph_tool",
-		Description: "Developer tool for inspecting and debugging the Neo4j knowledge graph",
-	}, handler.handle)
-	if logger != nil {
-		logger.Info("graph_tool registered successfully")
-	}
+func RegisterGraphTool(server *ToolServer, client *pkgneo4j.Client, catalog SavedQueryCatalog, schema GraphSchemaProvider, exporter GraphExporter, limits GraphToolLimits) error {
+	Register(server, WithGraphTool(client, catalog, schema, exporter, limits))
 	return nil
 }
 
-func (h *graphToolHandler) handle(ctx context.Context, req *sdkmcp.CallToolRequest, params *GraphToolParams) (*sdkmcp.CallToolResult, any, error) {
-	if h.logger != nil {
-		h.logger.Debug("graph_tool called")
-	}
-
-	if h.client == nil {
-		err := fmt.Errorf("Neo4j client not configured")
-		if h.logger != nil {
-			h.logger.Error("graph_tool: Neo4j client not available", "err", err)
-		}
-		return textResult("graph_tool unavailable: Neo4j client not configured"), nil, err
+func (h *graphToolHandler) handle(ctx context.Context, params *GraphToolParams) (*sdkmcp.CallToolResult, any, error) {
+	format, err := parseGraphFormat(params.Format)
+	if err != nil {
+		return nil, nil, invalidParams("%v", err)
 	}
 
-	if params == nil {
-		err := fmt.Errorf("missing parameters")
-		if h.logger != nil {
-			h.logger.Warn("graph_tool: parameters are required")
-		}
-		return textResult("graph_tool requires parameters"), nil, err
+	switch mode := strings.ToLower(strings.TrimSpace(params.Mode)); mode {
+	case "", GraphModeQuery:
+	case GraphModeSchema:
+		return h.handleSchema(ctx, params.Refresh, format)
+	case GraphModeExport:
+		return h.handleExport(ctx, params.Export)
+	default:
+		return nil, nil, invalidParams("unsupported mode %q (use query, schema or export)", params.Mode)
 	}
 
 	var query string
 	var queryParams map[string]interface{}
 	queryType := ""
 
-	if params.Cypher != "" {
-		query = params.Cypher
+	if params.QueryName != "" && params.Cypher != "" {
+		return nil, nil, invalidParams("query_name and cypher are mutually exclusive")
+	}
+
+	if params.QueryName != "" {
+		if h.catalog == nil {
+			return nil, nil, fmt.Errorf("saved queries are not configured")
+		}
+		saved, bound, err := h.catalog.Resolve(ctx, params.QueryName, params.Params)
+		if err != nil {
+			return nil, nil, invalidParams("%v", err)
+		}
+		query = saved.Cypher
+		queryParams = bound
+		queryType = "saved_query"
+		annotate(ctx, "query_name", saved.Name, "source", saved.Source)
+	} else if params.Cypher != "" {
+		analysis, err := h.guard.Check(params.Cypher)
+		if err != nil {
+			return h.refuse(ctx, params.Cypher, err)
+		}
+		query = analysis.Query
 		queryParams = buildQueryParams(params)
 		queryType = "custom_cypher"
-		if h.logger != nil {
-			h.logger.Info("graph_tool: executing custom Cypher query",
-				"cypher", query,
-				"params_count", len(queryParams),
-			)
-		}
+		annotate(ctx, "cypher", query, "limit_injected", analysis.LimitInjected, "limit_capped", analysis.LimitCapped)
 	} else if params.JobID != "" {
 		query = `
 			MATCH (j:Job {id: $jobId})
 			OPTIONAL MATCH (j)-[:WORKED_AT]->(c:Company)
 			OPTIONAL MATCH (j)-[:REQUIRES]->(s:Skill)
 			OPTIONAL MATCH (j)-[hk:HAS_KEYWORD]->(k:Keyword)
+			WHERE coalesce(hk.ownerId, '') = $ownerId
 			RETURN j, c,
 			       collect(DISTINCT s) as skills,
 			       collect(DISTINCT {value: k.value, source: hk.source}) as keywords
 		`
-		queryParams = map[string]interface{}{"jobId": params.JobID}
+		queryParams = map[string]interface{}{"jobId": params.JobID, "ownerId": identity.OwnerID(ctx)}
 		queryType = "job_inspection"
-		if h.logger != nil {
-			h.logger.Info("graph_tool: inspecting job",
-				"job_id", params.JobID,
-			)
-		}
+		annotate(ctx, "job_id", params.JobID)
 	} else {
 		query = "MATCH (n) RETURN labels(n) as labels, count(n) as count ORDER BY count DESC LIMIT 20"
 		queryParams = nil
 		queryType = "node_statistics"
-		if h.logger != nil {
-			h.logger.Info("graph_tool: fetching node statistics")
-		}
 	}
+	annotate(ctx, "query_type", queryType)
 
-	result, err := h.executeQuery(ctx, query, queryParams)
+	collected, err := h.executeQuery(ctx, query, queryParams)
 	if err != nil {
-		if h.logger != nil {
-			h.logger.Error("graph_tool: query execution failed",
-				"err", err,
-				"query_type", queryType,
-				"query", query,
-			)
-		}
-		return textResult(fmt.Sprintf("graph_tool error: %v", err)), nil, err
+		return nil, nil, err
+	}
+
+	// The text is rendered from the rows kept in the structured result and
+	// cut again below if its format is wordier than JSON
+	structured, collected := fitGraphQueryResult(collected, format, h.limits.MaxBytes)
+	result, err := h.render(format, collected, structured)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	if h.logger != nil {
-		h.logger.Info("graph_tool: query executed successfully",
-			"query_type", queryType,
-			"result_length", len(result),
-		)
-		h.logger.Debug("graph_tool: query result",
-			"result", result,
-		)
+	if h.limits.MaxBytes > 0 && len(result) > h.limits.MaxBytes {
+		result = strings.ToValidUTF8(result[:h.limits.MaxBytes], "") + "\n... output truncated"
+		structured.Truncated = true
 	}
+	if structured.Truncated {
+		result += fmt.Sprintf("\n[graph_tool] result truncated (limits: %d rows, %d bytes)", h.limits.MaxRows, h.limits.MaxBytes)
+	}
+
+	annotate(ctx, "format", format, "rows", structured.RowCount, "truncated", structured.Truncated)
+	return textResult(result), structured, nil
+}
+
+// refuse reports a query rejected by the Cypher guard as a tool error with structured details
+func (h *graphToolHandler) refuse(ctx context.Context, query string, err error) (*sdkmcp.CallToolResult, any, error) {
+	var violation *cypherguard.Violation
+	if !errors.As(err, &violation) {
+		return nil, nil, invalidParams("%v", err)
+	}
+
+	annotate(ctx, "code", violation.Code, "clause", violation.Clause, "reason", violation.Reason, "query", query)
+
+	refusal := GraphQueryRefusal{
+		Refused: true,
+		Code:    violation.Code,
+		Clause:  violation.Clause,
+		Reason:  violation.Reason,
+		Query:   query,
+	}
+	return errorResult(fmt.Sprintf("graph_tool refused the query: %v", violation)), refusal, nil
+}
 
-	return textResult(result), nil, nil
+// graphRecords are the raw records collected by executeQuery
+type graphRecords struct {
+	keys      []string
+	records   []*neo4j.Record
+	truncated bool
 }
 
-func (h *graphToolHandler) executeQuery(ctx context.Context, query string, params map[string]interface{}) (string, error) {
-	if h.logger != nil {
-		h.logger.Debug("graph_tool: creating Neo4j session")
+func (h *graphToolHandler) executeQuery(ctx context.Context, query string, params map[string]interface{}) (graphRecords, error) {
+	if h.limits.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.limits.Timeout)
+		defer cancel()
 	}
+
 	session := h.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
 	defer session.Close(ctx)
 
 	var allRecords []*neo4j.Record
 	var keys []string
+	truncated := false
 
-	if h.logger != nil {
-		h.logger.Debug("graph_tool: executing read transaction",
-			"query", query,
-			"has_params", params != nil,
-		)
+	var txConfig []func(*neo4j.TransactionConfig)
+	if h.limits.Timeout > 0 {
+		txConfig = append(txConfig, neo4j.WithTxTimeout(h.limits.Timeout))
 	}
 
 	_, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
 		result, err := tx.Run(ctx, query, params)
 		if err != nil {
-			if h.logger != nil {
-				h.logger.Error("graph_tool: failed to run query", "err", err)
-			}
+			return nil, err
+		}
+
+		keys, err = result.Keys()
+		if err != nil {
 			return nil, err
 		}
 
 		recordCount := 0
 		for result.Next(ctx) {
-			record := result.Record()
-			if keys == nil {
-				keys = record.Keys
-				if h.logger != nil {
-					h.logger.Debug("graph_tool: query result keys", "keys", keys)
-				}
+			if h.limits.MaxRows > 0 && recordCount >= h.limits.MaxRows {
+				truncated = true
+				break
 			}
-			allRecords = append(allRecords, record)
+			allRecords = append(allRecords, result.Record())
 			recordCount++
 		}
 
-		if h.logger != nil {
-			h.logger.Debug("graph_tool: records collected", "count", recordCount)
-		}
-
 		if err := result.Err(); err != nil {
-			if h.logger != nil {
-				h.logger.Error("graph_tool: result iteration error", "err", err)
-			}
 			return nil, err
 		}
 
 		return nil, nil
-	})
+	}, txConfig...)
 	if err != nil {
-		if h.logger != nil {
-			h.logger.Error("graph_tool: transaction failed", "err", err)
-		}
-		return "", fmt.Errorf("query execution failed: %w", err)
-	}
-
-	if h.logger != nil {
-		h.logger.Debug("graph_tool: formatting results",
-			"records_count", len(allRecords),
-			"keys_count", len(keys),
-		)
+		return graphRecords{}, fmt.Errorf("query execution failed: %w", err)
 	}
 
-	return h.formatCollectedResults(allRecords, keys)
+	return graphRecords{keys: keys, records: allRecords, truncated: truncated}, nil
 }
 
 func (h *graphToolHandler) formatCollectedResults(records []*neo4j.Record, keys []string) (string, error) {
@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/honeycarbs/project-ets/internal/repository"
+)
+
+// GraphExporter writes the job subgraph in a portable format
+type GraphExporter interface {
+	Export(ctx context.Context, w io.Writer, format string, filter repository.ExportFilter) (int, error)
+}
+
+// GraphExportParams selects the format and jobs for graph_tool mode=export
+type GraphExportParams struct {
+	Format    string   `json:"format" jsonschema:"graphml, cypher (MERGE script) or jsonl"`
+	JobIDs    []string `json:"job_ids,omitempty" jsonschema:"Only export these jobs"`
+	From      string   `json:"from,omitempty" jsonschema:"Only export jobs on or after this date (YYYY-MM-DD or RFC 3339)"`
+	To        string   `json:"to,omitempty" jsonschema:"Only export jobs before this date (YYYY-MM-DD or RFC 3339)"`
+	TimeField string   `json:"time_field,omitempty" jsonschema:"Date used by from/to: fetchedAt (default) or postedAt"`
+}
+
+// GraphExportResult is the structured output of graph_tool mode=export
+type GraphExportResult struct {
+	Format    string `json:"format" jsonschema:"Export format"`
+	Jobs      int    `json:"jobs" jsonschema:"Number of jobs exported"`
+	Bytes     int    `json:"bytes" jsonschema:"Size of the full export in bytes"`
+	Truncated bool   `json:"truncated" jsonschema:"Whether content was cut by the output limit; use the export CLI for large exports"`
+	Content   string `json:"content" jsonschema:"Exported document"`
+}
+
+// handleExport serves graph_tool mode=export
+func (h *graphToolHandler) handleExport(ctx context.Context, params *GraphExportParams) (*sdkmcp.CallToolResult, any, error) {
+	if h.exporter == nil {
+		return nil, nil, fmt.Errorf("graph export is not configured")
+	}
+	if params == nil || params.Format == "" {
+		return nil, nil, invalidParams("export.format is required (graphml, cypher or jsonl)")
+	}
+
+	filter, err := params.filter()
+	if err != nil {
+		return nil, nil, invalidParams("%v", err)
+	}
+
+	var buf bytes.Buffer
+	jobs, err := h.exporter.Export(ctx, &buf, params.Format, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := GraphExportResult{
+		Format:  strings.ToLower(strings.TrimSpace(params.Format)),
+		Jobs:    jobs,
+		Bytes:   buf.Len(),
+		Content: buf.String(),
+	}
+	if h.limits.MaxBytes > 0 && len(result.Content) > h.limits.MaxBytes {
+		result.Content = strings.ToValidUTF8(result.Content[:h.limits.MaxBytes], "")
+		result.Truncated = true
+	}
+
+	text := result.Content
+	if result.Truncated {
+		text += fmt.Sprintf("\n[graph_tool] export truncated at %d of %d bytes; use `server export` for the full file", h.limits.MaxBytes, result.Bytes)
+	}
+
+	annotate(ctx, "mode", GraphModeExport, "format", result.Format, "jobs", jobs, "bytes", result.Bytes, "truncated", result.Truncated)
+	return textResult(text), result, nil
+}
+
+func (p *GraphExportParams) filter() (repository.ExportFilter, error) {
+	filter := repository.ExportFilter{
+		JobIDs:    p.JobIDs,
+		TimeField: p.TimeField,
+	}
+	var err error
+	if filter.From, err = ParseExportDate(p.From); err != nil {
+		return filter, fmt.Errorf("invalid from: %w", err)
+	}
+	if filter.To, err = ParseExportDate(p.To); err != nil {
+		return filter, fmt.Errorf("invalid to: %w", err)
+	}
+	return filter, nil
+}
+
+// ParseExportDate accepts YYYY-MM-DD or RFC 3339; empty means unbounded
+func ParseExportDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
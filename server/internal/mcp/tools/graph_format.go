@@ -0,0 +1,314 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
+)
+
+// graph_tool output formats
+const (
+	GraphFormatText  = "text"
+	GraphFormatJSON  = "json"
+	GraphFormatTable = "table"
+	GraphFormatGraph = "graph"
+)
+
+// GraphNode is a node returned by a graph_tool query
+type GraphNode struct {
+	ElementID  string         `json:"element_id" jsonschema:"Neo4j element ID"`
+	Labels     []string       `json:"labels" jsonschema:"Node labels"`
+	Properties map[string]any `json:"properties" jsonschema:"Node properties"`
+}
+
+// GraphRelationship is a relationship returned by a graph_tool query
+type GraphRelationship struct {
+	ElementID      string         `json:"element_id" jsonschema:"Neo4j element ID"`
+	Type           string         `json:"type" jsonschema:"Relationship type"`
+	StartElementID string         `json:"start_element_id" jsonschema:"Element ID of the start node"`
+	EndElementID   string         `json:"end_element_id" jsonschema:"Element ID of the end node"`
+	Properties     map[string]any `json:"properties" jsonschema:"Relationship properties"`
+}
+
+// GraphQueryResult is the structured output of graph_tool
+type GraphQueryResult struct {
+	Format        string              `json:"format" jsonschema:"Format used for the text content"`
+	Columns       []string            `json:"columns" jsonschema:"Result column names"`
+	Rows          [][]any             `json:"rows" jsonschema:"Result rows; nodes and relationships appear as objects with element_id"`
+	RowCount      int                 `json:"row_count" jsonschema:"Number of rows returned"`
+	Nodes         []GraphNode         `json:"nodes" jsonschema:"Distinct nodes found anywhere in the result"`
+	Relationships []GraphRelationship `json:"relationships" jsonschema:"Distinct relationships found anywhere in the result"`
+	Truncated     bool                `json:"truncated" jsonschema:"Whether rows or text output were cut by server limits"`
+}
+
+func parseGraphFormat(format string) (string, error) {
+	switch f := strings.ToLower(strings.TrimSpace(format)); f {
+	case "":
+		return GraphFormatText, nil
+	case GraphFormatText, GraphFormatJSON, GraphFormatTable, GraphFormatGraph:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unsupported format %q (use text, json, table or graph)", format)
+	}
+}
+
+// graphCollector converts driver values to JSON-friendly values while
+// gathering every distinct node and relationship it encounters
+type graphCollector struct {
+	nodes     []GraphNode
+	rels      []GraphRelationship
+	seenNodes map[string]bool
+	seenRels  map[string]bool
+}
+
+func buildGraphQueryResult(collected graphRecords, format string) GraphQueryResult {
+	c := &graphCollector{
+		seenNodes: make(map[string]bool),
+		seenRels:  make(map[string]bool),
+	}
+
+	rows := make([][]any, 0, len(collected.records))
+	for _, record := range collected.records {
+		row := make([]any, 0, len(collected.keys))
+		for _, key := range collected.keys {
+			val, _ := record.Get(key)
+			row = append(row, c.convert(val))
+		}
+		rows = append(rows, row)
+	}
+
+	columns := collected.keys
+	if columns == nil {
+		columns = []string{}
+	}
+	nodes := c.nodes
+	if nodes == nil {
+		nodes = []GraphNode{}
+	}
+	rels := c.rels
+	if rels == nil {
+		rels = []GraphRelationship{}
+	}
+
+	return GraphQueryResult{
+		Format:        format,
+		Columns:       columns,
+		Rows:          rows,
+		RowCount:      len(rows),
+		Nodes:         nodes,
+		Relationships: rels,
+		Truncated:     collected.truncated,
+	}
+}
+
+// fitGraphQueryResult builds the structured result from as many leading
+// records as encode to at most maxBytes of JSON, so the structured content
+// obeys the same size limit as the text. Nodes and relationships seen only
+// in dropped rows are dropped with them. It also returns the kept records
+func fitGraphQueryResult(collected graphRecords, format string, maxBytes int) (GraphQueryResult, graphRecords) {
+	structured := buildGraphQueryResult(collected, format)
+	if maxBytes <= 0 || encodedSize(structured) <= maxBytes {
+		return structured, collected
+	}
+
+	kept := graphRecords{keys: collected.keys, truncated: true}
+	best := buildGraphQueryResult(kept, format)
+	lo, hi := 1, len(collected.records)-1
+	for lo <= hi {
+		mid := (lo + hi) / 2
+		prefix := graphRecords{keys: collected.keys, records: collected.records[:mid], truncated: true}
+		candidate := buildGraphQueryResult(prefix, format)
+		if encodedSize(candidate) <= maxBytes {
+			best, kept = candidate, prefix
+			lo = mid + 1
+		} else {
+			hi = mid - 1
+		}
+	}
+	return best, kept
+}
+
+func encodedSize(v any) int {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
+
+func (c *graphCollector) convert(val any) any {
+	switch v := val.(type) {
+	case nil:
+		return nil
+	case neo4j.Node:
+		c.addNode(v)
+		return map[string]any{
+			"element_id": v.ElementId,
+			"labels":     v.Labels,
+			"properties": convertProps(v.Props),
+		}
+	case neo4j.Relationship:
+		c.addRel(v)
+		return map[string]any{
+			"element_id":       v.ElementId,
+			"type":             v.Type,
+			"start_element_id": v.StartElementId,
+			"end_element_id":   v.EndElementId,
+			"properties":       convertProps(v.Props),
+		}
+	case neo4j.Path:
+		nodeIDs := make([]string, 0, len(v.Nodes))
+		for _, n := range v.Nodes {
+			c.addNode(n)
+			nodeIDs = append(nodeIDs, n.ElementId)
+		}
+		relIDs := make([]string, 0, len(v.Relationships))
+		for _, r := range v.Relationships {
+			c.addRel(r)
+			relIDs = append(relIDs, r.ElementId)
+		}
+		return map[string]any{"nodes": nodeIDs, "relationships": relIDs}
+	case []any:
+		out := make([]any, 0, len(v))
+		for _, item := range v {
+			out = append(out, c.convert(item))
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			out[k] = c.convert(item)
+		}
+		return out
+	default:
+		return convertScalar(v)
+	}
+}
+
+func (c *graphCollector) addNode(n neo4j.Node) {
+	if c.seenNodes[n.ElementId] {
+		return
+	}
+	c.seenNodes[n.ElementId] = true
+	c.nodes = append(c.nodes, GraphNode{
+		ElementID:  n.ElementId,
+		Labels:     n.Labels,
+		Properties: convertProps(n.Props),
+	})
+}
+
+func (c *graphCollector) addRel(r neo4j.Relationship) {
+	if c.seenRels[r.ElementId] {
+		return
+	}
+	c.seenRels[r.ElementId] = true
+	c.rels = append(c.rels, GraphRelationship{
+		ElementID:      r.ElementId,
+		Type:           r.Type,
+		StartElementID: r.StartElementId,
+		EndElementID:   r.EndElementId,
+		Properties:     convertProps(r.Props),
+	})
+}
+
+func convertProps(props map[string]any) map[string]any {
+	out := make(map[string]any, len(props))
+	for k, v := range props {
+		out[k] = convertScalar(v)
+	}
+	return out
+}
+
+// convertScalar renders temporal and spatial driver types as strings
+func convertScalar(val any) any {
+	switch v := val.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case dbtype.Date, dbtype.LocalTime, dbtype.LocalDateTime, dbtype.Time, dbtype.Duration,
+		dbtype.Point2D, dbtype.Point3D:
+		return fmt.Sprint(v)
+	case []any:
+		out := make([]any, 0, len(v))
+		for _, item := range v {
+			out = append(out, convertScalar(item))
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// render produces the text content for the requested format
+func (h *graphToolHandler) render(format string, collected graphRecords, structured GraphQueryResult) (string, error) {
+	switch format {
+	case GraphFormatJSON:
+		return marshalIndent(structured)
+	case GraphFormatGraph:
+		return marshalIndent(map[string]any{
+			"nodes":         structured.Nodes,
+			"relationships": structured.Relationships,
+		})
+	case GraphFormatTable:
+		return formatTable(collected), nil
+	default:
+		return h.formatCollectedResults(collected.records, collected.keys)
+	}
+}
+
+func marshalIndent(v any) (string, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode result: %w", err)
+	}
+	return string(data), nil
+}
+
+// formatTable renders tab-separated values with a header row, ready to paste into a spreadsheet
+func formatTable(collected graphRecords) string {
+	var sb strings.Builder
+	sb.WriteString(strings.Join(collected.keys, "\t"))
+	sb.WriteString("\n")
+
+	for _, record := range collected.records {
+		cells := make([]string, 0, len(collected.keys))
+		for _, key := range collected.keys {
+			val, _ := record.Get(key)
+			cells = append(cells, tableCell(val))
+		}
+		sb.WriteString(strings.Join(cells, "\t"))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func tableCell(val any) string {
+	var cell string
+	switch v := val.(type) {
+	case nil:
+		cell = ""
+	case string:
+		cell = v
+	case neo4j.Node:
+		data, _ := json.Marshal(convertProps(v.Props))
+		cell = string(data)
+	case neo4j.Relationship:
+		cell = v.Type
+	default:
+		converted := convertScalar(v)
+		if s, ok := converted.(string); ok {
+			cell = s
+		} else {
+			data, err := json.Marshal(converted)
+			if err != nil {
+				cell = fmt.Sprint(v)
+			} else {
+				cell = string(data)
+			}
+		}
+	}
+	return strings.NewReplacer("\t", " ", "\n", " ", "\r", " ").Replace(cell)
+}
@@ -0,0 +1,49 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+func TestFitGraphQueryResult(t *testing.T) {
+	collected := graphRecords{keys: []string{"j"}}
+	for i := 0; i < 50; i++ {
+		node := neo4j.Node{
+			ElementId: fmt.Sprintf("4:job:%d", i),
+			Labels:    []string{"Job"},
+			Props:     map[string]any{"title": strings.Repeat("x", 100)},
+		}
+		collected.records = append(collected.records, &neo4j.Record{Keys: []string{"j"}, Values: []any{node}})
+	}
+
+	full, _ := fitGraphQueryResult(collected, GraphFormatJSON, 0)
+	if full.RowCount != 50 || len(full.Nodes) != 50 || full.Truncated {
+		t.Fatalf("unlimited: %d rows, %d nodes, truncated %v", full.RowCount, len(full.Nodes), full.Truncated)
+	}
+
+	const maxBytes = 4096
+	fitted, kept := fitGraphQueryResult(collected, GraphFormatJSON, maxBytes)
+	data, err := json.Marshal(fitted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) > maxBytes {
+		t.Errorf("structured result is %d bytes, want at most %d", len(data), maxBytes)
+	}
+	if !fitted.Truncated || fitted.RowCount == 0 || fitted.RowCount >= 50 {
+		t.Errorf("fitted: %d rows, truncated %v", fitted.RowCount, fitted.Truncated)
+	}
+	if len(fitted.Rows) != fitted.RowCount || len(fitted.Nodes) != fitted.RowCount || len(kept.records) != fitted.RowCount {
+		t.Errorf("fitted: %d rows, %d nodes, %d records kept for row_count %d", len(fitted.Rows), len(fitted.Nodes), len(kept.records), fitted.RowCount)
+	}
+
+	// One more row would not have fit
+	next := graphRecords{keys: collected.keys, records: collected.records[:fitted.RowCount+1]}
+	if size := encodedSize(buildGraphQueryResult(next, GraphFormatJSON)); size <= maxBytes {
+		t.Errorf("%d rows encode to %d bytes, yet only %d were kept", fitted.RowCount+1, size, fitted.RowCount)
+	}
+}
@@ -0,0 +1,131 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/honeycarbs/project-ets/internal/repository"
+	"github.com/honeycarbs/project-ets/pkg/logging"
+)
+
+// GraphSchemaURI is the MCP resource describing the live graph schema
+const GraphSchemaURI = "graph://schema"
+
+// GraphSchemaProvider returns the graph schema, cached unless refresh is set
+type GraphSchemaProvider interface {
+	Schema(ctx context.Context, refresh bool) (repository.GraphSchema, error)
+}
+
+// handleSchema serves graph_tool mode=schema
+func (h *graphToolHandler) handleSchema(ctx context.Context, refresh bool, format string) (*sdkmcp.CallToolResult, any, error) {
+	if h.schema == nil {
+		return nil, nil, fmt.Errorf("schema introspection is not configured")
+	}
+
+	schema, err := h.schema.Schema(ctx, refresh)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var text string
+	if format == GraphFormatText {
+		text = formatGraphSchema(schema)
+	} else {
+		text, err = marshalIndent(schema)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	annotate(ctx, "mode", GraphModeSchema, "refresh", refresh, "labels", len(schema.Labels), "relationships", len(schema.Relationships))
+	return textResult(text), schema, nil
+}
+
+// formatGraphSchema renders the schema as Cypher-like patterns
+func formatGraphSchema(schema repository.GraphSchema) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Graph schema (fetched %s)\n\n", schema.FetchedAt.Format(time.RFC3339)))
+
+	sb.WriteString("Node labels:\n")
+	for _, l := range schema.Labels {
+		sb.WriteString(fmt.Sprintf("  (:%s) %d nodes\n", l.Label, l.Count))
+		for _, p := range l.Properties {
+			sb.WriteString("    " + formatPropertySchema(p) + "\n")
+		}
+	}
+
+	sb.WriteString("\nRelationship types:\n")
+	for _, r := range schema.Relationships {
+		sb.WriteString(fmt.Sprintf("  [:%s] %d relationships\n", r.Type, r.Count))
+		for _, p := range r.Patterns {
+			sb.WriteString(fmt.Sprintf("    (:%s)-[:%s]->(:%s) x%d\n", p.From, r.Type, p.To, p.Count))
+		}
+		for _, p := range r.Properties {
+			sb.WriteString("    " + formatPropertySchema(p) + "\n")
+		}
+	}
+
+	if len(schema.Constraints) > 0 {
+		sb.WriteString("\nConstraints:\n")
+		for _, c := range schema.Constraints {
+			sb.WriteString(fmt.Sprintf("  %s: %s on %s %s(%s)\n",
+				c.Name, c.Type, strings.ToLower(c.EntityType), strings.Join(c.Labels, ":"), strings.Join(c.Properties, ", ")))
+		}
+	}
+
+	return sb.String()
+}
+
+func formatPropertySchema(p repository.PropertySchema) string {
+	s := fmt.Sprintf("%s: %s", p.Key, strings.Join(p.Types, " | "))
+	if p.Mandatory {
+		s += " (always set)"
+	}
+	return s
+}
+
+// RegisterGraphSchemaResource exposes the cached graph schema as an MCP resource
+func RegisterGraphSchemaResource(server *sdkmcp.Server, provider GraphSchemaProvider, logger *logging.Logger) error {
+	if provider == nil {
+		if logger != nil {
+			logger.Warn("graph schema provider not configured, skipping resource")
+		}
+		return nil
+	}
+
+	server.AddResource(&sdkmcp.Resource{
+		URI:         GraphSchemaURI,
+		Name:        "graph_schema",
+		Title:       "Graph schema",
+		Description: "Live node labels, relationship types, property keys with value types, constraints and counts. Cached; call graph_tool with mode=schema and refresh=true to reload",
+		MIMEType:    "application/json",
+	}, func(ctx context.Context, req *sdkmcp.ReadResourceRequest) (*sdkmcp.ReadResourceResult, error) {
+		schema, err := provider.Schema(ctx, false)
+		if err != nil {
+			if logger != nil {
+				logger.Error("failed to read graph schema", "err", err)
+			}
+			return nil, fmt.Errorf("read graph schema: %w", err)
+		}
+
+		data, err := marshalIndent(schema)
+		if err != nil {
+			return nil, err
+		}
+
+		return &sdkmcp.ReadResourceResult{
+			Contents: []*sdkmcp.ResourceContents{
+				{URI: GraphSchemaURI, MIMEType: "application/json", Text: data},
+			},
+		}, nil
+	})
+
+	if logger != nil {
+		logger.Info("graph schema resource registered successfully", "uri", GraphSchemaURI)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Text diff operations
+const (
+	DiffEqual  = "="
+	DiffDelete = "-"
+	DiffInsert = "+"
+)
+
+// JobHistoryService lists job versions and compares them
+type JobHistoryService interface {
+	JobHistory(ctx context.Context, params JobHistoryParams) (JobHistoryResult, error)
+}
+
+// JobHistoryParams defines the arguments for the job_history tool
+type JobHistoryParams struct {
+	JobID string `json:"job_id" jsonschema:"Job identifier"`
+	From  int    `json:"from,omitempty" jsonschema:"Version to diff from (default: the one before to)"`
+	To    int    `json:"to,omitempty" jsonschema:"Version to diff to (default: latest)"`
+}
+
+// JobVersion summarizes one stored snapshot
+type JobVersion struct {
+	Version    int       `json:"version" jsonschema:"1-based version number, oldest first"`
+	Hash       string    `json:"hash" jsonschema:"Content hash of the version"`
+	CapturedAt time.Time `json:"captured_at" jsonschema:"When the version was first seen"`
+	Title      string    `json:"title" jsonschema:"Job title"`
+	Location   string    `json:"location,omitempty" jsonschema:"Job location"`
+	Score      float64   `json:"score,omitempty" jsonschema:"Advertised salary"`
+	Words      int       `json:"words" jsonschema:"Description length in words"`
+}
+
+// FieldChange is a field whose value differs between two versions
+type FieldChange struct {
+	Field string `json:"field" jsonschema:"Field name"`
+	From  string `json:"from" jsonschema:"Value in the older version"`
+	To    string `json:"to" jsonschema:"Value in the newer version"`
+}
+
+// DiffLine is one line of a description diff
+type DiffLine struct {
+	Op   string `json:"op" jsonschema:"= unchanged, - removed, + added"`
+	Text string `json:"text" jsonschema:"Line or sentence text"`
+}
+
+// JobVersionDiff compares two versions of a job
+type JobVersionDiff struct {
+	From        int           `json:"from" jsonschema:"Older version"`
+	To          int           `json:"to" jsonschema:"Newer version"`
+	Fields      []FieldChange `json:"fields" jsonschema:"Changed fields other than the description"`
+	Description []DiffLine    `json:"description,omitempty" jsonschema:"Sentence-level description diff; omitted when unchanged"`
+}
+
+// JobHistoryResult is the structured response of job_history
+type JobHistoryResult struct {
+	JobID    string          `json:"job_id" jsonschema:"Job identifier"`
+	Title    string          `json:"title" jsonschema:"Current job title"`
+	Company  string          `json:"company,omitempty" jsonschema:"Company name"`
+	Versions []JobVersion    `json:"versions" jsonschema:"Stored versions, oldest first"`
+	Diff     *JobVersionDiff `json:"diff,omitempty" jsonschema:"Comparison of the selected versions; omitted with fewer than two versions"`
+}
+
+type jobHistoryTool struct {
+	service JobHistoryService
+}
+
+// WithJobHistory registers the job_history tool
+func WithJobHistory(service JobHistoryService) Option {
+	return func(reg *registry) {
+		handler := jobHistoryTool{service: service}
+		AddTool(reg.server, &sdkmcp.Tool{
+			Name:        "job_history",
+			Description: "List stored versions of a job posting and diff the fields and description of any two",
+		}, handler.handle, requires(service, "job history service"))
+	}
+}
+
+func RegisterHistoryTools(server *ToolServer, service JobHistoryService) error {
+	Register(server, WithJobHistory(service))
+	return nil
+}
+
+func (t jobHistoryTool) handle(ctx context.Context, params *JobHistoryParams) (*sdkmcp.CallToolResult, any, error) {
+	if strings.TrimSpace(params.JobID) == "" {
+		return nil, nil, invalidParams("job_id is required")
+	}
+
+	result, err := t.service.JobHistory(ctx, *params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	annotate(ctx, "job_id", result.JobID, "versions", len(result.Versions))
+	return textResult(formatJobHistory(result)), result, nil
+}
+
+func formatJobHistory(r JobHistoryResult) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("[job_history] %s", r.Title))
+	if r.Company != "" {
+		sb.WriteString(" at " + r.Company)
+	}
+	sb.WriteString(fmt.Sprintf(" (%s): %d version(s)\n", r.JobID, len(r.Versions)))
+
+	for _, v := range r.Versions {
+		sb.WriteString(fmt.Sprintf("  v%d %s %s, %d words", v.Version, v.CapturedAt.Format("2006-01-02 15:04"), v.Title, v.Words))
+		if v.Score > 0 {
+			sb.WriteString(fmt.Sprintf(", salary %.0f", v.Score))
+		}
+		sb.WriteString("\n")
+	}
+
+	if r.Diff == nil {
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("\nv%d → v%d\n", r.Diff.From, r.Diff.To))
+	if len(r.Diff.Fields) == 0 && len(r.Diff.Description) == 0 {
+		sb.WriteString("  no differences\n")
+	}
+	for _, f := range r.Diff.Fields {
+		sb.WriteString(fmt.Sprintf("  %s: %q → %q\n", f.Field, f.From, f.To))
+	}
+	if len(r.Diff.Description) > 0 {
+		sb.WriteString("  description:\n")
+		for _, line := range r.Diff.Description {
+			if line.Op == DiffEqual {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("    %s %s\n", line.Op, line.Text))
+		}
+	}
+	return sb.String()
+}
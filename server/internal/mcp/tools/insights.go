@@ -0,0 +1,110 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// SkillInsightsParams defines the arguments for the skill_insights tool
+type SkillInsightsParams struct {
+	Skills     []string `json:"skills,omitempty" jsonschema:"Skills to find co-occurring skills for, e.g. kubernetes"`
+	WindowDays int      `json:"window_days,omitempty" jsonschema:"Length of each trend window in days (default 30)"`
+	TimeField  string   `json:"time_field,omitempty" jsonschema:"Job timestamp to bucket by: fetched_at (default) or posted_at"`
+	SortBy     string   `json:"sort_by,omitempty" jsonschema:"Trend ordering: count (default) or delta for rising terms"`
+	Limit      int      `json:"limit,omitempty" jsonschema:"Maximum entries per section (default 20)"`
+}
+
+// SkillCooccurrenceEntry is a skill commonly required together with the requested skills
+type SkillCooccurrenceEntry struct {
+	Skill      string   `json:"skill" jsonschema:"Co-occurring skill name"`
+	Cooccurs   int      `json:"cooccurs" jsonschema:"Number of jobs requiring both"`
+	CommonWith []string `json:"common_with,omitempty" jsonschema:"Requested skills it appeared with"`
+}
+
+// TermTrendEntry compares a skill or keyword frequency between two windows
+type TermTrendEntry struct {
+	Term     string   `json:"term" jsonschema:"Skill name or keyword value"`
+	Current  int      `json:"current" jsonschema:"Jobs mentioning the term in the current window"`
+	Previous int      `json:"previous" jsonschema:"Jobs mentioning the term in the previous window"`
+	Delta    int      `json:"delta" jsonschema:"current - previous"`
+	Change   *float64 `json:"change,omitempty" jsonschema:"Relative change vs previous window; omitted when previous is 0"`
+}
+
+// InsightWindow is a half-open time range used for trend buckets
+type InsightWindow struct {
+	From time.Time `json:"from" jsonschema:"Inclusive window start"`
+	To   time.Time `json:"to" jsonschema:"Exclusive window end"`
+}
+
+// SkillInsightsResult is the structured response of skill_insights
+type SkillInsightsResult struct {
+	Cooccurrences  []SkillCooccurrenceEntry `json:"cooccurrences,omitempty" jsonschema:"Skills that usually come with the requested skills"`
+	SkillTrends    []TermTrendEntry         `json:"skill_trends" jsonschema:"Skill frequencies across windows"`
+	KeywordTrends  []TermTrendEntry         `json:"keyword_trends" jsonschema:"Keyword frequencies across windows"`
+	TimeField      string                   `json:"time_field" jsonschema:"Timestamp used for bucketing"`
+	CurrentWindow  InsightWindow            `json:"current_window" jsonschema:"Most recent window"`
+	PreviousWindow InsightWindow            `json:"previous_window" jsonschema:"Window preceding current_window"`
+	GeneratedAt    time.Time                `json:"generated_at" jsonschema:"Timestamp when insights were computed"`
+}
+
+type skillInsightsTool struct {
+	service AnalysisService
+}
+
+func WithSkillInsights(service AnalysisService) Option {
+	return func(reg *registry) {
+		handler := skillInsightsTool{service: service}
+		AddTool(reg.server, &sdkmcp.Tool{
+			Name:        "skill_insights",
+			Description: "Skill co-occurrence and time-windowed skill/keyword market trends from stored jobs",
+		}, handler.handle, requires(service, "analysis service"))
+	}
+}
+
+func (t skillInsightsTool) handle(ctx context.Context, params *SkillInsightsParams) (*sdkmcp.CallToolResult, any, error) {
+	result, err := t.service.SkillInsights(ctx, *params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	annotate(ctx,
+		"cooccurrences", len(result.Cooccurrences),
+		"skill_trends", len(result.SkillTrends),
+		"keyword_trends", len(result.KeywordTrends),
+	)
+	return textResult(formatSkillInsights(result)), result, nil
+}
+
+func formatSkillInsights(result SkillInsightsResult) string {
+	msg := fmt.Sprintf("[skill_insights] %s window %s – %s vs %s – %s\n",
+		result.TimeField,
+		result.CurrentWindow.From.Format("2006-01-02"), result.CurrentWindow.To.Format("2006-01-02"),
+		result.PreviousWindow.From.Format("2006-01-02"), result.PreviousWindow.To.Format("2006-01-02"),
+	)
+
+	if len(result.Cooccurrences) > 0 {
+		msg += "\nCo-occurring skills:\n"
+		for _, c := range result.Cooccurrences {
+			msg += fmt.Sprintf("  • %s (%d jobs, with %v)\n", c.Skill, c.Cooccurs, c.CommonWith)
+		}
+	}
+
+	msg += formatTrendSection("Skills", result.SkillTrends)
+	msg += formatTrendSection("Keywords", result.KeywordTrends)
+	return msg
+}
+
+func formatTrendSection(title string, trends []TermTrendEntry) string {
+	if len(trends) == 0 {
+		return fmt.Sprintf("\n%s: no data in either window\n", title)
+	}
+
+	msg := fmt.Sprintf("\n%s:\n", title)
+	for _, tr := range trends {
+		msg += fmt.Sprintf("  • %s: %d (prev %d, %+d)\n", tr.Term, tr.Current, tr.Previous, tr.Delta)
+	}
+	return msg
+}
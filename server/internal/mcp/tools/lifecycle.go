@@ -0,0 +1,90 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// LifecycleService re-checks stored postings against their providers
+type LifecycleService interface {
+	Recheck(ctx context.Context, params JobRecheckParams) (JobRecheckResult, error)
+}
+
+// JobRecheckParams defines the arguments for the job_recheck tool
+type JobRecheckParams struct {
+	JobIDs   []string `json:"job_ids,omitempty" jsonschema:"Jobs to re-check; otherwise the least recently seen jobs in statuses"`
+	Statuses []string `json:"statuses,omitempty" jsonschema:"Statuses to re-check when job_ids is empty: open, unknown, closed (default open and unknown)"`
+	Limit    int      `json:"limit,omitempty" jsonschema:"Maximum jobs to check (default 25)"`
+}
+
+// JobStatusChange is a job whose lifecycle status changed during a re-check
+type JobStatusChange struct {
+	JobID   string `json:"job_id" jsonschema:"Job identifier"`
+	Title   string `json:"title" jsonschema:"Job title"`
+	Company string `json:"company" jsonschema:"Company name"`
+	From    string `json:"from" jsonschema:"Previous status"`
+	To      string `json:"to" jsonschema:"New status"`
+}
+
+// JobRecheckResult is the structured response of job_recheck
+type JobRecheckResult struct {
+	Checked     int               `json:"checked" jsonschema:"Jobs the provider answered for"`
+	Open        int               `json:"open" jsonschema:"Jobs confirmed open"`
+	Closed      int               `json:"closed" jsonschema:"Jobs confirmed closed"`
+	Unknown     int               `json:"unknown" jsonschema:"Jobs the provider could not confirm either way"`
+	Unsupported int               `json:"unsupported" jsonschema:"Jobs whose provider cannot check postings"`
+	Failed      int               `json:"failed" jsonschema:"Jobs whose check returned an error"`
+	Changes     []JobStatusChange `json:"changes" jsonschema:"Jobs whose status changed"`
+	CheckedAt   time.Time         `json:"checked_at" jsonschema:"Timestamp of the re-check"`
+}
+
+type jobRecheckTool struct {
+	service LifecycleService
+}
+
+// WithJobRecheck registers the job_recheck tool
+func WithJobRecheck(service LifecycleService) Option {
+	return func(reg *registry) {
+		handler := jobRecheckTool{service: service}
+		AddTool(reg.server, &sdkmcp.Tool{
+			Name:        "job_recheck",
+			Description: "Re-check stored jobs against their provider and mark them open or closed",
+		}, handler.handle, requires(service, "lifecycle service"))
+	}
+}
+
+func RegisterLifecycleTools(server *ToolServer, service LifecycleService) error {
+	Register(server, WithJobRecheck(service))
+	return nil
+}
+
+func (t jobRecheckTool) handle(ctx context.Context, params *JobRecheckParams) (*sdkmcp.CallToolResult, any, error) {
+	result, err := t.service.Recheck(ctx, *params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	annotate(ctx, "checked", result.Checked, "closed", result.Closed, "changes", len(result.Changes))
+	return textResult(formatJobRecheck(result)), result, nil
+}
+
+func formatJobRecheck(r JobRecheckResult) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("[job_recheck] checked %d job(s): %d open, %d closed, %d unknown",
+		r.Checked, r.Open, r.Closed, r.Unknown))
+	if r.Unsupported > 0 {
+		sb.WriteString(fmt.Sprintf(", %d unsupported", r.Unsupported))
+	}
+	if r.Failed > 0 {
+		sb.WriteString(fmt.Sprintf(", %d failed", r.Failed))
+	}
+	sb.WriteString("\n")
+	for _, c := range r.Changes {
+		sb.WriteString(fmt.Sprintf("  • %s → %s | %s at %s %s\n", c.From, c.To, c.Title, c.Company, c.JobID))
+	}
+	return sb.String()
+}
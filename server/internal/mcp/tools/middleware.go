@@ -0,0 +1,182 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RequestIDs gives every call an ID, tags its log lines with it and returns
+// it in the result's _meta so clients can quote it
+func RequestIDs() ToolMiddleware {
+	return func(next ToolHandler) ToolHandler {
+		return func(ctx context.Context, call *ToolCall) (*sdkmcp.CallToolResult, any, error) {
+			call.RequestID = uuid.NewString()
+			call.logger = call.logger.With("request_id", call.RequestID)
+
+			res, out, err := next(ctx, call)
+			if res != nil {
+				if res.Meta == nil {
+					res.Meta = sdkmcp.Meta{}
+				}
+				res.Meta["request_id"] = call.RequestID
+			}
+			return res, out, err
+		}
+	}
+}
+
+// LogCalls logs each call when it starts and how it ended: completed,
+// refused (a tool error result), rejected arguments or failed
+func LogCalls() ToolMiddleware {
+	return func(next ToolHandler) ToolHandler {
+		return func(ctx context.Context, call *ToolCall) (*sdkmcp.CallToolResult, any, error) {
+			call.logger.Debug("tool called", "params", call.Params)
+			start := time.Now()
+
+			res, out, err := next(ctx, call)
+			fields := append([]any{"duration_ms", time.Since(start).Milliseconds()}, call.fields...)
+			switch {
+			case IsInvalidParams(err):
+				call.logger.Debug("tool call rejected", append(fields, "err", err)...)
+			case err != nil:
+				call.logger.Error("tool call failed", append(fields, "err", err)...)
+			case res != nil && res.IsError:
+				call.logger.Warn("tool call refused", fields...)
+			default:
+				call.logger.Info("tool call completed", fields...)
+			}
+			return res, out, err
+		}
+	}
+}
+
+// errPanic marks calls that ended in a recovered panic
+var errPanic = errors.New("internal error")
+
+// Recover turns a panicking handler into a failed call, so one bad call
+// cannot take the server down
+func Recover() ToolMiddleware {
+	return func(next ToolHandler) ToolHandler {
+		return func(ctx context.Context, call *ToolCall) (res *sdkmcp.CallToolResult, out any, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					call.logger.Error("tool call panicked", "panic", r, "stack", string(debug.Stack()))
+					res, out = nil, nil
+					err = fmt.Errorf("%w, request %s", errPanic, call.RequestID)
+				}
+			}()
+			return next(ctx, call)
+		}
+	}
+}
+
+// errTimeout marks calls cut off by Timeout
+var errTimeout = errors.New("timed out")
+
+// Timeout bounds each call by d. A zero d leaves calls unbounded
+func Timeout(d time.Duration) ToolMiddleware {
+	return func(next ToolHandler) ToolHandler {
+		if d <= 0 {
+			return next
+		}
+		return func(ctx context.Context, call *ToolCall) (*sdkmcp.CallToolResult, any, error) {
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+
+			res, out, err := next(ctx, call)
+			if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, nil, fmt.Errorf("%w after %s: %v", errTimeout, d, err)
+			}
+			return res, out, err
+		}
+	}
+}
+
+// ToolStats are the server-wide counters of one tool
+type ToolStats struct {
+	Tool       string  `json:"tool" jsonschema:"Tool name"`
+	Calls      int64   `json:"calls" jsonschema:"Calls since the server started"`
+	Refused    int64   `json:"refused" jsonschema:"Calls answered with a tool error without failing, such as scope or rate limit refusals"`
+	Invalid    int64   `json:"invalid" jsonschema:"Calls rejected for their arguments"`
+	Failed     int64   `json:"failed" jsonschema:"Calls that failed, including timeouts and panics"`
+	Timeouts   int64   `json:"timeouts" jsonschema:"Calls cut off by the tool timeout"`
+	Panics     int64   `json:"panics" jsonschema:"Calls that panicked"`
+	AvgMillis  float64 `json:"avg_ms" jsonschema:"Mean call duration in milliseconds"`
+	MaxMillis  int64   `json:"max_ms" jsonschema:"Longest call duration in milliseconds"`
+	totalMilli int64
+}
+
+// ToolMetrics counts calls, outcomes and durations per tool. It is safe for
+// concurrent use
+type ToolMetrics struct {
+	mu    sync.Mutex
+	tools map[string]*ToolStats
+}
+
+// NewToolMetrics creates empty metrics
+func NewToolMetrics() *ToolMetrics {
+	return &ToolMetrics{tools: make(map[string]*ToolStats)}
+}
+
+// Middleware records every call passing through it
+func (m *ToolMetrics) Middleware() ToolMiddleware {
+	return func(next ToolHandler) ToolHandler {
+		return func(ctx context.Context, call *ToolCall) (*sdkmcp.CallToolResult, any, error) {
+			start := time.Now()
+			res, out, err := next(ctx, call)
+			m.record(call.Tool, time.Since(start), res, err)
+			return res, out, err
+		}
+	}
+}
+
+func (m *ToolMetrics) record(tool string, elapsed time.Duration, res *sdkmcp.CallToolResult, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats, ok := m.tools[tool]
+	if !ok {
+		stats = &ToolStats{Tool: tool}
+		m.tools[tool] = stats
+	}
+	stats.Calls++
+	switch {
+	case IsInvalidParams(err):
+		stats.Invalid++
+	case err != nil:
+		stats.Failed++
+		if errors.Is(err, errTimeout) {
+			stats.Timeouts++
+		}
+		if errors.Is(err, errPanic) {
+			stats.Panics++
+		}
+	case res != nil && res.IsError:
+		stats.Refused++
+	}
+	ms := elapsed.Milliseconds()
+	stats.totalMilli += ms
+	stats.MaxMillis = max(stats.MaxMillis, ms)
+	stats.AvgMillis = float64(stats.totalMilli) / float64(stats.Calls)
+}
+
+// Snapshot returns the counters of every called tool, by name
+func (m *ToolMetrics) Snapshot() []ToolStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make([]ToolStats, 0, len(m.tools))
+	for _, stats := range m.tools {
+		out = append(out, *stats)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Tool < out[j].Tool })
+	return out
+}
@@ -0,0 +1,99 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type echoParams struct {
+	Text string `json:"text,omitempty"`
+}
+
+func TestToolChain(t *testing.T) {
+	metrics := NewToolMetrics()
+	server := sdkmcp.NewServer(&sdkmcp.Implementation{Name: "test", Version: "0"}, nil)
+	ts := NewToolServer(server, nil, RequestIDs(), LogCalls(), metrics.Middleware(), Recover(), Timeout(50*time.Millisecond))
+
+	AddTool(ts, &sdkmcp.Tool{Name: "echo"}, func(ctx context.Context, params *echoParams) (*sdkmcp.CallToolResult, any, error) {
+		if params.Text == "" {
+			return nil, nil, invalidParams("text is required")
+		}
+		return textResult(params.Text), nil, nil
+	})
+	AddTool(ts, &sdkmcp.Tool{Name: "boom"}, func(ctx context.Context, _ *echoParams) (*sdkmcp.CallToolResult, any, error) {
+		panic("boom")
+	})
+	AddTool(ts, &sdkmcp.Tool{Name: "slow"}, func(ctx context.Context, _ *echoParams) (*sdkmcp.CallToolResult, any, error) {
+		<-ctx.Done()
+		return nil, nil, ctx.Err()
+	})
+	var missing GhostService
+	AddTool(ts, &sdkmcp.Tool{Name: "ghosts"}, func(ctx context.Context, _ *echoParams) (*sdkmcp.CallToolResult, any, error) {
+		return textResult("unreachable"), nil, nil
+	}, requires(missing, "ghost service"))
+
+	serverTransport, clientTransport := sdkmcp.NewInMemoryTransports()
+	if _, err := server.Connect(context.Background(), serverTransport, nil); err != nil {
+		t.Fatal(err)
+	}
+	client := sdkmcp.NewClient(&sdkmcp.Implementation{Name: "test-client", Version: "0"}, nil)
+	session, err := client.Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+
+	call := func(tool string, args map[string]any) *sdkmcp.CallToolResult {
+		t.Helper()
+		res, err := session.CallTool(context.Background(), &sdkmcp.CallToolParams{Name: tool, Arguments: args})
+		if err != nil {
+			t.Fatalf("call %s: %v", tool, err)
+		}
+		return res
+	}
+	text := func(res *sdkmcp.CallToolResult) string {
+		return res.Content[0].(*sdkmcp.TextContent).Text
+	}
+
+	res := call("echo", map[string]any{"text": "hi"})
+	if res.IsError || text(res) != "hi" {
+		t.Errorf("echo = %q (error %v), want hi", text(res), res.IsError)
+	}
+	if id, _ := res.Meta["request_id"].(string); id == "" {
+		t.Errorf("echo meta = %v, want a request_id", res.Meta)
+	}
+
+	cases := []struct {
+		tool string
+		want string
+	}{
+		{"echo", "[echo] text is required"},
+		{"boom", "[boom] failed: internal error, request "},
+		{"slow", "[slow] failed: timed out after 50ms"},
+		{"ghosts", "[ghosts] failed: ghost service not configured"},
+	}
+	for _, tc := range cases {
+		res := call(tc.tool, map[string]any{})
+		if !res.IsError || !strings.HasPrefix(text(res), tc.want) {
+			t.Errorf("%s = %q (error %v), want an error starting with %q", tc.tool, text(res), res.IsError, tc.want)
+		}
+	}
+
+	stats := map[string]ToolStats{}
+	for _, s := range metrics.Snapshot() {
+		stats[s.Tool] = s
+	}
+	if s := stats["echo"]; s.Calls != 2 || s.Invalid != 1 || s.Failed != 0 {
+		t.Errorf("echo stats = %+v", s)
+	}
+	if s := stats["boom"]; s.Failed != 1 || s.Panics != 1 {
+		t.Errorf("boom stats = %+v", s)
+	}
+	if s := stats["slow"]; s.Failed != 1 || s.Timeouts != 1 || s.MaxMillis < 50 {
+		t.Errorf("slow stats = %+v", s)
+	}
+}
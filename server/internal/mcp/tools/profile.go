@@ -0,0 +1,191 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/repository"
+)
+
+// ExperienceEntry is a single position on the candidate's resume
+type ExperienceEntry struct {
+	Title       string   `json:"title" jsonschema:"Position title"`
+	Company     string   `json:"company,omitempty" jsonschema:"Employer name"`
+	Start       string   `json:"start,omitempty" jsonschema:"Start date, e.g. 2021-03"`
+	End         string   `json:"end,omitempty" jsonschema:"End date, empty when current"`
+	Description string   `json:"description,omitempty" jsonschema:"What the candidate did in this role"`
+	Skills      []string `json:"skills,omitempty" jsonschema:"Skills used in this role"`
+}
+
+// ProfilePreferences captures what the candidate is looking for
+type ProfilePreferences struct {
+	Locations   []string `json:"locations,omitempty" jsonschema:"Preferred locations"`
+	SalaryFloor float64  `json:"salary_floor,omitempty" jsonschema:"Minimum acceptable salary"`
+	Remote      *bool    `json:"remote,omitempty" jsonschema:"Whether remote work is required (true), excluded (false) or either (unset)"`
+}
+
+// CandidateProfile is the persisted candidate profile exchanged by profile tools
+type CandidateProfile struct {
+	CandidateID string             `json:"candidate_id,omitempty" jsonschema:"Candidate identifier; generated on first upsert when empty"`
+	Name        string             `json:"name,omitempty" jsonschema:"Candidate name"`
+	Headline    string             `json:"headline,omitempty" jsonschema:"One-line professional headline"`
+	Summary     string             `json:"summary,omitempty" jsonschema:"Free-form resume or summary text"`
+	Skills      []string           `json:"skills,omitempty" jsonschema:"Skills the candidate has"`
+	Experience  []ExperienceEntry  `json:"experience,omitempty" jsonschema:"Work history entries"`
+	Preferences ProfilePreferences `json:"preferences,omitempty" jsonschema:"Job search preferences"`
+	UpdatedAt   time.Time          `json:"updated_at,omitempty" jsonschema:"Timestamp of the last upsert"`
+}
+
+// ProfileUpsertParams defines the arguments for the profile_upsert tool
+type ProfileUpsertParams struct {
+	Profile CandidateProfile `json:"profile" jsonschema:"Full candidate profile; replaces the stored one"`
+}
+
+// ProfileGetParams defines the arguments for the profile_get tool
+type ProfileGetParams struct {
+	CandidateID string `json:"candidate_id" jsonschema:"Candidate identifier"`
+}
+
+type profileTool struct {
+	repo repository.CandidateRepository
+}
+
+// WithProfileTools registers the profile_upsert and profile_get tools
+func WithProfileTools(repo repository.CandidateRepository) Option {
+	return func(reg *registry) {
+		handler := profileTool{repo: repo}
+		AddTool(reg.server, &sdkmcp.Tool{
+			Name:        "profile_upsert",
+			Description: "Create or replace a persisted candidate profile (skills, experience, preferences)",
+		}, handler.handleUpsert, requires(repo, "candidate repository"))
+		AddTool(reg.server, &sdkmcp.Tool{
+			Name:        "profile_get",
+			Description: "Load a persisted candidate profile by candidate_id",
+		}, handler.handleGet, requires(repo, "candidate repository"))
+	}
+}
+
+func RegisterProfileTools(server *ToolServer, repo repository.CandidateRepository) error {
+	Register(server, WithProfileTools(repo))
+	return nil
+}
+
+func (t profileTool) handleUpsert(ctx context.Context, params *ProfileUpsertParams) (*sdkmcp.CallToolResult, any, error) {
+	profile := params.Profile
+	if profile.CandidateID == "" {
+		profile.CandidateID = uuid.NewString()
+	}
+	profile.UpdatedAt = time.Now().UTC()
+
+	candidate := candidateFromProfile(profile)
+	if err := t.repo.UpsertCandidate(ctx, candidate); err != nil {
+		return nil, nil, fmt.Errorf("persist profile: %w", err)
+	}
+
+	result := profileFromCandidate(candidate)
+	annotate(ctx, "candidate_id", result.CandidateID, "skills_count", len(result.Skills))
+	msg := fmt.Sprintf("[profile_upsert] Saved profile %s (%d skill(s), %d experience entr(ies))",
+		result.CandidateID, len(result.Skills), len(result.Experience))
+	return textResult(msg), result, nil
+}
+
+func (t profileTool) handleGet(ctx context.Context, params *ProfileGetParams) (*sdkmcp.CallToolResult, any, error) {
+	if params.CandidateID == "" {
+		return nil, nil, invalidParams("candidate_id is required")
+	}
+
+	candidate, found, err := t.repo.GetCandidate(ctx, params.CandidateID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load profile: %w", err)
+	}
+	if !found {
+		return nil, nil, invalidParams("no profile found for %s", params.CandidateID)
+	}
+
+	result := profileFromCandidate(candidate)
+	msg := fmt.Sprintf("[profile_get] %s — %s\n  Skills: %s\n  Experience entries: %d",
+		result.Name, result.Headline, strings.Join(result.Skills, ", "), len(result.Experience))
+	return textResult(msg), result, nil
+}
+
+func candidateFromProfile(p CandidateProfile) domain.Candidate {
+	skills := make([]domain.SkillRef, 0, len(p.Skills))
+	seen := make(map[string]bool, len(p.Skills))
+	for _, name := range p.Skills {
+		name = strings.TrimSpace(name)
+		id := domain.SkillID(name)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		skills = append(skills, domain.SkillRef{ID: id, Name: name})
+	}
+
+	experience := make([]domain.ExperienceEntry, 0, len(p.Experience))
+	for _, e := range p.Experience {
+		experience = append(experience, domain.ExperienceEntry{
+			Title:       e.Title,
+			Company:     e.Company,
+			Start:       e.Start,
+			End:         e.End,
+			Description: e.Description,
+			Skills:      e.Skills,
+		})
+	}
+
+	return domain.Candidate{
+		ID:         p.CandidateID,
+		Name:       p.Name,
+		Headline:   p.Headline,
+		Summary:    p.Summary,
+		Skills:     skills,
+		Experience: experience,
+		Preferences: domain.CandidatePreferences{
+			Locations:   p.Preferences.Locations,
+			SalaryFloor: p.Preferences.SalaryFloor,
+			Remote:      p.Preferences.Remote,
+		},
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+// profileFromCandidate converts a stored candidate into its tool representation
+func profileFromCandidate(c domain.Candidate) CandidateProfile {
+	skills := make([]string, 0, len(c.Skills))
+	for _, s := range c.Skills {
+		skills = append(skills, s.Name)
+	}
+
+	experience := make([]ExperienceEntry, 0, len(c.Experience))
+	for _, e := range c.Experience {
+		experience = append(experience, ExperienceEntry{
+			Title:       e.Title,
+			Company:     e.Company,
+			Start:       e.Start,
+			End:         e.End,
+			Description: e.Description,
+			Skills:      e.Skills,
+		})
+	}
+
+	return CandidateProfile{
+		CandidateID: c.ID,
+		Name:        c.Name,
+		Headline:    c.Headline,
+		Summary:     c.Summary,
+		Skills:      skills,
+		Experience:  experience,
+		Preferences: ProfilePreferences{
+			Locations:   c.Preferences.Locations,
+			SalaryFloor: c.Preferences.SalaryFloor,
+			Remote:      c.Preferences.Remote,
+		},
+		UpdatedAt: c.UpdatedAt,
+	}
+}
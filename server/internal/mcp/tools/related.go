@@ -0,0 +1,87 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RelatedJobsParams defines the arguments for the related_jobs tool
+type RelatedJobsParams struct {
+	JobID        string  `json:"job_id" jsonschema:"Job identifier stored in Neo4j"`
+	Limit        int     `json:"limit,omitempty" jsonschema:"Maximum number of related jobs to return (default 10)"`
+	MinRelevance float64 `json:"min_relevance,omitempty" jsonschema:"Drop related jobs scoring below this relevance"`
+}
+
+// RelatedJobEntry describes a single related job and why it matched
+type RelatedJobEntry struct {
+	JobID           string   `json:"job_id" jsonschema:"Related job identifier"`
+	Title           string   `json:"title" jsonschema:"Related job title"`
+	Company         string   `json:"company,omitempty" jsonschema:"Related job company"`
+	Location        string   `json:"location,omitempty" jsonschema:"Related job location"`
+	URL             string   `json:"url,omitempty" jsonschema:"Related job URL"`
+	SharedSkills    []string `json:"shared_skills,omitempty" jsonschema:"Skills required by both jobs"`
+	SharedKeywords  []string `json:"shared_keywords,omitempty" jsonschema:"Keywords attached to both jobs"`
+	SameCompany     bool     `json:"same_company" jsonschema:"Whether both jobs are posted by the same company"`
+	TitleSimilarity float64  `json:"title_similarity" jsonschema:"Token overlap between job titles (0-1)"`
+	Relevance       float64  `json:"relevance" jsonschema:"Combined relevance score"`
+}
+
+// RelatedJobsResult is the structured response of related_jobs
+type RelatedJobsResult struct {
+	JobID       string            `json:"job_id" jsonschema:"Source job identifier"`
+	Title       string            `json:"title,omitempty" jsonschema:"Source job title"`
+	Related     []RelatedJobEntry `json:"related" jsonschema:"Related jobs ordered by relevance"`
+	GeneratedAt time.Time         `json:"generated_at" jsonschema:"Timestamp when the lookup completed"`
+}
+
+type relatedJobsTool struct {
+	service AnalysisService
+}
+
+func WithRelatedJobs(service AnalysisService) Option {
+	return func(reg *registry) {
+		handler := relatedJobsTool{service: service}
+		AddTool(reg.server, &sdkmcp.Tool{
+			Name:        "related_jobs",
+			Description: "Find stored jobs related to a job through shared skills, keywords, company and title",
+		}, handler.handle, requires(service, "analysis service"))
+	}
+}
+
+func (t relatedJobsTool) handle(ctx context.Context, params *RelatedJobsParams) (*sdkmcp.CallToolResult, any, error) {
+	if params.JobID == "" {
+		return nil, nil, invalidParams("job_id is required")
+	}
+
+	result, err := t.service.RelatedJobs(ctx, *params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	annotate(ctx, "job_id", result.JobID, "related_count", len(result.Related))
+	return textResult(formatRelatedJobs(result)), result, nil
+}
+
+func formatRelatedJobs(result RelatedJobsResult) string {
+	if len(result.Related) == 0 {
+		return fmt.Sprintf("[related_jobs] No related jobs found for %s", result.JobID)
+	}
+
+	msg := fmt.Sprintf("[related_jobs] %d job(s) related to %q (%s)\n", len(result.Related), result.Title, result.JobID)
+	for _, r := range result.Related {
+		msg += fmt.Sprintf("  • %s | %s at %s (relevance %.2f)\n", r.JobID, r.Title, r.Company, r.Relevance)
+		if len(r.SharedSkills) > 0 {
+			msg += fmt.Sprintf("    shared skills: %v\n", r.SharedSkills)
+		}
+		if len(r.SharedKeywords) > 0 {
+			msg += fmt.Sprintf("    shared keywords: %v\n", r.SharedKeywords)
+		}
+		if r.SameCompany {
+			msg += "    same company\n"
+		}
+	}
+	return msg
+}
@@ -0,0 +1,139 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TodoDigestPrompt is the name of the MCP prompt listing overdue items
+const TodoDigestPrompt = "todo_digest"
+
+// ReminderService computes due follow-ups from application history
+type ReminderService interface {
+	Digest(ctx context.Context, params TodoDigestParams) (TodoDigest, error)
+}
+
+// TodoDigestParams defines the arguments for the todo_digest tool
+type TodoDigestParams struct {
+	CandidateID string `json:"candidate_id,omitempty" jsonschema:"Only this candidate's applications and events (default: all)"`
+	HorizonDays int    `json:"horizon_days,omitempty" jsonschema:"Days ahead to list upcoming interviews and deadlines (default 3)"`
+}
+
+// TodoItem is a task raised by a reminder rule
+type TodoItem struct {
+	Rule         string    `json:"rule" jsonschema:"Rule that raised the task"`
+	Task         string    `json:"task" jsonschema:"What to do"`
+	CandidateID  string    `json:"candidate_id" jsonschema:"Candidate identifier"`
+	JobID        string    `json:"job_id" jsonschema:"Job identifier"`
+	Title        string    `json:"title" jsonschema:"Job title"`
+	Company      string    `json:"company" jsonschema:"Company name"`
+	Status       string    `json:"status" jsonschema:"Application status"`
+	LastActivity time.Time `json:"last_activity" jsonschema:"Last status change or recorded follow-up"`
+	DueAt        time.Time `json:"due_at" jsonschema:"When the task became due"`
+	DaysOverdue  int       `json:"days_overdue" jsonschema:"Whole days since the task became due"`
+}
+
+// TodoDigest is the structured response of todo_digest and the HTTP digest
+type TodoDigest struct {
+	GeneratedAt time.Time  `json:"generated_at" jsonschema:"When the digest was computed"`
+	Overdue     []TodoItem `json:"overdue" jsonschema:"Due tasks, most overdue first"`
+	Upcoming    []Event    `json:"upcoming" jsonschema:"Interviews and deadlines within the horizon"`
+}
+
+type todoDigestTool struct {
+	service ReminderService
+}
+
+// WithTodoDigest registers the todo_digest tool and prompt
+func WithTodoDigest(service ReminderService) Option {
+	return func(reg *registry) {
+		handler := todoDigestTool{service: service}
+		AddTool(reg.server, &sdkmcp.Tool{
+			Name:        "todo_digest",
+			Description: "List overdue application follow-ups and upcoming interviews and deadlines",
+		}, handler.handle, requires(service, "reminder service"))
+		reg.server.MCP().AddPrompt(todoDigestPrompt(), handler.handlePrompt)
+	}
+}
+
+func RegisterReminderTools(server *ToolServer, service ReminderService) error {
+	Register(server, WithTodoDigest(service))
+	return nil
+}
+
+func todoDigestPrompt() *sdkmcp.Prompt {
+	return &sdkmcp.Prompt{
+		Name:        TodoDigestPrompt,
+		Title:       "Overdue job search tasks",
+		Description: "Overdue follow-ups and upcoming interviews and deadlines, with a request to plan the next steps",
+		Arguments: []*sdkmcp.PromptArgument{
+			{Name: "candidate_id", Description: "Candidate whose tasks to list (default: all)"},
+		},
+	}
+}
+
+func (t todoDigestTool) handle(ctx context.Context, params *TodoDigestParams) (*sdkmcp.CallToolResult, any, error) {
+	digest, err := t.digest(ctx, *params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	annotate(ctx, "overdue", len(digest.Overdue), "upcoming", len(digest.Upcoming))
+	return textResult(formatTodoDigest(digest)), digest, nil
+}
+
+func (t todoDigestTool) handlePrompt(ctx context.Context, req *sdkmcp.GetPromptRequest) (*sdkmcp.GetPromptResult, error) {
+	params := TodoDigestParams{}
+	if req != nil && req.Params != nil {
+		params.CandidateID = strings.TrimSpace(req.Params.Arguments["candidate_id"])
+	}
+
+	digest, err := t.digest(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	text := formatTodoDigest(digest) +
+		"\nHelp me work through this list: for each overdue item suggest the next step and draft a short " +
+		"follow-up message where one is needed, then list what to prepare for the upcoming events."
+
+	return &sdkmcp.GetPromptResult{
+		Description: "Overdue job search tasks",
+		Messages: []*sdkmcp.PromptMessage{
+			{Role: "user", Content: &sdkmcp.TextContent{Text: text}},
+		},
+	}, nil
+}
+
+func (t todoDigestTool) digest(ctx context.Context, params TodoDigestParams) (TodoDigest, error) {
+	// The prompt is not behind the tool chain, so it checks the service itself
+	if t.service == nil {
+		return TodoDigest{}, fmt.Errorf("reminder service not configured")
+	}
+	return t.service.Digest(ctx, params)
+}
+
+// formatTodoDigest renders a digest as text
+func formatTodoDigest(d TodoDigest) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("[todo_digest] %d overdue, %d upcoming\n", len(d.Overdue), len(d.Upcoming)))
+	if len(d.Overdue) > 0 {
+		sb.WriteString("\nOverdue:\n")
+		for _, item := range d.Overdue {
+			sb.WriteString(fmt.Sprintf("  • %s: %s at %s (%s since %s, %d day(s) overdue) %s\n",
+				item.Task, item.Title, item.Company, item.Status,
+				item.LastActivity.Format("2006-01-02"), item.DaysOverdue, item.JobID))
+		}
+	}
+	if len(d.Upcoming) > 0 {
+		sb.WriteString("\nUpcoming:\n")
+		for _, event := range d.Upcoming {
+			sb.WriteString("  • " + formatEvent(event) + "\n")
+		}
+	}
+	return sb.String()
+}
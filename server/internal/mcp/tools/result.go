@@ -12,3 +12,13 @@ func textResult(msg string) *sdkmcp.CallToolResult {
 		},
 	}
 }
+
+// errorResult returns a text ToolResult flagged as a tool error
+func errorResult(msg string) *sdkmcp.CallToolResult {
+	return &sdkmcp.CallToolResult{
+		Content: []sdkmcp.Content{
+			&sdkmcp.TextContent{Text: msg},
+		},
+		IsError: true,
+	}
+}
@@ -0,0 +1,63 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/pkg/logging"
+)
+
+// SavedQueriesURI is the MCP resource listing the saved query catalog
+const SavedQueriesURI = "graph://saved-queries"
+
+// SavedQueryCatalog resolves named Cypher templates for graph_tool
+type SavedQueryCatalog interface {
+	List(ctx context.Context) ([]domain.SavedQuery, error)
+	Resolve(ctx context.Context, name string, params map[string]any) (domain.SavedQuery, map[string]any, error)
+}
+
+// RegisterSavedQueryResource exposes the saved query catalog as an MCP resource
+func RegisterSavedQueryResource(server *sdkmcp.Server, catalog SavedQueryCatalog, logger *logging.Logger) error {
+	if catalog == nil {
+		if logger != nil {
+			logger.Warn("saved query catalog not configured, skipping resource")
+		}
+		return nil
+	}
+
+	server.AddResource(&sdkmcp.Resource{
+		URI:         SavedQueriesURI,
+		Name:        "saved_queries",
+		Title:       "Saved graph queries",
+		Description: "Named Cypher templates with typed parameters that graph_tool runs via query_name and params",
+		MIMEType:    "application/json",
+	}, func(ctx context.Context, req *sdkmcp.ReadResourceRequest) (*sdkmcp.ReadResourceResult, error) {
+		queries, err := catalog.List(ctx)
+		if err != nil {
+			if logger != nil {
+				logger.Error("failed to list saved queries", "err", err)
+			}
+			return nil, fmt.Errorf("list saved queries: %w", err)
+		}
+
+		data, err := json.MarshalIndent(queries, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("encode saved queries: %w", err)
+		}
+
+		return &sdkmcp.ReadResourceResult{
+			Contents: []*sdkmcp.ResourceContents{
+				{URI: SavedQueriesURI, MIMEType: "application/json", Text: string(data)},
+			},
+		}, nil
+	})
+
+	if logger != nil {
+		logger.Info("saved query resource registered successfully", "uri", SavedQueriesURI)
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// SavedSearchService manages saved searches and their scheduled runs
+type SavedSearchService interface {
+	SaveSearch(ctx context.Context, params SavedSearchSaveParams) (SavedSearch, error)
+	ListSearches(ctx context.Context) (SavedSearchListResult, error)
+	DeleteSearch(ctx context.Context, searchID string) (bool, error)
+	RunSearch(ctx context.Context, searchID string) (SavedSearchRunResult, error)
+	NewSinceLastRun(ctx context.Context, params NewSinceLastRunParams) (NewSinceLastRunResult, error)
+}
+
+// SavedSearchSaveParams defines the arguments for the saved_search_save tool
+type SavedSearchSaveParams struct {
+	SearchID      string   `json:"search_id,omitempty" jsonschema:"Saved search to update; omitted to create one"`
+	Name          string   `json:"name,omitempty" jsonschema:"Display name (default: the query)"`
+	Query         string   `json:"query" jsonschema:"Job search query, as for job_search"`
+	Location      string   `json:"location,omitempty" jsonschema:"Preferred location filter"`
+	Remote        *bool    `json:"remote,omitempty" jsonschema:"Whether to restrict to remote postings"`
+	Skills        []string `json:"skills,omitempty" jsonschema:"List of required skills"`
+	IncludeClosed bool     `json:"include_closed,omitempty" jsonschema:"Also return postings already known to be closed"`
+	Schedule      string   `json:"schedule,omitempty" jsonschema:"@hourly, @daily, @weekly or a duration such as 6h (default @daily, minimum 15m)"`
+	Enabled       *bool    `json:"enabled,omitempty" jsonschema:"Whether the scheduler runs the search (default true)"`
+	Notify        []string `json:"notify,omitempty" jsonschema:"Notification channels to announce new jobs on; needs the admin scope (default: the configured default channels when authentication is off)"`
+}
+
+// SavedSearchIDParams identifies a saved search
+type SavedSearchIDParams struct {
+	SearchID string `json:"search_id" jsonschema:"Saved search identifier"`
+}
+
+// NewSinceLastRunParams defines the arguments for the new_since_last_run tool
+type NewSinceLastRunParams struct {
+	SearchID string `json:"search_id" jsonschema:"Saved search identifier"`
+	Limit    int    `json:"limit,omitempty" jsonschema:"Maximum jobs to return (default 50)"`
+}
+
+// SavedSearch is a job search re-run on a schedule
+type SavedSearch struct {
+	ID            string     `json:"id" jsonschema:"Saved search identifier"`
+	Name          string     `json:"name" jsonschema:"Display name"`
+	Query         string     `json:"query" jsonschema:"Job search query"`
+	Location      string     `json:"location,omitempty" jsonschema:"Location filter"`
+	Remote        *bool      `json:"remote,omitempty" jsonschema:"Remote filter"`
+	Skills        []string   `json:"skills,omitempty" jsonschema:"Required skills"`
+	IncludeClosed bool       `json:"include_closed,omitempty" jsonschema:"Whether closed postings are included"`
+	Schedule      string     `json:"schedule" jsonschema:"Run schedule"`
+	Enabled       bool       `json:"enabled" jsonschema:"Whether the scheduler runs the search"`
+	Notify        []string   `json:"notify,omitempty" jsonschema:"Notification channels new jobs are announced on"`
+	NextRunAt     time.Time  `json:"next_run_at" jsonschema:"When the next scheduled run is due"`
+	LastRunAt     *time.Time `json:"last_run_at,omitempty" jsonschema:"When the latest successful run started"`
+	LastRunStatus string     `json:"last_run_status,omitempty" jsonschema:"ok or failed"`
+	LastError     string     `json:"last_error,omitempty" jsonschema:"Error of the latest run when it failed"`
+	LastFound     int        `json:"last_found" jsonschema:"Jobs returned by the latest successful run"`
+	LastNew       int        `json:"last_new" jsonschema:"Jobs the latest successful run found for the first time"`
+}
+
+// SavedSearchListResult is the structured response of saved_search_list
+type SavedSearchListResult struct {
+	Searches []SavedSearch `json:"searches" jsonschema:"Saved searches, by name"`
+}
+
+// SavedSearchRunResult is the structured response of saved_search_run
+type SavedSearchRunResult struct {
+	Search SavedSearch    `json:"search" jsonschema:"Saved search after the run"`
+	Found  int            `json:"found" jsonschema:"Jobs returned by the run"`
+	New    []JobSearchJob `json:"new" jsonschema:"Jobs the run found for the first time"`
+}
+
+// NewSinceLastRunResult is the structured response of new_since_last_run
+type NewSinceLastRunResult struct {
+	Search SavedSearch    `json:"search" jsonschema:"Saved search"`
+	Jobs   []JobSearchJob `json:"jobs" jsonschema:"Jobs first found by the latest successful run, newest first"`
+}
+
+type savedSearchTool struct {
+	service SavedSearchService
+}
+
+// WithSavedSearchTools registers the saved search tools
+func WithSavedSearchTools(service SavedSearchService) Option {
+	return func(reg *registry) {
+		savedSearchTool{service: service}.register(reg.server)
+	}
+}
+
+func RegisterSavedSearchTools(server *ToolServer, service SavedSearchService) error {
+	Register(server, WithSavedSearchTools(service))
+	return nil
+}
+
+func (t savedSearchTool) register(server *ToolServer) {
+	dep := requires(t.service, "saved search service")
+	AddTool(server, &sdkmcp.Tool{
+		Name:        "saved_search_save",
+		Description: "Create or update a job search that the server re-runs on a schedule",
+	}, t.handleSave, dep)
+	AddTool(server, &sdkmcp.Tool{
+		Name:        "saved_search_list",
+		Description: "List saved searches with their schedule and latest run",
+	}, t.handleList, dep)
+	AddTool(server, &sdkmcp.Tool{
+		Name:        "saved_search_delete",
+		Description: "Delete a saved search",
+	}, t.handleDelete, dep)
+	AddTool(server, &sdkmcp.Tool{
+		Name:        "saved_search_run",
+		Description: "Run a saved search now instead of waiting for its schedule",
+	}, t.handleRun, dep)
+	AddTool(server, &sdkmcp.Tool{
+		Name:        "new_since_last_run",
+		Description: "List the jobs a saved search found for the first time in its latest run",
+	}, t.handleNew, dep)
+}
+
+func (t savedSearchTool) handleSave(ctx context.Context, params *SavedSearchSaveParams) (*sdkmcp.CallToolResult, any, error) {
+	if strings.TrimSpace(params.Query) == "" {
+		return nil, nil, invalidParams("query is required")
+	}
+
+	search, err := t.service.SaveSearch(ctx, *params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	annotate(ctx, "search_id", search.ID)
+	return textResult("[saved_search_save] " + formatSavedSearch(search)), search, nil
+}
+
+func (t savedSearchTool) handleList(ctx context.Context, _ *struct{}) (*sdkmcp.CallToolResult, any, error) {
+	result, err := t.service.ListSearches(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	annotate(ctx, "searches", len(result.Searches))
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("[saved_search_list] %d saved search(es)\n", len(result.Searches)))
+	for _, s := range result.Searches {
+		sb.WriteString("  • " + formatSavedSearch(s) + "\n")
+	}
+	return textResult(sb.String()), result, nil
+}
+
+func (t savedSearchTool) handleDelete(ctx context.Context, params *SavedSearchIDParams) (*sdkmcp.CallToolResult, any, error) {
+	if strings.TrimSpace(params.SearchID) == "" {
+		return nil, nil, invalidParams("search_id is required")
+	}
+
+	deleted, err := t.service.DeleteSearch(ctx, params.SearchID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !deleted {
+		return nil, nil, invalidParams("saved search %s not found", params.SearchID)
+	}
+
+	annotate(ctx, "search_id", params.SearchID)
+	return textResult(fmt.Sprintf("[saved_search_delete] deleted %s", params.SearchID)), nil, nil
+}
+
+func (t savedSearchTool) handleRun(ctx context.Context, params *SavedSearchIDParams) (*sdkmcp.CallToolResult, any, error) {
+	if strings.TrimSpace(params.SearchID) == "" {
+		return nil, nil, invalidParams("search_id is required")
+	}
+
+	result, err := t.service.RunSearch(ctx, params.SearchID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	annotate(ctx, "search_id", params.SearchID, "found", result.Found, "new", len(result.New))
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("[saved_search_run] %s: %d found, %d new\n", result.Search.Name, result.Found, len(result.New)))
+	for _, j := range result.New {
+		sb.WriteString("  • " + formatSavedSearchJob(j) + "\n")
+	}
+	return textResult(sb.String()), result, nil
+}
+
+func (t savedSearchTool) handleNew(ctx context.Context, params *NewSinceLastRunParams) (*sdkmcp.CallToolResult, any, error) {
+	if strings.TrimSpace(params.SearchID) == "" {
+		return nil, nil, invalidParams("search_id is required")
+	}
+
+	result, err := t.service.NewSinceLastRun(ctx, *params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	annotate(ctx, "search_id", params.SearchID, "jobs", len(result.Jobs))
+
+	var sb strings.Builder
+	if result.Search.LastRunAt == nil {
+		sb.WriteString(fmt.Sprintf("[new_since_last_run] %s has not run yet\n", result.Search.Name))
+	} else {
+		sb.WriteString(fmt.Sprintf("[new_since_last_run] %s: %d new job(s) since %s\n",
+			result.Search.Name, len(result.Jobs), result.Search.LastRunAt.Format(time.RFC3339)))
+	}
+	for _, j := range result.Jobs {
+		sb.WriteString("  • " + formatSavedSearchJob(j) + "\n")
+	}
+	return textResult(sb.String()), result, nil
+}
+
+func formatSavedSearch(s SavedSearch) string {
+	state := "enabled"
+	if !s.Enabled {
+		state = "disabled"
+	}
+	text := fmt.Sprintf("%s (%q, %s, %s, next run %s)", s.Name, s.Query, s.Schedule, state, s.NextRunAt.Format(time.RFC3339))
+	if len(s.Notify) > 0 {
+		text += ", notifies " + strings.Join(s.Notify, ", ")
+	}
+	if s.LastRunAt != nil {
+		text += fmt.Sprintf(", last run %s: %d found, %d new", s.LastRunAt.Format(time.RFC3339), s.LastFound, s.LastNew)
+	}
+	if s.LastRunStatus == "failed" && s.LastError != "" {
+		text += ", last attempt failed: " + s.LastError
+	}
+	return text + " " + s.ID
+}
+
+func formatSavedSearchJob(j JobSearchJob) string {
+	text := j.Title
+	if j.Company != "" {
+		text += " at " + j.Company
+	}
+	if j.Location != "" {
+		text += " (" + j.Location + ")"
+	}
+	return text + " " + j.ID
+}
@@ -0,0 +1,98 @@
+package tools
+
+import "strings"
+
+// Scopes granted to API keys
+const (
+	ScopeRead  = "read"  // query stored jobs, profiles and reports
+	ScopeWrite = "write" // fetch from Adzuna, change stored data, export to Sheets
+	ScopeGraph = "graph" // run Cypher and inspect the graph through graph_tool
+	ScopeAdmin = "admin" // inspect server-wide usage counters and run free-form Cypher
+)
+
+// toolScopes maps each tool to the scope needed to call it. Tools missing
+// from the map need ScopeWrite, so a new tool is never exposed by accident
+var toolScopes = map[string]string{
+	"application_list":    ScopeRead,
+	"contact_search":      ScopeRead,
+	"employer_profile":    ScopeRead,
+	"event_list":          ScopeRead,
+	"ghost_report":        ScopeRead,
+	"job_analysis":        ScopeRead,
+	"job_history":         ScopeRead,
+	"keyword_gap_report":  ScopeRead,
+	"new_since_last_run":  ScopeRead,
+	"profile_get":         ScopeRead,
+	"related_jobs":        ScopeRead,
+	"saved_search_list":   ScopeRead,
+	"skill_insights":      ScopeRead,
+	"todo_digest":         ScopeRead,
+	"application_update":  ScopeWrite,
+	"company_merge":       ScopeWrite,
+	"contact_add":         ScopeWrite,
+	"event_add":           ScopeWrite,
+	"job_recheck":         ScopeWrite,
+	"job_search":          ScopeWrite,
+	"persist_keywords":    ScopeWrite,
+	"profile_upsert":      ScopeWrite,
+	"saved_search_delete": ScopeWrite,
+	"saved_search_run":    ScopeWrite,
+	"saved_search_save":   ScopeWrite,
+	"sheets_export":       ScopeWrite,
+	"graph_tool":          ScopeGraph,
+	"usage_report":        ScopeAdmin,
+}
+
+// ToolScope returns the scope needed to call a tool
+func ToolScope(name string) string {
+	if scope, ok := toolScopes[name]; ok {
+		return scope
+	}
+	return ScopeWrite
+}
+
+// CallScopes returns the scopes needed for one call of a tool with params.
+// Free-form Cypher in graph_tool is not scoped to the caller's data, so it
+// also needs ScopeAdmin; saved queries and the other modes only need
+// ScopeGraph. Notification channels are server-wide destinations, so
+// routing a saved search to them needs ScopeAdmin too
+func CallScopes(name string, params any) []string {
+	scopes := []string{ToolScope(name)}
+	switch p := params.(type) {
+	case *GraphToolParams:
+		if strings.TrimSpace(p.Cypher) != "" {
+			scopes = append(scopes, ScopeAdmin)
+		}
+	case *SavedSearchSaveParams:
+		if routesNotifications(p.Notify) {
+			scopes = append(scopes, ScopeAdmin)
+		}
+	}
+	return scopes
+}
+
+func routesNotifications(channels []string) bool {
+	for _, name := range channels {
+		if strings.TrimSpace(name) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// ResourceScope returns the scope needed to read a resource
+func ResourceScope(uri string) string {
+	if strings.HasPrefix(uri, "graph://") {
+		return ScopeGraph
+	}
+	return ScopeRead
+}
+
+// ValidScope reports whether scope is one of the known scopes
+func ValidScope(scope string) bool {
+	switch scope {
+	case ScopeRead, ScopeWrite, ScopeGraph, ScopeAdmin:
+		return true
+	}
+	return false
+}
@@ -2,7 +2,6 @@ package tools
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"time"
 
@@ -10,30 +9,31 @@ import (
 
 	"github.com/honeycarbs/project-ets/internal/domain"
 	"github.com/honeycarbs/project-ets/internal/domain/job"
-	"github.com/honeycarbs/project-ets/pkg/logging"
 )
 
 // JobSearchParams defines the arguments for the job_search tool
 type JobSearchParams struct {
-	Query    string   `json:"query" jsonschema:"Natural language job search query"`
-	Location string   `json:"location,omitempty" jsonschema:"Preferred location filter"`
-	Remote   *bool    `json:"remote,omitempty" jsonschema:"Whether to restrict to remote postings"`
-	Skills   []string `json:"skills,omitempty" jsonschema:"List of required skills"`
+	Query         string   `json:"query" jsonschema:"Natural language job search query"`
+	Location      string   `json:"location,omitempty" jsonschema:"Preferred location filter"`
+	Remote        *bool    `json:"remote,omitempty" jsonschema:"Whether to restrict to remote postings"`
+	Skills        []string `json:"skills,omitempty" jsonschema:"List of required skills"`
+	IncludeClosed bool     `json:"include_closed,omitempty" jsonschema:"Also return postings already known to be closed"`
 }
 
 // JobSearchJob represents a normalized job returned to the client
 type JobSearchJob struct {
-	ID          string    `json:"id" jsonschema:"Canonical job identifier"`
-	Title       string    `json:"title" jsonschema:"Job title"`
-	Company     string    `json:"company" jsonschema:"Company or employer name"`
-	Location    string    `json:"location" jsonschema:"Primary listed location"`
-	Remote      bool      `json:"remote" jsonschema:"Whether the role is remote-friendly"`
-	URL         string    `json:"url,omitempty" jsonschema:"Direct application URL"`
-	Source      string    `json:"source,omitempty" jsonschema:"Originating provider e.g. linkedin"`
-	Score       float64   `json:"score,omitempty" jsonschema:"Relevance or ranking score"`
-	Description string    `json:"description,omitempty" jsonschema:"Canonical job description text"`
-	Skills      []string  `json:"skills,omitempty" jsonschema:"Parsed/normalized skill tags"`
-	FetchedAt   time.Time `json:"fetched_at" jsonschema:"Timestamp the job was fetched"`
+	ID          string           `json:"id" jsonschema:"Canonical job identifier"`
+	Title       string           `json:"title" jsonschema:"Job title"`
+	Company     string           `json:"company" jsonschema:"Company or employer name"`
+	Location    string           `json:"location" jsonschema:"Primary listed location"`
+	Remote      bool             `json:"remote" jsonschema:"Whether the role is remote-friendly"`
+	URL         string           `json:"url,omitempty" jsonschema:"Direct application URL"`
+	Source      string           `json:"source,omitempty" jsonschema:"Originating provider e.g. linkedin"`
+	Score       float64          `json:"score,omitempty" jsonschema:"Relevance or ranking score"`
+	Description string           `json:"description,omitempty" jsonschema:"Canonical job description text"`
+	Skills      []string         `json:"skills,omitempty" jsonschema:"Parsed/normalized skill tags"`
+	FetchedAt   time.Time        `json:"fetched_at" jsonschema:"Timestamp the job was fetched"`
+	Ghost       *GhostAssessment `json:"ghost,omitempty" jsonschema:"Ghost-posting likelihood, present when ghost detection is configured"`
 }
 
 // JobSearchResult contains the result payload for job_search
@@ -45,117 +45,48 @@ type JobSearchResult struct {
 
 type jobSearchTool struct {
 	service job.Service
-	logger  *logging.Logger
+	ghosts  GhostService
 }
 
 // WithJobSearch registers the job_search tool with the provided service
-func WithJobSearch(service job.Service, logger *logging.Logger) Option {
+func WithJobSearch(service job.Service, ghosts GhostService) Option {
 	return func(reg *registry) {
 		handler := jobSearchTool{
 			service: service,
-			logger:  logger,
+			ghosts:  ghosts,
 		}
-		sdkmcp.AddTool(reg.server, &sdkmcp.Tool{
+		AddTool(reg.server, &sdkmcp.Tool{
 			Name:        "job_search",
 			Description: "Search external job boards/APIs, normalize, and store job postings",
-		}, handler.handle)
+		}, handler.handle, requires(service, "job service"))
 	}
 }
 
-func RegisterJobTools(server *sdkmcp.Server, jobSvc job.Service, logger *logging.Logger) error {
-	handler := jobSearchTool{
-		service: jobSvc,
-		logger:  logger,
-	}
-	sdkmcp.AddTool(server, &sdkmcp.Tool{
-		Name:        "job_search",
-		Description: "Search external job boards/APIs, normalize, and store job postings",
-	}, handler.handle)
-	if logger != nil {
-		logger.Info("job_search tool registered successfully")
-	}
+func RegisterJobTools(server *ToolServer, jobSvc job.Service, ghosts GhostService) error {
+	Register(server, WithJobSearch(jobSvc, ghosts))
 	return nil
 }
 
-func (t jobSearchTool) handle(ctx context.Context, req *sdkmcp.CallToolRequest, params *JobSearchParams) (*sdkmcp.CallToolResult, any, error) {
-	if t.logger != nil {
-		t.logger.Debug("job_search called")
-	}
-
-	query := ""
-	location := ""
-	var skills []string
-	var remote *bool
-	if params != nil {
-		query = params.Query
-		location = params.Location
-		skills = params.Skills
-		remote = params.Remote
-	}
-
-	if t.service == nil {
-		msg := "job_search unavailable: no job service is configured"
-		if t.logger != nil {
-			t.logger.Warn("job_search: service not available")
-		}
-		return textResult(msg), JobSearchResult{}, errors.New(msg)
-	}
-
-	if t.logger != nil {
-		t.logger.Info("job_search request",
-			"query", query,
-			"location", location,
-			"skills", skills,
-			"remote", remote,
-		)
-	}
-
-	if query == "" {
-		err := fmt.Errorf("job_search: query is required")
-		if t.logger != nil {
-			t.logger.Warn("job_search: missing required query parameter")
-		}
-		return textResult("job_search requires a non-empty query"), JobSearchResult{}, err
+func (t jobSearchTool) handle(ctx context.Context, params *JobSearchParams) (*sdkmcp.CallToolResult, any, error) {
+	if params.Query == "" {
+		return nil, nil, invalidParams("query is required")
 	}
 
 	filters := domain.JobSearchFilters{
-		Location: location,
-		Remote:   nil,
-		Skills:   skills,
-	}
-	if params != nil {
-		filters.Remote = params.Remote
+		Location:      params.Location,
+		Remote:        params.Remote,
+		Skills:        params.Skills,
+		IncludeClosed: params.IncludeClosed,
 	}
 
-	if t.logger != nil {
-		t.logger.Debug("job_search: calling service.Search",
-			"filters", fmt.Sprintf("%+v", filters),
-		)
-	}
-
-	serviceResult, err := t.service.Search(ctx, query, filters)
+	serviceResult, err := t.service.Search(ctx, params.Query, filters)
 	if err != nil {
-		if t.logger != nil {
-			t.logger.Error("job_search: service failure",
-				"err", err,
-				"query", query,
-				"filters", fmt.Sprintf("%+v", filters),
-			)
-		}
-		return textResult(fmt.Sprintf("job_search failed: %v", err)), JobSearchResult{}, err
-	}
-
-	if t.logger != nil {
-		t.logger.Debug("job_search: service returned results",
-			"jobs_count", len(serviceResult.Jobs),
-			"source_count", serviceResult.SourceCount,
-			"fetched_at", serviceResult.FetchedAt,
-		)
+		return nil, nil, err
 	}
 
 	jobs := make([]JobSearchJob, 0, len(serviceResult.Jobs))
-	for i, summary := range serviceResult.Jobs {
-		job := JobSearchJob{
+	for _, summary := range serviceResult.Jobs {
+		jobs = append(jobs, JobSearchJob{
 			ID:        summary.ID.String(),
 			Title:     summary.Title,
 			Company:   summary.Company,
@@ -165,37 +96,53 @@ func (t jobSearchTool) handle(ctx context.Context, req *sdkmcp.CallToolRequest,
 			Source:    summary.Source,
 			Score:     summary.Score,
 			FetchedAt: serviceResult.FetchedAt,
-		}
-		jobs = append(jobs, job)
-		
-		if t.logger != nil {
-			t.logger.Debug("job_search: job processed",
-				"index", i,
-				"job_id", job.ID,
-				"title", job.Title,
-				"company", job.Company,
-				"source", job.Source,
-			)
-		}
+		})
 	}
 
+	t.attachGhostScores(ctx, jobs)
+
 	result := JobSearchResult{
 		Jobs:        jobs,
 		FetchedAt:   serviceResult.FetchedAt,
 		SourceCount: serviceResult.SourceCount,
 	}
 
-	if t.logger != nil {
-		t.logger.Info("job_search completed successfully",
-			"jobs_count", len(jobs),
-			"sources", serviceResult.SourceCount,
-			"fetched_at", serviceResult.FetchedAt,
-		)
-	}
+	annotate(ctx, "jobs_count", len(jobs), "sources", serviceResult.SourceCount)
 
 	msg := fmt.Sprintf("[job_search] fetched %d job(s) from %d source(s)\n", len(jobs), serviceResult.SourceCount)
 	for _, j := range jobs {
 		msg += fmt.Sprintf("  • %s | %s at %s [%s]\n", j.ID, j.Title, j.Company, j.Location)
+		if j.Ghost != nil && j.Ghost.Level != GhostLevelLow {
+			msg += "    " + formatGhost(j.Ghost) + "\n"
+		}
 	}
 	return textResult(msg), result, nil
 }
+
+// attachGhostScores links reposts among the freshly stored jobs and scores
+// them. Failures are logged only; search results are still returned
+func (t jobSearchTool) attachGhostScores(ctx context.Context, jobs []JobSearchJob) {
+	if t.ghosts == nil || len(jobs) == 0 {
+		return
+	}
+
+	ids := make([]string, 0, len(jobs))
+	for _, j := range jobs {
+		ids = append(ids, j.ID)
+	}
+
+	if _, err := t.ghosts.DetectReposts(ctx, ids); err != nil {
+		callLogger(ctx).Warn("repost detection failed", "err", err)
+	}
+
+	assessments, err := t.ghosts.Assess(ctx, ids)
+	if err != nil {
+		callLogger(ctx).Warn("ghost scoring failed", "err", err)
+		return
+	}
+	for i := range jobs {
+		if a, ok := assessments[jobs[i].ID]; ok {
+			jobs[i].Ghost = &a
+		}
+	}
+}
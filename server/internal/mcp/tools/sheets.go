@@ -11,7 +11,6 @@ import (
 
 	"github.com/honeycarbs/project-ets/internal/domain"
 	"github.com/honeycarbs/project-ets/internal/repository"
-	"github.com/honeycarbs/project-ets/pkg/logging"
 )
 
 // SheetRow defines a row to upsert into Sheets
@@ -33,12 +32,13 @@ type SheetsClient interface {
 
 // SheetsExportParams defines the arguments for the sheets_export tool
 type SheetsExportParams struct {
-	JobIDs   []string          `json:"job_ids,omitempty" jsonschema:"Jobs to rehydrate from storage"`
-	Rows     []SheetRow        `json:"rows,omitempty" jsonschema:"Explicit rows to write when not rehydrating"`
-	Filter   map[string]string `json:"filter,omitempty" jsonschema:"Optional filter tags applied server-side"`
-	Upsert   bool              `json:"upsert,omitempty" jsonschema:"Whether to upsert (true) or append (false)"`
-	ClearTab bool              `json:"clear_tab,omitempty" jsonschema:"If true, clears the tab before writing"`
-	Sheet    struct {
+	JobIDs        []string          `json:"job_ids,omitempty" jsonschema:"Jobs to rehydrate from storage"`
+	Rows          []SheetRow        `json:"rows,omitempty" jsonschema:"Explicit rows to write when not rehydrating"`
+	Filter        map[string]string `json:"filter,omitempty" jsonschema:"Optional filter tags applied server-side"`
+	Upsert        bool              `json:"upsert,omitempty" jsonschema:"Whether to upsert (true) or append (false)"`
+	ClearTab      bool              `json:"clear_tab,omitempty" jsonschema:"If true, clears the tab before writing"`
+	IncludeClosed bool              `json:"include_closed,omitempty" jsonschema:"When rehydrating job_ids, also export jobs whose posting is closed"`
+	Sheet         struct {
 		SpreadsheetID string `json:"spreadsheet_id" jsonschema:"Google Sheets document ID"`
 		Tab           string `json:"tab,omitempty" jsonschema:"Tab name to upsert data"`
 		Range         string `json:"range,omitempty" jsonschema:"Optional A1 range override"`
@@ -59,84 +59,49 @@ type SheetsExportResult struct {
 type sheetsExportTool struct {
 	client SheetsClient
 	repo   repository.JobRepository
-	logger *logging.Logger
 }
 
-// WithSheetsExport registers the sheets_export tool
-func WithSheetsExport(client SheetsClient, logger *logging.Logger) Option {
+// WithSheetsExport registers the sheets_export tool. repo hydrates rows
+// from job IDs and may be nil when only rows are exported
+func WithSheetsExport(client SheetsClient, repo repository.JobRepository) Option {
 	return func(reg *registry) {
-		handler := sheetsExportTool{client: client, logger: logger}
-		sdkmcp.AddTool(reg.server, &sdkmcp.Tool{
+		handler := sheetsExportTool{client: client, repo: repo}
+		AddTool(reg.server, &sdkmcp.Tool{
 			Name:        "sheets_export",
 			Description: "Export job selections to Google Sheets via the sheets_client integrations",
-		}, handler.handle)
+		}, handler.handle, requires(client, "sheets client"))
 	}
 }
 
-func RegisterExportTools(server *sdkmcp.Server, client SheetsClient, repo repository.JobRepository, logger *logging.Logger) error {
-	handler := sheetsExportTool{client: client, repo: repo, logger: logger}
-	sdkmcp.AddTool(server, &sdkmcp.Tool{
-		Name:        "sheets_export",
-		Description: "Export job selections to Google Sheets via the sheets_client integrations",
-	}, handler.handle)
-	if logger != nil {
-		logger.Info("sheets_export tool registered successfully")
-	}
+func RegisterExportTools(server *ToolServer, client SheetsClient, repo repository.JobRepository) error {
+	Register(server, WithSheetsExport(client, repo))
 	return nil
 }
 
-func (t sheetsExportTool) handle(ctx context.Context, req *sdkmcp.CallToolRequest, params *SheetsExportParams) (*sdkmcp.CallToolResult, any, error) {
-	if t.logger != nil {
-		t.logger.Debug("sheets_export called")
-	}
-
-	if params == nil {
-		t.logWarn("sheets_export: parameters are required")
-		return textResult("sheets_export: parameters are required"), SheetsExportResult{}, fmt.Errorf("parameters are required")
-	}
-
+func (t sheetsExportTool) handle(ctx context.Context, params *SheetsExportParams) (*sdkmcp.CallToolResult, any, error) {
 	if params.Sheet.SpreadsheetID == "" {
-		t.logWarn("sheets_export: spreadsheet_id is required")
-		return textResult("sheets_export: spreadsheet_id is required"), SheetsExportResult{}, fmt.Errorf("spreadsheet_id is required")
+		return nil, nil, invalidParams("spreadsheet_id is required")
 	}
 
-	t.logInfo("sheets_export start",
-		"spreadsheet_id", params.Sheet.SpreadsheetID,
-		"tab", params.Sheet.Tab,
-		"job_ids", len(params.JobIDs),
-		"rows", len(params.Rows),
-		"upsert", params.Upsert,
-		"clear_tab", params.ClearTab,
-	)
-
 	var rows []SheetRow
 	var mode string
 
 	if len(params.JobIDs) > 0 {
-		t.logInfo("sheets_export hydrate_jobs", "job_ids", params.JobIDs)
-		jobRows, err := t.fetchJobsAsRows(ctx, params.JobIDs, params.Filter)
+		jobRows, err := t.fetchJobsAsRows(ctx, params.JobIDs, params.Filter, params.IncludeClosed)
 		if err != nil {
-			t.logError("sheets_export fetchJobsAsRows failed", "err", err)
-			return textResult(fmt.Sprintf("sheets_export: failed to fetch jobs: %v", err)), SheetsExportResult{}, err
+			return nil, nil, err
 		}
 		rows = jobRows
 		mode = "hydrate_jobs"
 	} else if len(params.Rows) > 0 {
-		t.logInfo("sheets_export append_rows", "provided_rows", len(params.Rows))
 		rows = params.Rows
 		mode = "append_rows"
 	} else {
-		t.logWarn("sheets_export: either job_ids or rows must be provided")
-		return textResult("sheets_export: either job_ids or rows must be provided"), SheetsExportResult{}, fmt.Errorf("either job_ids or rows must be provided")
-	}
-
-	if len(rows) == 0 && mode == "append_rows" {
-		t.logWarn("sheets_export: received empty rows in append mode", "provided_rows", len(params.Rows))
-		return textResult(fmt.Sprintf("sheets_export: received %d rows in params (expected > 0)", len(params.Rows))), SheetsExportResult{}, fmt.Errorf("no rows to export")
+		return nil, nil, invalidParams("either job_ids or rows must be provided")
 	}
 
 	if len(rows) == 0 {
-		t.logWarn("sheets_export: no rows after hydration", "mode", mode)
+		annotate(ctx, "mode", "noop")
 		result := SheetsExportResult{
 			SpreadsheetID: params.Sheet.SpreadsheetID,
 			Tab:           params.Sheet.Tab,
@@ -149,19 +114,19 @@ func (t sheetsExportTool) handle(ctx context.Context, req *sdkmcp.CallToolReques
 	}
 
 	exportParams := SheetsExportParams{
-		JobIDs:   params.JobIDs,
-		Rows:     rows,
-		Filter:   params.Filter,
-		Upsert:   params.Upsert,
-		ClearTab: params.ClearTab,
-		Sheet:    params.Sheet,
-		Metadata: params.Metadata,
+		JobIDs:        params.JobIDs,
+		Rows:          rows,
+		Filter:        params.Filter,
+		Upsert:        params.Upsert,
+		ClearTab:      params.ClearTab,
+		IncludeClosed: params.IncludeClosed,
+		Sheet:         params.Sheet,
+		Metadata:      params.Metadata,
 	}
 
 	result, err := t.client.Export(ctx, exportParams)
 	if err != nil {
-		t.logError("sheets_export: export failed", "err", err)
-		return textResult(fmt.Sprintf("sheets_export: export failed: %v", err)), SheetsExportResult{}, err
+		return nil, nil, fmt.Errorf("export failed: %w", err)
 	}
 
 	result.Mode = mode
@@ -175,48 +140,41 @@ func (t sheetsExportTool) handle(ctx context.Context, req *sdkmcp.CallToolReques
 		result.Tab = params.Sheet.Tab
 	}
 
-	t.logInfo("sheets_export complete",
-		"mode", result.Mode,
-		"written_rows", result.WrittenRows,
-		"spreadsheet_id", result.SpreadsheetID,
-		"tab", result.Tab,
-	)
+	annotate(ctx, "mode", result.Mode, "written_rows", result.WrittenRows, "spreadsheet_id", result.SpreadsheetID, "tab", result.Tab)
 
 	msg := fmt.Sprintf("[sheets_export] Exported %d row(s) to spreadsheet %q (tab: %q, mode: %s)", result.WrittenRows, result.SpreadsheetID, result.Tab, result.Mode)
 	return textResult(msg), result, nil
 }
 
-func (t sheetsExportTool) fetchJobsAsRows(ctx context.Context, jobIDs []string, filter map[string]string) ([]SheetRow, error) {
+func (t sheetsExportTool) fetchJobsAsRows(ctx context.Context, jobIDs []string, filter map[string]string, includeClosed bool) ([]SheetRow, error) {
 	if t.repo == nil {
-		t.logWarn("sheets_export: job repository not available")
-		return nil, fmt.Errorf("job repository not available")
+		return nil, fmt.Errorf("job repository not configured")
 	}
 
 	ids := make([]domain.JobID, 0, len(jobIDs))
 	for _, idStr := range jobIDs {
 		id, err := uuid.Parse(idStr)
 		if err != nil {
-			t.logWarn("sheets_export: skipping invalid job id", "job_id", idStr)
+			callLogger(ctx).Warn("skipping invalid job id", "job_id", idStr)
 			continue
 		}
 		ids = append(ids, id)
 	}
 
 	if len(ids) == 0 {
-		t.logWarn("sheets_export: no valid job IDs provided", "job_ids", jobIDs)
-		return nil, fmt.Errorf("no valid job IDs provided")
+		return nil, invalidParams("no valid job IDs provided")
 	}
 
-	t.logInfo("sheets_export: fetching jobs", "count", len(ids))
 	jobs, err := t.repo.FindByIDs(ctx, ids)
 	if err != nil {
-		t.logError("sheets_export: failed to fetch jobs", "err", err)
 		return nil, fmt.Errorf("failed to fetch jobs: %w", err)
 	}
 
-	t.logInfo("sheets_export: jobs fetched", "jobs", len(jobs))
 	rows := make([]SheetRow, 0, len(jobs))
 	for _, job := range jobs {
+		if job.Closed() && !includeClosed {
+			continue
+		}
 		if !t.matchesFilter(job, filter) {
 			continue
 		}
@@ -273,21 +231,3 @@ func (t sheetsExportTool) matchesFilter(job domain.Job, filter map[string]string
 
 	return true
 }
-
-func (t sheetsExportTool) logInfo(msg string, args ...any) {
-	if t.logger != nil {
-		t.logger.Info(msg, args...)
-	}
-}
-
-func (t sheetsExportTool) logWarn(msg string, args ...any) {
-	if t.logger != nil {
-		t.logger.Warn(msg, args...)
-	}
-}
-
-func (t sheetsExportTool) logError(msg string, args ...any) {
-	if t.logger != nil {
-		t.logger.Error(msg, args...)
-	}
-}
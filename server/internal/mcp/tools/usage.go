@@ -0,0 +1,135 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/honeycarbs/project-ets/pkg/ratelimit"
+)
+
+// UsageReporter exposes the per-client tool call counters of the rate limiter
+type UsageReporter interface {
+	Usage(client string) []ratelimit.Usage
+}
+
+// UsageReportParams defines the arguments for the usage_report tool
+type UsageReportParams struct {
+	Client string `json:"client,omitempty" jsonschema:"Client name (API key name or token subject) or user ID; all clients when omitted"`
+}
+
+// ToolUsage counts one client's calls of one tool
+type ToolUsage struct {
+	Tool       string    `json:"tool" jsonschema:"Tool name"`
+	Allowed    int64     `json:"allowed" jsonschema:"Calls let through since the server started"`
+	Limited    int64     `json:"limited" jsonschema:"Calls refused since the server started"`
+	Today      int       `json:"today" jsonschema:"Calls counted against today's quota (UTC)"`
+	DailyQuota int       `json:"daily_quota,omitempty" jsonschema:"Calls allowed per UTC day; omitted when unlimited"`
+	LastCall   time.Time `json:"last_call" jsonschema:"Last call, allowed or not"`
+}
+
+// ClientUsage is the usage of one client, in total and per tool
+type ClientUsage struct {
+	Client string      `json:"client" jsonschema:"User ID the client's counters are kept under"`
+	Name   string      `json:"name" jsonschema:"Client name its policies are looked up by"`
+	Total  ToolUsage   `json:"total" jsonschema:"All of the client's calls; tool is empty"`
+	Tools  []ToolUsage `json:"tools" jsonschema:"Calls per tool, by name"`
+}
+
+// UsageReportResult is the structured response of usage_report
+type UsageReportResult struct {
+	Clients []ClientUsage `json:"clients" jsonschema:"Clients that called a tool since the server started; empty when rate limiting is disabled"`
+	Tools   []ToolStats   `json:"tools" jsonschema:"Server-wide call outcomes and durations per tool, by name"`
+}
+
+type usageReportTool struct {
+	reporter UsageReporter
+	metrics  *ToolMetrics
+}
+
+// WithUsageReport registers the usage_report tool. reporter is nil when rate
+// limiting is disabled, metrics when tool calls are not measured
+func WithUsageReport(reporter UsageReporter, metrics *ToolMetrics) Option {
+	return func(reg *registry) {
+		handler := usageReportTool{reporter: reporter, metrics: metrics}
+		AddTool(reg.server, &sdkmcp.Tool{
+			Name:        "usage_report",
+			Description: "Admin: show per-client tool call counts, rate-limited calls and daily quota use, and per-tool outcomes and durations since the server started",
+		}, handler.handle)
+	}
+}
+
+func RegisterUsageTools(server *ToolServer, reporter UsageReporter, metrics *ToolMetrics) error {
+	Register(server, WithUsageReport(reporter, metrics))
+	return nil
+}
+
+func (t usageReportTool) handle(ctx context.Context, params *UsageReportParams) (*sdkmcp.CallToolResult, any, error) {
+	result := UsageReportResult{Clients: []ClientUsage{}, Tools: []ToolStats{}}
+	if t.metrics != nil {
+		result.Tools = t.metrics.Snapshot()
+	}
+	if t.reporter == nil {
+		return textResult(formatUsageReport(result, false)), result, nil
+	}
+
+	for _, u := range t.reporter.Usage(strings.TrimSpace(params.Client)) {
+		entry := ToolUsage{
+			Tool:       u.Tool,
+			Allowed:    u.Allowed,
+			Limited:    u.Limited,
+			Today:      u.Today,
+			DailyQuota: u.DailyQuota,
+			LastCall:   u.LastCall,
+		}
+		// Usage lists each client's total first
+		if u.Tool == "" {
+			result.Clients = append(result.Clients, ClientUsage{Client: u.Client, Name: u.Name, Total: entry, Tools: []ToolUsage{}})
+			continue
+		}
+		last := &result.Clients[len(result.Clients)-1]
+		last.Tools = append(last.Tools, entry)
+	}
+
+	annotate(ctx, "clients", len(result.Clients), "tools", len(result.Tools))
+	return textResult(formatUsageReport(result, true)), result, nil
+}
+
+func formatUsageReport(r UsageReportResult, limited bool) string {
+	var sb strings.Builder
+	if limited {
+		sb.WriteString(fmt.Sprintf("[usage_report] %d client(s)\n", len(r.Clients)))
+	} else {
+		sb.WriteString("[usage_report] rate limiting is disabled, no per-client usage is recorded\n")
+	}
+	for _, c := range r.Clients {
+		label := c.Name
+		if c.Client != c.Name {
+			label += " (" + c.Client + ")"
+		}
+		sb.WriteString(fmt.Sprintf("%s: %d allowed, %d limited, %d today%s\n",
+			label, c.Total.Allowed, c.Total.Limited, c.Total.Today, formatQuota(c.Total.DailyQuota)))
+		for _, u := range c.Tools {
+			sb.WriteString(fmt.Sprintf("  %s: %d allowed, %d limited, %d today%s, last %s\n",
+				u.Tool, u.Allowed, u.Limited, u.Today, formatQuota(u.DailyQuota), u.LastCall.UTC().Format(time.RFC3339)))
+		}
+	}
+	if len(r.Tools) > 0 {
+		sb.WriteString("\nTools:\n")
+		for _, s := range r.Tools {
+			sb.WriteString(fmt.Sprintf("  %s: %d call(s), %d refused, %d invalid, %d failed (%d timed out, %d panicked), avg %.0fms, max %dms\n",
+				s.Tool, s.Calls, s.Refused, s.Invalid, s.Failed, s.Timeouts, s.Panics, s.AvgMillis, s.MaxMillis))
+		}
+	}
+	return sb.String()
+}
+
+func formatQuota(quota int) string {
+	if quota == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" of %d", quota)
+}
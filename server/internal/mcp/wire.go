@@ -6,27 +6,47 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/wire"
 
 	"github.com/honeycarbs/project-ets/internal/config"
 	"github.com/honeycarbs/project-ets/internal/domain/analysis"
+	"github.com/honeycarbs/project-ets/internal/domain/application"
+	"github.com/honeycarbs/project-ets/internal/domain/contact"
+	"github.com/honeycarbs/project-ets/internal/domain/employer"
+	"github.com/honeycarbs/project-ets/internal/domain/event"
+	"github.com/honeycarbs/project-ets/internal/domain/ghost"
+	"github.com/honeycarbs/project-ets/internal/domain/graphio"
+	"github.com/honeycarbs/project-ets/internal/domain/graphschema"
+	"github.com/honeycarbs/project-ets/internal/domain/history"
+	"github.com/honeycarbs/project-ets/internal/domain/lifecycle"
+	"github.com/honeycarbs/project-ets/internal/domain/notifier"
+	"github.com/honeycarbs/project-ets/internal/domain/reminder"
+	"github.com/honeycarbs/project-ets/internal/domain/savedquery"
+	"github.com/honeycarbs/project-ets/internal/domain/savedsearch"
 	"github.com/honeycarbs/project-ets/internal/domain/job"
 	adzunaProvider "github.com/honeycarbs/project-ets/internal/domain/job/providers/adzuna"
+	"github.com/honeycarbs/project-ets/internal/identity"
 	"github.com/honeycarbs/project-ets/internal/mcp/tools"
 	"github.com/honeycarbs/project-ets/internal/repository"
 	storage "github.com/honeycarbs/project-ets/internal/storage/neo4j"
 	"github.com/honeycarbs/project-ets/pkg/adzuna"
+	"github.com/honeycarbs/project-ets/pkg/apikey"
+	"github.com/honeycarbs/project-ets/pkg/jwtauth"
+	"github.com/honeycarbs/project-ets/pkg/logging"
 	n4j "github.com/honeycarbs/project-ets/pkg/neo4j"
+	"github.com/honeycarbs/project-ets/pkg/notify"
+	"github.com/honeycarbs/project-ets/pkg/ratelimit"
 	sheetsclient "github.com/honeycarbs/project-ets/pkg/sheets"
 )
 
 // InitializeResources creates Resources with all resources wired up
-func InitializeResources(ctx context.Context, cfg config.Config) (*Resources, error) {
+func InitializeResources(ctx context.Context, cfg config.Config, logger *logging.Logger) (*Resources, error) {
 	wire.Build(
 		// Infrastructure - Neo4j
 		provideNeo4jConfig,
-		n4j.NewClient,
+		provideNeo4jClient,
 
 		// Infrastructure - Adzuna
 		provideAdzunaConfig,
@@ -40,26 +60,106 @@ func InitializeResources(ctx context.Context, cfg config.Config) (*Resources, er
 		wire.Bind(new(tools.KeywordRepository), new(*storage.KeywordRepository)),
 		storage.NewAnalysisRepository,
 		wire.Bind(new(repository.AnalysisRepository), new(*storage.AnalysisRepository)),
+		storage.NewCandidateRepository,
+		wire.Bind(new(repository.CandidateRepository), new(*storage.CandidateRepository)),
+		storage.NewCompanyRepository,
+		wire.Bind(new(repository.CompanyRepository), new(*storage.CompanyRepository)),
+		storage.NewPostingRepository,
+		wire.Bind(new(repository.PostingRepository), new(*storage.PostingRepository)),
+		storage.NewLifecycleRepository,
+		wire.Bind(new(repository.LifecycleRepository), new(*storage.LifecycleRepository)),
+		storage.NewSnapshotRepository,
+		wire.Bind(new(repository.SnapshotRepository), new(*storage.SnapshotRepository)),
+		storage.NewApplicationRepository,
+		wire.Bind(new(repository.ApplicationRepository), new(*storage.ApplicationRepository)),
+		storage.NewEventRepository,
+		wire.Bind(new(repository.EventRepository), new(*storage.EventRepository)),
+		storage.NewContactRepository,
+		wire.Bind(new(repository.ContactRepository), new(*storage.ContactRepository)),
+		storage.NewSavedSearchRepository,
+		wire.Bind(new(repository.SavedSearchRepository), new(*storage.SavedSearchRepository)),
+		storage.NewNotificationRepository,
+		wire.Bind(new(repository.NotificationRepository), new(*storage.NotificationRepository)),
+		storage.NewSavedQueryRepository,
+		wire.Bind(new(repository.SavedQueryRepository), new(*storage.SavedQueryRepository)),
+		storage.NewSchemaRepository,
+		wire.Bind(new(repository.SchemaRepository), new(*storage.SchemaRepository)),
+		storage.NewExportRepository,
+		wire.Bind(new(repository.ExportRepository), new(*storage.ExportRepository)),
 
 		// Providers
 		provideAdzunaProvider,
 		provideJobProviders,
 
 		// Services
+		provideLifecycleService,
+		wire.Bind(new(job.RefreshRecorder), new(*lifecycle.Service)),
+		wire.Bind(new(tools.LifecycleService), new(*lifecycle.Service)),
 		job.NewServiceWithDeps,
+		ghost.NewService,
+		wire.Bind(new(tools.GhostService), new(*ghost.Service)),
 		analysis.NewService,
 		wire.Bind(new(tools.AnalysisService), new(*analysis.Service)),
+		employer.NewService,
+		wire.Bind(new(tools.EmployerService), new(*employer.Service)),
+		history.NewService,
+		wire.Bind(new(tools.JobHistoryService), new(*history.Service)),
+		application.NewService,
+		wire.Bind(new(tools.ApplicationService), new(*application.Service)),
+		event.NewService,
+		wire.Bind(new(tools.EventService), new(*event.Service)),
+		contact.NewService,
+		wire.Bind(new(tools.ContactService), new(*contact.Service)),
+		provideReminderService,
+		wire.Bind(new(tools.ReminderService), new(*reminder.Service)),
+		provideNotifier,
+		wire.Bind(new(savedsearch.Notifier), new(*notifier.Service)),
+		provideSavedSearchService,
+		wire.Bind(new(tools.SavedSearchService), new(*savedsearch.Service)),
+		provideSavedSearchScheduler,
 
 		// Tool resources
 		provideSheetsConfig,
 		provideSheetsClient,
 		provideSheetsClientAdapter,
+		provideGraphToolLimits,
+		provideSavedQueryCatalog,
+		wire.Bind(new(tools.SavedQueryCatalog), new(*savedquery.Catalog)),
+		provideGraphSchemaCache,
+		wire.Bind(new(tools.GraphSchemaProvider), new(*graphschema.Cache)),
+		graphio.NewExporter,
+		wire.Bind(new(tools.GraphExporter), new(*graphio.Exporter)),
+
+		// HTTP authentication
+		provideAPIKeys,
+		provideTokenValidator,
+		storage.NewUserRepository,
+		wire.Bind(new(repository.UserRepository), new(*storage.UserRepository)),
+		provideIdentityResolver,
+		provideAuthenticator,
+
+		// Tool call rate limits
+		provideRateLimiter,
 		newResources,
 	)
 
 	return &Resources{}, nil
 }
 
+// provideNeo4jClient connects to Neo4j and makes sure the indexes and
+// nodes the repositories look companies up by exist
+func provideNeo4jClient(ctx context.Context, cfg n4j.Config) (*n4j.Client, error) {
+	client, err := n4j.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.EnsureCompanyAliases(ctx, client); err != nil {
+		_ = client.Close(ctx)
+		return nil, err
+	}
+	return client, nil
+}
+
 // provideNeo4jConfig extracts Neo4j config from main config
 func provideNeo4jConfig(cfg config.Config) n4j.Config {
 	return n4j.Config{
@@ -88,6 +188,60 @@ func provideJobProviders(adzunaProvider *adzunaProvider.Provider) []job.Provider
 	return []job.Provider{adzunaProvider}
 }
 
+// provideGraphToolLimits extracts graph_tool limits from main config
+func provideGraphToolLimits(cfg config.Config) tools.GraphToolLimits {
+	return tools.GraphToolLimits{
+		MaxRows:  cfg.GraphTool.MaxRows,
+		MaxBytes: cfg.GraphTool.MaxBytes,
+		Timeout:  cfg.GraphTool.Timeout,
+	}
+}
+
+// provideLifecycleService creates the lifecycle service used by job_search refreshes and job_recheck
+func provideLifecycleService(cfg config.Config, repo repository.LifecycleRepository, providers []job.Provider) *lifecycle.Service {
+	return lifecycle.NewService(repo, providers, cfg.Lifecycle.CloseAfterMisses)
+}
+
+// provideReminderService creates the reminder service used by todo_digest and the digest endpoint
+func provideReminderService(cfg config.Config, apps repository.ApplicationRepository, events tools.EventService) *reminder.Service {
+	return reminder.NewService(apps, events, reminder.DefaultRules(cfg.Reminders.FollowUpDays))
+}
+
+// provideNotifier loads the notification channels announced to after saved search runs
+func provideNotifier(cfg config.Config, repo repository.NotificationRepository, logger *logging.Logger) (*notifier.Service, error) {
+	var channels []notifier.Channel
+	var defaults []string
+	if cfg.Notify.ConfigPath != "" {
+		var err error
+		channels, defaults, err = notifier.LoadFile(cfg.Notify.ConfigPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	policy := notify.RetryPolicy{Attempts: cfg.Notify.RetryAttempts, Backoff: cfg.Notify.RetryBackoff, MaxBackoff: 30 * time.Second}
+	return notifier.NewService(repo, channels, defaults, policy, logger), nil
+}
+
+// provideSavedSearchService creates the saved search service used by the saved search tools and the scheduler
+func provideSavedSearchService(cfg config.Config, repo repository.SavedSearchRepository, jobs job.Service, notifier savedsearch.Notifier) *savedsearch.Service {
+	return savedsearch.NewService(repo, jobs, notifier, cfg.SavedSearches.Jitter, cfg.SavedSearches.RunTimeout)
+}
+
+// provideSavedSearchScheduler creates the background scheduler for saved searches
+func provideSavedSearchScheduler(cfg config.Config, service *savedsearch.Service, logger *logging.Logger) *savedsearch.Scheduler {
+	return savedsearch.NewScheduler(service, cfg.SavedSearches.Tick, logger)
+}
+
+// provideSavedQueryCatalog loads the saved query catalog used by graph_tool
+func provideSavedQueryCatalog(cfg config.Config, repo repository.SavedQueryRepository, logger *logging.Logger) (*savedquery.Catalog, error) {
+	return savedquery.NewCatalog(repo, cfg.SavedQueries.Path, logger)
+}
+
+// provideGraphSchemaCache creates the schema cache used by graph_tool and the schema resource
+func provideGraphSchemaCache(cfg config.Config, repo repository.SchemaRepository) *graphschema.Cache {
+	return graphschema.NewCache(repo, cfg.GraphTool.SchemaTTL)
+}
+
 // provideSheetsConfig extracts Sheets config from main config
 func provideSheetsConfig(cfg config.Config) sheetsclient.Config {
 	return sheetsclient.Config{
@@ -108,22 +262,136 @@ func provideSheetsClientAdapter(client *sheetsclient.Client) tools.SheetsClient
 	return &sheetsClientAdapter{client: client}
 }
 
+// provideAPIKeys loads the API keys accepted by the HTTP endpoints, if any
+func provideAPIKeys(cfg config.Config) (*apikey.Store, error) {
+	if cfg.Auth.KeysPath == "" {
+		return nil, nil
+	}
+	keys, err := apikey.LoadFile(cfg.Auth.KeysPath)
+	if err != nil {
+		return nil, err
+	}
+	return apikey.NewStore(keys)
+}
+
+// provideTokenValidator creates the OAuth access token validator when an
+// issuer is configured, discovering its JWKS unless one is set
+func provideTokenValidator(ctx context.Context, cfg config.Config) (*jwtauth.Validator, error) {
+	if cfg.OAuth.Issuer == "" {
+		return nil, nil
+	}
+	jwksURL := cfg.OAuth.JWKSURL
+	if jwksURL == "" {
+		meta, err := jwtauth.Discover(ctx, cfg.OAuth.Issuer, nil)
+		if err != nil {
+			return nil, err
+		}
+		jwksURL = meta.JWKSURI
+	}
+	keys := jwtauth.NewKeySet(jwksURL, nil)
+	return jwtauth.NewValidator(jwtauth.Config{
+		Issuer:   cfg.OAuth.Issuer,
+		Audience: cfg.OAuth.Audience,
+		Leeway:   jwtauth.DefaultLeeway,
+	}, keys), nil
+}
+
+// provideIdentityResolver creates the resolver mapping token subjects to users
+func provideIdentityResolver(repo repository.UserRepository) *identity.Resolver {
+	return identity.NewResolver(repo, 10*time.Minute)
+}
+
+// provideAuthenticator combines API keys and OAuth tokens; it is nil only
+// when authentication is explicitly disabled
+func provideAuthenticator(cfg config.Config, keys *apikey.Store, tokens *jwtauth.Validator, users *identity.Resolver, logger *logging.Logger) (*Authenticator, error) {
+	if cfg.Auth.Disabled {
+		logger.Warn("authentication is disabled, every endpoint is open")
+		return nil, nil
+	}
+	if keys.Len() == 0 && tokens == nil {
+		return nil, fmt.Errorf("no authentication configured: set AUTH_KEYS_PATH or OAUTH_ISSUER, or AUTH_DISABLED=true")
+	}
+
+	var metadata *ResourceMetadata
+	if tokens != nil {
+		metadata = &ResourceMetadata{
+			Resource:               cfg.OAuth.Resource,
+			AuthorizationServers:   []string{cfg.OAuth.Issuer},
+			ScopesSupported:        []string{tools.ScopeRead, tools.ScopeWrite, tools.ScopeGraph, tools.ScopeAdmin},
+			BearerMethodsSupported: []string{"header"},
+			ResourceName:           "project-ets",
+		}
+	}
+	return NewAuthenticator(keys, tokens, users, metadata), nil
+}
+
+// provideRateLimiter loads the tool call policies, falling back to the
+// built-in defaults; it is nil when rate limiting is disabled
+func provideRateLimiter(cfg config.Config, logger *logging.Logger) (*ratelimit.Limiter, error) {
+	if cfg.RateLimit.Disabled {
+		logger.Warn("rate limiting is disabled, tool calls are not limited")
+		return nil, nil
+	}
+	policies := ratelimit.DefaultConfig()
+	if cfg.RateLimit.ConfigPath != "" {
+		var err error
+		if policies, err = ratelimit.LoadFile(cfg.RateLimit.ConfigPath); err != nil {
+			return nil, err
+		}
+	}
+	return ratelimit.New(policies)
+}
+
 // newResources creates Resources struct
 func newResources(
 	jobService job.Service,
 	jobRepo repository.JobRepository,
 	keywordRepo tools.KeywordRepository,
+	candidateRepo repository.CandidateRepository,
 	analysisSvc tools.AnalysisService,
+	employerSvc tools.EmployerService,
+	ghostSvc tools.GhostService,
+	lifecycleSvc tools.LifecycleService,
+	historySvc tools.JobHistoryService,
+	applicationSvc tools.ApplicationService,
+	eventSvc tools.EventService,
+	contactSvc tools.ContactService,
+	reminderSvc tools.ReminderService,
+	savedSearchSvc tools.SavedSearchService,
+	scheduler *savedsearch.Scheduler,
 	sheetsClient tools.SheetsClient,
 	neo4jClient *n4j.Client,
+	graphLimits tools.GraphToolLimits,
+	savedQueries tools.SavedQueryCatalog,
+	graphSchema tools.GraphSchemaProvider,
+	graphExporter tools.GraphExporter,
+	auth *Authenticator,
+	limiter *ratelimit.Limiter,
 ) *Resources {
 	return &Resources{
-		JobService:   jobService,
-		JobRepo:      jobRepo,
-		KeywordRepo:  keywordRepo,
-		AnalysisSvc:  analysisSvc,
-		SheetsClient: sheetsClient,
-		Neo4jClient:  neo4jClient,
+		JobService:     jobService,
+		JobRepo:        jobRepo,
+		KeywordRepo:    keywordRepo,
+		CandidateRepo:  candidateRepo,
+		AnalysisSvc:    analysisSvc,
+		EmployerSvc:    employerSvc,
+		GhostSvc:       ghostSvc,
+		LifecycleSvc:   lifecycleSvc,
+		HistorySvc:     historySvc,
+		ApplicationSvc: applicationSvc,
+		EventSvc:       eventSvc,
+		ContactSvc:     contactSvc,
+		ReminderSvc:    reminderSvc,
+		SavedSearchSvc: savedSearchSvc,
+		Scheduler:      scheduler,
+		SheetsClient:   sheetsClient,
+		Neo4jClient:    neo4jClient,
+		GraphLimits:    graphLimits,
+		SavedQueries:   savedQueries,
+		GraphSchema:    graphSchema,
+		GraphExporter:  graphExporter,
+		Auth:           auth,
+		Limiter:        limiter,
 	}
 }
 
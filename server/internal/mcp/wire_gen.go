@@ -9,17 +9,36 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/honeycarbs/project-ets/internal/config"
 	"github.com/honeycarbs/project-ets/internal/domain/analysis"
+	"github.com/honeycarbs/project-ets/internal/domain/application"
+	"github.com/honeycarbs/project-ets/internal/domain/contact"
+	"github.com/honeycarbs/project-ets/internal/domain/employer"
+	"github.com/honeycarbs/project-ets/internal/domain/event"
+	"github.com/honeycarbs/project-ets/internal/domain/ghost"
+	"github.com/honeycarbs/project-ets/internal/domain/graphio"
+	"github.com/honeycarbs/project-ets/internal/domain/graphschema"
+	"github.com/honeycarbs/project-ets/internal/domain/history"
+	"github.com/honeycarbs/project-ets/internal/domain/lifecycle"
+	"github.com/honeycarbs/project-ets/internal/domain/notifier"
+	"github.com/honeycarbs/project-ets/internal/domain/reminder"
+	"github.com/honeycarbs/project-ets/internal/domain/savedquery"
+	"github.com/honeycarbs/project-ets/internal/domain/savedsearch"
 	"github.com/honeycarbs/project-ets/internal/domain/job"
 	adzuna2 "github.com/honeycarbs/project-ets/internal/domain/job/providers/adzuna"
+	"github.com/honeycarbs/project-ets/internal/identity"
 	"github.com/honeycarbs/project-ets/internal/mcp/tools"
 	"github.com/honeycarbs/project-ets/internal/repository"
 	neo4j2 "github.com/honeycarbs/project-ets/internal/storage/neo4j"
 	"github.com/honeycarbs/project-ets/pkg/adzuna"
+	"github.com/honeycarbs/project-ets/pkg/apikey"
+	"github.com/honeycarbs/project-ets/pkg/jwtauth"
 	"github.com/honeycarbs/project-ets/pkg/logging"
 	"github.com/honeycarbs/project-ets/pkg/neo4j"
+	"github.com/honeycarbs/project-ets/pkg/notify"
+	"github.com/honeycarbs/project-ets/pkg/ratelimit"
 	"github.com/honeycarbs/project-ets/pkg/sheets"
 )
 
@@ -28,7 +47,7 @@ import (
 // InitializeResources creates Resources with all resources wired up
 func InitializeResources(ctx context.Context, cfg config.Config, logger *logging.Logger) (*Resources, error) {
 	neo4jConfig := provideNeo4jConfig(cfg)
-	client, err := neo4j.NewClient(neo4jConfig)
+	client, err := provideNeo4jClient(ctx, neo4jConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -43,25 +62,91 @@ func InitializeResources(ctx context.Context, cfg config.Config, logger *logging
 		return nil, err
 	}
 	v := provideJobProviders(provider)
-	service, err := job.NewServiceWithDeps(jobRepository, v)
+	lifecycleRepository := neo4j2.NewLifecycleRepository(client)
+	lifecycleService := provideLifecycleService(cfg, lifecycleRepository, v)
+	service, err := job.NewServiceWithDeps(jobRepository, v, lifecycleService)
 	if err != nil {
 		return nil, err
 	}
 	keywordRepository := neo4j2.NewKeywordRepository(client)
 	analysisRepository := neo4j2.NewAnalysisRepository(client, logger)
-	analysisService := analysis.NewService(analysisRepository)
+	candidateRepository := neo4j2.NewCandidateRepository(client)
+	postingRepository := neo4j2.NewPostingRepository(client)
+	ghostService := ghost.NewService(postingRepository)
+	contactRepository := neo4j2.NewContactRepository(client)
+	analysisService := analysis.NewService(analysisRepository, candidateRepository, ghostService, contactRepository)
+	companyRepository := neo4j2.NewCompanyRepository(client)
+	employerService := employer.NewService(companyRepository)
+	snapshotRepository := neo4j2.NewSnapshotRepository(client)
+	historyService := history.NewService(snapshotRepository)
+	applicationRepository := neo4j2.NewApplicationRepository(client)
+	applicationService := application.NewService(applicationRepository)
+	eventRepository := neo4j2.NewEventRepository(client)
+	eventService := event.NewService(eventRepository)
+	contactService := contact.NewService(contactRepository, companyRepository)
+	reminderService := provideReminderService(cfg, applicationRepository, eventService)
+	savedSearchRepository := neo4j2.NewSavedSearchRepository(client)
+	notificationRepository := neo4j2.NewNotificationRepository(client)
+	notifierService, err := provideNotifier(cfg, notificationRepository, logger)
+	if err != nil {
+		return nil, err
+	}
+	savedsearchService := provideSavedSearchService(cfg, savedSearchRepository, service, notifierService)
+	scheduler := provideSavedSearchScheduler(cfg, savedsearchService, logger)
 	sheetsConfig := provideSheetsConfig(cfg)
 	sheetsClient, err := provideSheetsClient(ctx, sheetsConfig)
 	if err != nil {
 		return nil, err
 	}
 	toolsSheetsClient := provideSheetsClientAdapter(sheetsClient)
-	resources := newResources(service, jobRepository, keywordRepository, analysisService, toolsSheetsClient, client)
+	graphToolLimits := provideGraphToolLimits(cfg)
+	savedQueryRepository := neo4j2.NewSavedQueryRepository(client)
+	catalog, err := provideSavedQueryCatalog(cfg, savedQueryRepository, logger)
+	if err != nil {
+		return nil, err
+	}
+	schemaRepository := neo4j2.NewSchemaRepository(client, logger)
+	cache := provideGraphSchemaCache(cfg, schemaRepository)
+	exportRepository := neo4j2.NewExportRepository(client)
+	exporter := graphio.NewExporter(exportRepository)
+	store, err := provideAPIKeys(cfg)
+	if err != nil {
+		return nil, err
+	}
+	validator, err := provideTokenValidator(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	userRepository := neo4j2.NewUserRepository(client)
+	resolver := provideIdentityResolver(userRepository)
+	authenticator, err := provideAuthenticator(cfg, store, validator, resolver, logger)
+	if err != nil {
+		return nil, err
+	}
+	limiter, err := provideRateLimiter(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	resources := newResources(service, jobRepository, keywordRepository, candidateRepository, analysisService, employerService, ghostService, lifecycleService, historyService, applicationService, eventService, contactService, reminderService, savedsearchService, scheduler, toolsSheetsClient, client, graphToolLimits, catalog, cache, exporter, authenticator, limiter)
 	return resources, nil
 }
 
 // wire.go:
 
+// provideNeo4jClient connects to Neo4j and makes sure the indexes and
+// nodes the repositories look companies up by exist
+func provideNeo4jClient(ctx context.Context, cfg neo4j.Config) (*neo4j.Client, error) {
+	client, err := neo4j.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := neo4j2.EnsureCompanyAliases(ctx, client); err != nil {
+		_ = client.Close(ctx)
+		return nil, err
+	}
+	return client, nil
+}
+
 // provideNeo4jConfig extracts Neo4j config from main config
 func provideNeo4jConfig(cfg config.Config) neo4j.Config {
 	return neo4j.Config{
@@ -90,6 +175,60 @@ func provideJobProviders(adzunaProvider *adzuna2.Provider) []job.Provider {
 	return []job.Provider{adzunaProvider}
 }
 
+// provideGraphToolLimits extracts graph_tool limits from main config
+func provideGraphToolLimits(cfg config.Config) tools.GraphToolLimits {
+	return tools.GraphToolLimits{
+		MaxRows:  cfg.GraphTool.MaxRows,
+		MaxBytes: cfg.GraphTool.MaxBytes,
+		Timeout:  cfg.GraphTool.Timeout,
+	}
+}
+
+// provideLifecycleService creates the lifecycle service used by job_search refreshes and job_recheck
+func provideLifecycleService(cfg config.Config, repo repository.LifecycleRepository, providers []job.Provider) *lifecycle.Service {
+	return lifecycle.NewService(repo, providers, cfg.Lifecycle.CloseAfterMisses)
+}
+
+// provideReminderService creates the reminder service used by todo_digest and the digest endpoint
+func provideReminderService(cfg config.Config, apps repository.ApplicationRepository, events tools.EventService) *reminder.Service {
+	return reminder.NewService(apps, events, reminder.DefaultRules(cfg.Reminders.FollowUpDays))
+}
+
+// provideNotifier loads the notification channels announced to after saved search runs
+func provideNotifier(cfg config.Config, repo repository.NotificationRepository, logger *logging.Logger) (*notifier.Service, error) {
+	var channels []notifier.Channel
+	var defaults []string
+	if cfg.Notify.ConfigPath != "" {
+		var err error
+		channels, defaults, err = notifier.LoadFile(cfg.Notify.ConfigPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	policy := notify.RetryPolicy{Attempts: cfg.Notify.RetryAttempts, Backoff: cfg.Notify.RetryBackoff, MaxBackoff: 30 * time.Second}
+	return notifier.NewService(repo, channels, defaults, policy, logger), nil
+}
+
+// provideSavedSearchService creates the saved search service used by the saved search tools and the scheduler
+func provideSavedSearchService(cfg config.Config, repo repository.SavedSearchRepository, jobs job.Service, notifier savedsearch.Notifier) *savedsearch.Service {
+	return savedsearch.NewService(repo, jobs, notifier, cfg.SavedSearches.Jitter, cfg.SavedSearches.RunTimeout)
+}
+
+// provideSavedSearchScheduler creates the background scheduler for saved searches
+func provideSavedSearchScheduler(cfg config.Config, service *savedsearch.Service, logger *logging.Logger) *savedsearch.Scheduler {
+	return savedsearch.NewScheduler(service, cfg.SavedSearches.Tick, logger)
+}
+
+// provideSavedQueryCatalog loads the saved query catalog used by graph_tool
+func provideSavedQueryCatalog(cfg config.Config, repo repository.SavedQueryRepository, logger *logging.Logger) (*savedquery.Catalog, error) {
+	return savedquery.NewCatalog(repo, cfg.SavedQueries.Path, logger)
+}
+
+// provideGraphSchemaCache creates the schema cache used by graph_tool and the schema resource
+func provideGraphSchemaCache(cfg config.Config, repo repository.SchemaRepository) *graphschema.Cache {
+	return graphschema.NewCache(repo, cfg.GraphTool.SchemaTTL)
+}
+
 // provideSheetsConfig extracts Sheets config from main config
 func provideSheetsConfig(cfg config.Config) sheets.Config {
 	return sheets.Config{
@@ -110,21 +249,135 @@ func provideSheetsClientAdapter(client *sheets.Client) tools.SheetsClient {
 	return &sheetsClientAdapter{client: client}
 }
 
+// provideAPIKeys loads the API keys accepted by the HTTP endpoints, if any
+func provideAPIKeys(cfg config.Config) (*apikey.Store, error) {
+	if cfg.Auth.KeysPath == "" {
+		return nil, nil
+	}
+	keys, err := apikey.LoadFile(cfg.Auth.KeysPath)
+	if err != nil {
+		return nil, err
+	}
+	return apikey.NewStore(keys)
+}
+
+// provideTokenValidator creates the OAuth access token validator when an
+// issuer is configured, discovering its JWKS unless one is set
+func provideTokenValidator(ctx context.Context, cfg config.Config) (*jwtauth.Validator, error) {
+	if cfg.OAuth.Issuer == "" {
+		return nil, nil
+	}
+	jwksURL := cfg.OAuth.JWKSURL
+	if jwksURL == "" {
+		meta, err := jwtauth.Discover(ctx, cfg.OAuth.Issuer, nil)
+		if err != nil {
+			return nil, err
+		}
+		jwksURL = meta.JWKSURI
+	}
+	keys := jwtauth.NewKeySet(jwksURL, nil)
+	return jwtauth.NewValidator(jwtauth.Config{
+		Issuer:   cfg.OAuth.Issuer,
+		Audience: cfg.OAuth.Audience,
+		Leeway:   jwtauth.DefaultLeeway,
+	}, keys), nil
+}
+
+// provideIdentityResolver creates the resolver mapping token subjects to users
+func provideIdentityResolver(repo repository.UserRepository) *identity.Resolver {
+	return identity.NewResolver(repo, 10*time.Minute)
+}
+
+// provideAuthenticator combines API keys and OAuth tokens; it is nil only
+// when authentication is explicitly disabled
+func provideAuthenticator(cfg config.Config, keys *apikey.Store, tokens *jwtauth.Validator, users *identity.Resolver, logger *logging.Logger) (*Authenticator, error) {
+	if cfg.Auth.Disabled {
+		logger.Warn("authentication is disabled, every endpoint is open")
+		return nil, nil
+	}
+	if keys.Len() == 0 && tokens == nil {
+		return nil, fmt.Errorf("no authentication configured: set AUTH_KEYS_PATH or OAUTH_ISSUER, or AUTH_DISABLED=true")
+	}
+
+	var metadata *ResourceMetadata
+	if tokens != nil {
+		metadata = &ResourceMetadata{
+			Resource:               cfg.OAuth.Resource,
+			AuthorizationServers:   []string{cfg.OAuth.Issuer},
+			ScopesSupported:        []string{tools.ScopeRead, tools.ScopeWrite, tools.ScopeGraph, tools.ScopeAdmin},
+			BearerMethodsSupported: []string{"header"},
+			ResourceName:           "project-ets",
+		}
+	}
+	return NewAuthenticator(keys, tokens, users, metadata), nil
+}
+
+// provideRateLimiter loads the tool call policies, falling back to the
+// built-in defaults; it is nil when rate limiting is disabled
+func provideRateLimiter(cfg config.Config, logger *logging.Logger) (*ratelimit.Limiter, error) {
+	if cfg.RateLimit.Disabled {
+		logger.Warn("rate limiting is disabled, tool calls are not limited")
+		return nil, nil
+	}
+	policies := ratelimit.DefaultConfig()
+	if cfg.RateLimit.ConfigPath != "" {
+		var err error
+		if policies, err = ratelimit.LoadFile(cfg.RateLimit.ConfigPath); err != nil {
+			return nil, err
+		}
+	}
+	return ratelimit.New(policies)
+}
+
 // newResources creates Resources struct
 func newResources(
 	jobService job.Service,
 	jobRepo repository.JobRepository,
 	keywordRepo tools.KeywordRepository,
+	candidateRepo repository.CandidateRepository,
 	analysisSvc tools.AnalysisService,
+	employerSvc tools.EmployerService,
+	ghostSvc tools.GhostService,
+	lifecycleSvc tools.LifecycleService,
+	historySvc tools.JobHistoryService,
+	applicationSvc tools.ApplicationService,
+	eventSvc tools.EventService,
+	contactSvc tools.ContactService,
+	reminderSvc tools.ReminderService,
+	savedSearchSvc tools.SavedSearchService,
+	scheduler *savedsearch.Scheduler,
 	sheetsClient tools.SheetsClient,
 	neo4jClient *neo4j.Client,
+	graphLimits tools.GraphToolLimits,
+	savedQueries tools.SavedQueryCatalog,
+	graphSchema tools.GraphSchemaProvider,
+	graphExporter tools.GraphExporter,
+	auth *Authenticator,
+	limiter *ratelimit.Limiter,
 ) *Resources {
 	return &Resources{
-		JobService:   jobService,
-		JobRepo:      jobRepo,
-		KeywordRepo:  keywordRepo,
-		AnalysisSvc:  analysisSvc,
-		SheetsClient: sheetsClient,
-		Neo4jClient:  neo4jClient,
+		JobService:     jobService,
+		JobRepo:        jobRepo,
+		KeywordRepo:    keywordRepo,
+		CandidateRepo:  candidateRepo,
+		AnalysisSvc:    analysisSvc,
+		EmployerSvc:    employerSvc,
+		GhostSvc:       ghostSvc,
+		LifecycleSvc:   lifecycleSvc,
+		HistorySvc:     historySvc,
+		ApplicationSvc: applicationSvc,
+		EventSvc:       eventSvc,
+		ContactSvc:     contactSvc,
+		ReminderSvc:    reminderSvc,
+		SavedSearchSvc: savedSearchSvc,
+		Scheduler:      scheduler,
+		SheetsClient:   sheetsClient,
+		Neo4jClient:    neo4jClient,
+		GraphLimits:    graphLimits,
+		SavedQueries:   savedQueries,
+		GraphSchema:    graphSchema,
+		GraphExporter:  graphExporter,
+		Auth:           auth,
+		Limiter:        limiter,
 	}
 }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/honeycarbs/project-ets/internal/domain"
 )
@@ -14,16 +15,18 @@ type JobSubgraph struct {
 
 // KeywordNode represents a keyword with its metadata
 type KeywordNode struct {
-	Value  string
-	Source string
+	Value      string
+	Source     string
+	Confidence float64 // 0 when the agent did not report one
 }
 
 // RelatedJob represents a job connected via shared graph elements
 type RelatedJob struct {
-	Job           domain.Job
-	SharedSkills  []string
+	Job            domain.Job
+	SharedSkills   []string
 	SharedKeywords []string
-	Relevance     float64
+	SameCompany    bool
+	Relevance      float64
 }
 
 // SkillCooccurrence represents skills frequently appearing together
@@ -33,10 +36,47 @@ type SkillCooccurrence struct {
 	CommonWith  []string
 }
 
-// AnalysisRepository defines graph retrieval operations for job analysis
+// Job timestamp properties a trend query can bucket by
+const (
+	TimeFieldFetchedAt = "fetchedAt"
+	TimeFieldPostedAt  = "postedAt"
+)
+
+// Trend orderings
+const (
+	TrendOrderCount = "count"
+	TrendOrderDelta = "delta"
+)
+
+// TimeWindow is a half-open [From, To) interval over a job timestamp
+type TimeWindow struct {
+	From time.Time
+	To   time.Time
+}
+
+// TrendQuery describes a windowed term frequency lookup
+type TrendQuery struct {
+	TimeField string
+	Current   TimeWindow
+	Previous  TimeWindow
+	OrderBy   string
+	Limit     int
+}
+
+// TermTrend compares how many jobs mention a term in two time windows
+type TermTrend struct {
+	Term     string
+	Current  int
+	Previous int
+}
+
+// AnalysisRepository defines graph retrieval operations for job analysis.
+// Jobs and skills are shared; keywords are limited to the caller's
 type AnalysisRepository interface {
 	GetJobSubgraphs(ctx context.Context, jobIDs []string) ([]JobSubgraph, error)
 	FindRelatedJobs(ctx context.Context, jobID string, limit int) ([]RelatedJob, error)
 	GetSkillCooccurrences(ctx context.Context, skills []string, limit int) ([]SkillCooccurrence, error)
+	GetSkillTrends(ctx context.Context, q TrendQuery) ([]TermTrend, error)
+	GetKeywordTrends(ctx context.Context, q TrendQuery) ([]TermTrend, error)
 }
 
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+)
+
+// ApplicationFilter selects applications; empty fields match everything
+type ApplicationFilter struct {
+	CandidateID string
+	JobIDs      []string
+	Statuses    []string
+	Limit       int
+}
+
+// ApplicationRepository stores APPLIED_TO relationships between candidates and
+// jobs. Applications are scoped through the caller's candidates
+type ApplicationRepository interface {
+	// GetApplication returns the candidate's application to the job; found is
+	// false when the candidate has not applied
+	GetApplication(ctx context.Context, candidateID, jobID string) (app domain.Application, found bool, err error)
+	// SaveApplication writes app if the stored status still equals
+	// expectedStatus (empty for a new application). saved is false when the
+	// candidate or job does not exist or the status changed concurrently
+	SaveApplication(ctx context.Context, app domain.Application, expectedStatus string) (saved bool, err error)
+	// ListApplications returns matching applications, most recently updated first
+	ListApplications(ctx context.Context, filter ApplicationFilter) ([]domain.Application, error)
+}
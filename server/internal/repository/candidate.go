@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+)
+
+// CandidateRepository defines storage operations for candidate profiles.
+// Profiles belong to the caller (see identity.OwnerID), so two users may use
+// the same candidate ID
+type CandidateRepository interface {
+	UpsertCandidate(ctx context.Context, candidate domain.Candidate) error
+	GetCandidate(ctx context.Context, id string) (domain.Candidate, bool, error)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+)
+
+// CompanyRecord is a resolved Company node
+type CompanyRecord struct {
+	ID      string
+	Name    string
+	Names   []string // raw names seen on postings
+	Aliases []string // company IDs that resolve to this company
+}
+
+// CompanyRepository resolves, inspects and merges Company nodes
+type CompanyRepository interface {
+	// ResolveCompany finds a company by ID or alias
+	ResolveCompany(ctx context.Context, id string) (CompanyRecord, bool, error)
+	// ListCompanyJobs returns the company's jobs with their skills, newest first
+	ListCompanyJobs(ctx context.Context, companyID string) ([]domain.Job, error)
+	// MergeCompanies moves postings, names and aliases of sourceIDs onto
+	// targetID, removes the source nodes and records their IDs as aliases
+	MergeCompanies(ctx context.Context, targetID string, sourceIDs []string) (CompanyRecord, error)
+}
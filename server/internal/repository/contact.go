@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+)
+
+// ContactFilter selects contacts; empty fields match everything
+type ContactFilter struct {
+	Query       string // case-insensitive substring of name, role, email or notes
+	Email       string // exact, case-insensitive
+	CompanyIDs  []string
+	JobID       string // contacts linked to the job
+	CandidateID string // contacts linked to the candidate's applications
+	Limit       int
+}
+
+// ContactRepository stores the caller's Contact nodes and their WORKS_AT,
+// REFERRED and INTERVIEWED_BY relationships
+type ContactRepository interface {
+	// UpsertContact creates the contact or updates its non-empty fields,
+	// moves it to contact.Company when set and adds the links whose jobs
+	// exist. It returns the stored contact with all its links
+	UpsertContact(ctx context.Context, contact domain.Contact, links []domain.ContactLink) (domain.Contact, error)
+	// SearchContacts returns matching contacts with their links, by name
+	SearchContacts(ctx context.Context, filter ContactFilter) ([]domain.Contact, error)
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+)
+
+// EventFilter selects events; empty fields match everything
+type EventFilter struct {
+	CandidateID string
+	JobIDs      []string
+	Kinds       []string
+	From        time.Time // events ending after From
+	To          time.Time // events starting before To
+	Limit       int
+}
+
+// EventRepository stores the caller's Event nodes attached to jobs and candidates
+type EventRepository interface {
+	// CreateEvent stores the event with FOR_JOB and, when CandidateID is set,
+	// HAS_EVENT edges and returns it with its job; created is false when the
+	// job or candidate does not exist
+	CreateEvent(ctx context.Context, event domain.Event) (stored domain.Event, created bool, err error)
+	// ListEvents returns matching events with their job, earliest first
+	ListEvents(ctx context.Context, filter EventFilter) ([]domain.Event, error)
+}
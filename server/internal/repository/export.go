@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+)
+
+// ExportFilter selects the jobs included in a graph export. Empty fields do not filter
+type ExportFilter struct {
+	JobIDs    []string
+	TimeField string // TimeFieldFetchedAt (default) or TimeFieldPostedAt
+	From      time.Time
+	To        time.Time
+}
+
+// ExportedKeyword is a HAS_KEYWORD edge with its Keyword node
+type ExportedKeyword struct {
+	Value      string  `json:"value"`
+	Notes      string  `json:"notes,omitempty"`
+	Source     string  `json:"source,omitempty"`
+	Confidence float64 `json:"confidence,omitempty"`
+}
+
+// ExportedJob is a job with its company, skills and keywords
+type ExportedJob struct {
+	Job      domain.Job
+	Keywords []ExportedKeyword
+}
+
+// ExportRepository reads the Job/Company/Skill/Keyword subgraph for export,
+// with the caller's keywords only
+type ExportRepository interface {
+	ExportJobs(ctx context.Context, filter ExportFilter) ([]ExportedJob, error)
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+)
+
+// Reasons recorded in Job.closedReason
+const (
+	// ClosedReasonMissing means the posting dropped out of refresh results;
+	// it is reopened when a provider returns it again
+	ClosedReasonMissing = "missing"
+	// ClosedReasonProvider means the provider reported the posting gone
+	ClosedReasonProvider = "provider"
+)
+
+// Refresh is the set of postings a provider returned for a repeated query
+type Refresh struct {
+	Source      string
+	QueryKey    string
+	ExternalIDs []string
+	At          time.Time
+	// CloseAfter is the number of consecutive misses after which a job is closed
+	CloseAfter int
+}
+
+// RefreshOutcome counts jobs that a refresh no longer returned
+type RefreshOutcome struct {
+	Missing int
+	Closed  int
+}
+
+// StatusFilter selects jobs for a lifecycle check; empty fields match everything
+type StatusFilter struct {
+	JobIDs   []string
+	Statuses []string // jobs without a status match domain.JobStatusOpen
+	Limit    int
+}
+
+// StatusUpdate sets the lifecycle status of a job
+type StatusUpdate struct {
+	JobID  string
+	Status string
+	At     time.Time
+}
+
+// LifecycleRepository tracks whether stored postings are still listed
+type LifecycleRepository interface {
+	// RecordRefresh tags the returned postings with the query key and marks
+	// previously tagged postings of the same source that were not returned
+	RecordRefresh(ctx context.Context, refresh Refresh) (RefreshOutcome, error)
+	// ListJobsByStatus returns jobs to re-check, least recently seen first
+	ListJobsByStatus(ctx context.Context, filter StatusFilter) ([]domain.Job, error)
+	// SetJobStatuses applies provider check results
+	SetJobStatuses(ctx context.Context, updates []StatusUpdate) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// NotificationRepository remembers which jobs were announced on which
+// notification channel, per owner of the announcing searches
+type NotificationRepository interface {
+	// Unannounced returns the jobIDs that were not yet announced on channel
+	// for the caller, in the given order
+	Unannounced(ctx context.Context, channel string, jobIDs []string) ([]string, error)
+	// MarkAnnounced records that the jobs were announced on channel for the
+	// caller
+	MarkAnnounced(ctx context.Context, channel string, jobIDs []string, at time.Time) error
+}
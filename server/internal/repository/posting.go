@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+)
+
+// RepostCandidates pairs a job with the other postings of its company
+type RepostCandidates struct {
+	Job    domain.Job
+	Others []domain.Job
+}
+
+// RepostLink marks Job as a re-listing of Original
+type RepostLink struct {
+	JobID      string
+	OriginalID string
+	Similarity float64
+}
+
+// PostingFilter selects jobs for posting behavior lookups; empty fields match everything
+type PostingFilter struct {
+	JobIDs    []string
+	CompanyID string
+}
+
+// PostingSignals is a job with the posting behavior recorded in the graph
+type PostingSignals struct {
+	Job     domain.Job
+	Reposts []string // IDs of jobs linked through REPOST_OF in either direction
+}
+
+// PostingRepository tracks how postings are re-listed over time
+type PostingRepository interface {
+	// ListRepostCandidates returns, for each job, the other jobs of the same company
+	ListRepostCandidates(ctx context.Context, jobIDs []string) ([]RepostCandidates, error)
+	// LinkReposts records REPOST_OF relationships
+	LinkReposts(ctx context.Context, links []RepostLink) error
+	// ListPostingSignals returns the jobs matching filter with their company, skills and reposts
+	ListPostingSignals(ctx context.Context, filter PostingFilter) ([]PostingSignals, error)
+}
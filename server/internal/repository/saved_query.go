@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+)
+
+// SavedQueryRepository loads saved queries stored as (:SavedQuery) nodes
+type SavedQueryRepository interface {
+	ListSavedQueries(ctx context.Context) ([]domain.SavedQuery, error)
+}
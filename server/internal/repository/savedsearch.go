@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+)
+
+// SavedSearchFilter selects saved searches; empty fields match everything
+type SavedSearchFilter struct {
+	IDs       []string
+	DueBefore time.Time // enabled searches whose next run is at or before DueBefore
+	AllOwners bool      // every user's searches instead of the caller's
+	Limit     int
+}
+
+// SavedSearchRepository stores SavedSearch nodes and the jobs their runs
+// found. Every method is scoped to the caller's searches (see identity.OwnerID)
+type SavedSearchRepository interface {
+	// SaveSearch creates or updates the search definition, keeping its run state
+	SaveSearch(ctx context.Context, search domain.SavedSearch) (domain.SavedSearch, error)
+	// ListSearches returns matching searches, by name
+	ListSearches(ctx context.Context, filter SavedSearchFilter) ([]domain.SavedSearch, error)
+	// DeleteSearch removes the search and its FOUND edges
+	DeleteSearch(ctx context.Context, id string) (bool, error)
+	// ClaimRun takes the run lease until leaseUntil; claimed is false when
+	// the search does not exist or another run holds an unexpired lease
+	ClaimRun(ctx context.Context, id string, now, leaseUntil time.Time) (claimed bool, err error)
+	// FinishRun links the returned jobs with FOUND edges, records the outcome
+	// and releases the lease. It returns the number of jobs found for the first time
+	FinishRun(ctx context.Context, run domain.SearchRun) (newJobs int, err error)
+	// ListNewJobs returns jobs the search first found at or after since, newest first
+	ListNewJobs(ctx context.Context, id string, since time.Time, limit int) ([]domain.Job, error)
+}
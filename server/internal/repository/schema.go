@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// PropertySchema describes a property key and the value types observed for it
+type PropertySchema struct {
+	Key       string   `json:"key"`
+	Types     []string `json:"types"`
+	Mandatory bool     `json:"mandatory"`
+}
+
+// LabelSchema describes a node label
+type LabelSchema struct {
+	Label      string           `json:"label"`
+	Count      int64            `json:"count"`
+	Properties []PropertySchema `json:"properties"`
+}
+
+// RelationshipPattern is a (:From)-[:TYPE]->(:To) combination present in the graph
+type RelationshipPattern struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Count int64  `json:"count"`
+}
+
+// RelationshipSchema describes a relationship type
+type RelationshipSchema struct {
+	Type       string                `json:"type"`
+	Count      int64                 `json:"count"`
+	Patterns   []RelationshipPattern `json:"patterns"`
+	Properties []PropertySchema      `json:"properties"`
+}
+
+// ConstraintSchema describes a schema constraint
+type ConstraintSchema struct {
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	EntityType string   `json:"entity_type"`
+	Labels     []string `json:"labels"`
+	Properties []string `json:"properties"`
+}
+
+// GraphSchema is a snapshot of the live graph schema
+type GraphSchema struct {
+	Labels        []LabelSchema        `json:"labels"`
+	Relationships []RelationshipSchema `json:"relationships"`
+	PropertyKeys  []string             `json:"property_keys"`
+	Constraints   []ConstraintSchema   `json:"constraints"`
+	FetchedAt     time.Time            `json:"fetched_at"`
+}
+
+// SchemaRepository introspects the graph schema
+type SchemaRepository interface {
+	GetGraphSchema(ctx context.Context) (GraphSchema, error)
+}
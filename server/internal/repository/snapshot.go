@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+)
+
+// SnapshotRepository reads the stored versions of a job
+type SnapshotRepository interface {
+	// ListSnapshots returns the job and its snapshots, oldest first; found is
+	// false when no job has the ID
+	ListSnapshots(ctx context.Context, jobID string) (job domain.Job, snapshots []domain.JobSnapshot, found bool, err error)
+}
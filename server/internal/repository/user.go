@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+)
+
+// UserRepository maps token subjects to users
+type UserRepository interface {
+	// ResolveUser returns the user for user.Issuer and user.Subject, creating
+	// it with user.ID when it does not exist yet. Email and Name are refreshed
+	ResolveUser(ctx context.Context, user domain.User, at time.Time) (domain.User, error)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 
 	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/identity"
 	"github.com/honeycarbs/project-ets/internal/repository"
 	"github.com/honeycarbs/project-ets/pkg/logging"
 	pkgneo4j "github.com/honeycarbs/project-ets/pkg/neo4j"
@@ -30,7 +31,7 @@ func NewAnalysisRepository(client *pkgneo4j.Client, logger *logging.Logger) *Ana
 	}
 }
 
-// GetJobSubgraphs retrieves jobs with their skills and keywords
+// GetJobSubgraphs retrieves jobs with their skills and the caller's keywords
 func (r *AnalysisRepository) GetJobSubgraphs(ctx context.Context, jobIDs []string) ([]repository.JobSubgraph, error) {
 	if len(jobIDs) == 0 {
 		return nil, nil
@@ -70,12 +71,16 @@ func (r *AnalysisRepository) GetJobSubgraphs(ctx context.Context, jobIDs []strin
 		OPTIONAL MATCH (j)-[:WORKED_AT]->(c:Company)
 		OPTIONAL MATCH (j)-[:REQUIRES]->(s:Skill)
 		OPTIONAL MATCH (j)-[hk:HAS_KEYWORD]->(k:Keyword)
+		WHERE coalesce(hk.ownerId, '') = $ownerId
 		RETURN j, c,
 		       collect(DISTINCT s) as skills,
-		       collect(DISTINCT {value: k.value, source: hk.source}) as keywords
+		       collect(DISTINCT {value: k.value, source: hk.source, confidence: hk.confidence}) as keywords
 	`
 
-	params := map[string]interface{}{"ids": jobIDs}
+	params := map[string]interface{}{
+		"ids":     jobIDs,
+		"ownerId": identity.OwnerID(ctx),
+	}
 
 	r.logger.Info("AnalysisRepository.GetJobSubgraphs: executing Neo4j query",
 		"job_ids", jobIDs,
@@ -133,19 +138,39 @@ func (r *AnalysisRepository) GetJobSubgraphs(ctx context.Context, jobIDs []strin
 	return subgraphs, nil
 }
 
-// FindRelatedJobs finds jobs connected via shared skills
+// FindRelatedJobs finds jobs connected via shared skills, the caller's
+// keywords or company
 func (r *AnalysisRepository) FindRelatedJobs(ctx context.Context, jobID string, limit int) ([]repository.RelatedJob, error) {
 	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
 	defer session.Close(ctx)
 
+	// Each branch expands from j through one kind of shared neighbour, so
+	// only jobs reachable from j are visited; the rows are then folded into
+	// one per related job
 	query := `
-		MATCH (j:Job {id: $jobId})-[:REQUIRES]->(s:Skill)<-[:REQUIRES]-(related:Job)
-		WHERE related.id <> $jobId
-		WITH related, collect(DISTINCT s.name) as sharedSkills
-		OPTIONAL MATCH (j:Job {id: $jobId})-[:HAS_KEYWORD]->(k:Keyword)<-[:HAS_KEYWORD]-(related)
-		WITH related, sharedSkills, collect(DISTINCT k.value) as sharedKeywords
-		RETURN related, sharedSkills, sharedKeywords,
-		       (size(sharedSkills) * 2 + size(sharedKeywords)) as relevance
+		MATCH (j:Job {id: $jobId})
+		CALL {
+			WITH j
+			MATCH (j)-[:REQUIRES]->(s:Skill)<-[:REQUIRES]-(related:Job)
+			WHERE related <> j
+			RETURN related, s.name as skill, null as keyword, false as sameCompany
+			UNION ALL
+			WITH j
+			MATCH (j)-[a:HAS_KEYWORD]->(k:Keyword)<-[b:HAS_KEYWORD]-(related:Job)
+			WHERE related <> j AND coalesce(a.ownerId, '') = $ownerId AND coalesce(b.ownerId, '') = $ownerId
+			RETURN related, null as skill, k.value as keyword, false as sameCompany
+			UNION ALL
+			WITH j
+			MATCH (j)-[:WORKED_AT]->(:Company)<-[:WORKED_AT]-(related:Job)
+			WHERE related <> j
+			RETURN related, null as skill, null as keyword, true as sameCompany
+		}
+		WITH related, collect(DISTINCT skill) as sharedSkills, collect(DISTINCT keyword) as sharedKeywords,
+		     any(same IN collect(sameCompany) WHERE same) as sameCompany
+		OPTIONAL MATCH (related)-[:WORKED_AT]->(rc:Company)
+		WITH related, head(collect(rc)) as rc, sharedSkills, sharedKeywords, sameCompany
+		RETURN related as j, rc as c, sharedSkills, sharedKeywords, sameCompany,
+		       (size(sharedSkills) * 2 + size(sharedKeywords) + CASE WHEN sameCompany THEN 1 ELSE 0 END) as relevance
 		ORDER BY relevance DESC
 		LIMIT $limit
 	`
@@ -153,8 +178,9 @@ func (r *AnalysisRepository) FindRelatedJobs(ctx context.Context, jobID string,
 	// Collect all records INSIDE the transaction
 	records, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
 		res, err := tx.Run(ctx, query, map[string]interface{}{
-			"jobId": jobID,
-			"limit": limit,
+			"jobId":   jobID,
+			"ownerId": identity.OwnerID(ctx),
+			"limit":   limit,
 		})
 		if err != nil {
 			return nil, err
@@ -361,6 +387,11 @@ func (r *AnalysisRepository) parseJobNode(record *neo4j.Record) (domain.Job, err
 		Description: getStringProp(props, "description"),
 		Score:       getFloatProp(props, "score"),
 		FetchedAt:   getTimeProp(props, "fetchedAt"),
+		FirstSeenAt: getTimeProp(props, "firstSeenAt"),
+		LastSeenAt:  getTimeProp(props, "lastSeenAt"),
+		SeenCount:   getIntProp(props, "seenCount"),
+		Status:      getStringProp(props, "status"),
+		ClosedAt:    getTimeProp(props, "closedAt"),
 	}, nil
 }
 
@@ -423,8 +454,9 @@ func (r *AnalysisRepository) parseKeywordNodes(record *neo4j.Record) []repositor
 				continue
 			}
 			keywords = append(keywords, repository.KeywordNode{
-				Value:  value,
-				Source: getStringFromMap(kwMap, "source"),
+				Value:      value,
+				Source:     getStringFromMap(kwMap, "source"),
+				Confidence: getFloatProp(kwMap, "confidence"),
 			})
 		}
 	}
@@ -440,6 +472,8 @@ func (r *AnalysisRepository) parseRelatedRecords(ctx context.Context, records []
 			continue
 		}
 
+		job.Company = r.parseCompanyNode(record)
+
 		sharedSkills := getStringSlice(record, "sharedSkills")
 		sharedKeywords := getStringSlice(record, "sharedKeywords")
 		relevance := getRecordFloat(record, "relevance")
@@ -448,6 +482,7 @@ func (r *AnalysisRepository) parseRelatedRecords(ctx context.Context, records []
 			Job:            job,
 			SharedSkills:   sharedSkills,
 			SharedKeywords: sharedKeywords,
+			SameCompany:    getRecordBool(record, "sameCompany"),
 			Relevance:      relevance,
 		})
 	}
@@ -500,6 +535,15 @@ func getFloatProp(props map[string]interface{}, key string) float64 {
 	return 0
 }
 
+func getIntProp(props map[string]interface{}, key string) int {
+	if v, ok := props[key]; ok {
+		if i, ok := v.(int64); ok {
+			return int(i)
+		}
+	}
+	return 0
+}
+
 func getTimeProp(props map[string]interface{}, key string) time.Time {
 	if v, ok := props[key]; ok {
 		if t, ok := v.(time.Time); ok {
@@ -554,3 +598,12 @@ func getRecordFloat(record *neo4j.Record, key string) float64 {
 	}
 	return 0
 }
+
+func getRecordBool(record *neo4j.Record, key string) bool {
+	val, ok := record.Get(key)
+	if !ok || val == nil {
+		return false
+	}
+	b, _ := val.(bool)
+	return b
+}
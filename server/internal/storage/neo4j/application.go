@@ -0,0 +1,194 @@
+package neo4j
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/identity"
+	"github.com/honeycarbs/project-ets/internal/repository"
+	pkgneo4j "github.com/honeycarbs/project-ets/pkg/neo4j"
+)
+
+var _ repository.ApplicationRepository = (*ApplicationRepository)(nil)
+
+// ApplicationRepository implements repository.ApplicationRepository with Neo4j
+type ApplicationRepository struct {
+	client *pkgneo4j.Client
+}
+
+// NewApplicationRepository creates an ApplicationRepository with a Neo4j client
+func NewApplicationRepository(client *pkgneo4j.Client) *ApplicationRepository {
+	return &ApplicationRepository{
+		client: client,
+	}
+}
+
+// GetApplication returns the APPLIED_TO relationship between the candidate and the job
+func (r *ApplicationRepository) GetApplication(ctx context.Context, candidateID, jobID string) (domain.Application, bool, error) {
+	apps, err := r.ListApplications(ctx, repository.ApplicationFilter{
+		CandidateID: candidateID,
+		JobIDs:      []string{jobID},
+		Limit:       1,
+	})
+	if err != nil {
+		return domain.Application{}, false, err
+	}
+	if len(apps) == 0 {
+		return domain.Application{}, false, nil
+	}
+	return apps[0], true, nil
+}
+
+// SaveApplication merges the APPLIED_TO relationship, guarded by the status
+// the caller read. Neo4j properties cannot hold nested maps, so the
+// transition history is stored as JSON
+func (r *ApplicationRepository) SaveApplication(ctx context.Context, app domain.Application, expectedStatus string) (bool, error) {
+	history, err := json.Marshal(app.History)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode application history: %w", err)
+	}
+
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	// A new application creates the relationship; an update must find it
+	bind := "MATCH"
+	if expectedStatus == "" {
+		bind = "MERGE"
+	}
+	query := `
+		MATCH (c:Candidate {id: $candidateId})
+		WHERE coalesce(c.ownerId, '') = $ownerId
+		MATCH (j:Job {id: $jobId})
+		` + bind + ` (c)-[a:APPLIED_TO]->(j)
+		WITH a, coalesce(a.status, '') = $expected as current
+		FOREACH (_ IN CASE WHEN current THEN [1] ELSE [] END |
+			SET a.status = $status,
+			    a.appliedAt = CASE WHEN $appliedAt IS NULL THEN null ELSE datetime({epochMillis: $appliedAt}) END,
+			    a.updatedAt = datetime({epochMillis: $updatedAt}),
+			    a.history = $history
+		)
+		RETURN current
+	`
+
+	params := map[string]interface{}{
+		"candidateId": app.CandidateID,
+		"ownerId":     identity.OwnerID(ctx),
+		"jobId":       app.Job.ID.String(),
+		"expected":    expectedStatus,
+		"status":      app.Status,
+		"appliedAt":   nil,
+		"updatedAt":   app.UpdatedAt.UnixMilli(),
+		"history":     string(history),
+	}
+	if !app.AppliedAt.IsZero() {
+		params["appliedAt"] = app.AppliedAt.UnixMilli()
+	}
+
+	result, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		return res.Collect(ctx)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	records := result.([]*neo4j.Record)
+	if len(records) == 0 {
+		return false, nil
+	}
+	return getRecordBool(records[0], "current"), nil
+}
+
+// ListApplications returns the caller's applications with their job and
+// company, most recently updated first
+func (r *ApplicationRepository) ListApplications(ctx context.Context, filter repository.ApplicationFilter) ([]domain.Application, error) {
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (cand:Candidate)-[a:APPLIED_TO]->(j:Job)
+		WHERE coalesce(cand.ownerId, '') = $ownerId
+		  AND ($candidateId = '' OR cand.id = $candidateId)
+		  AND (size($jobIds) = 0 OR j.id IN $jobIds)
+		  AND (size($statuses) = 0 OR a.status IN $statuses)
+		OPTIONAL MATCH (j)-[:WORKED_AT]->(c:Company)
+		WITH cand, a, j, head(collect(c)) as c
+		RETURN cand.id as candidateId, a, j, c
+		ORDER BY a.updatedAt DESC
+		LIMIT $limit
+	`
+
+	params := map[string]interface{}{
+		"candidateId": filter.CandidateID,
+		"ownerId":     identity.OwnerID(ctx),
+		"jobIds":      filter.JobIDs,
+		"statuses":    filter.Statuses,
+		"limit":       filter.Limit,
+	}
+	if filter.JobIDs == nil {
+		params["jobIds"] = []string{}
+	}
+	if filter.Statuses == nil {
+		params["statuses"] = []string{}
+	}
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		return res.Collect(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records := result.([]*neo4j.Record)
+	apps := make([]domain.Application, 0, len(records))
+	for _, record := range records {
+		app, ok, err := applicationFromRecord(record)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			apps = append(apps, app)
+		}
+	}
+
+	return apps, nil
+}
+
+// applicationFromRecord reads the candidateId, a, j and c columns
+func applicationFromRecord(record *neo4j.Record) (domain.Application, bool, error) {
+	job, ok := jobWithNeighbors(record)
+	if !ok {
+		return domain.Application{}, false, nil
+	}
+	relVal, _ := record.Get("a")
+	rel, ok := relVal.(neo4j.Relationship)
+	if !ok {
+		return domain.Application{}, false, nil
+	}
+
+	app := domain.Application{
+		CandidateID: getRecordString(record, "candidateId"),
+		Job:         job,
+		Status:      getStringProp(rel.Props, "status"),
+		AppliedAt:   getTimeProp(rel.Props, "appliedAt"),
+		UpdatedAt:   getTimeProp(rel.Props, "updatedAt"),
+	}
+	if raw := getStringProp(rel.Props, "history"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &app.History); err != nil {
+			return domain.Application{}, false, fmt.Errorf("failed to decode history for application %s/%s: %w", app.CandidateID, job.ID, err)
+		}
+	}
+	return app, true, nil
+}
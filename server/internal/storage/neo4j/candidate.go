@@ -0,0 +1,216 @@
+package neo4j
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/identity"
+	"github.com/honeycarbs/project-ets/internal/repository"
+	pkgneo4j "github.com/honeycarbs/project-ets/pkg/neo4j"
+)
+
+// Ensure CandidateRepository implements repository.CandidateRepository
+var _ repository.CandidateRepository = (*CandidateRepository)(nil)
+
+// CandidateRepository implements repository.CandidateRepository with Neo4j
+type CandidateRepository struct {
+	client *pkgneo4j.Client
+}
+
+// NewCandidateRepository creates a CandidateRepository with a Neo4j client
+func NewCandidateRepository(client *pkgneo4j.Client) *CandidateRepository {
+	return &CandidateRepository{
+		client: client,
+	}
+}
+
+// UpsertCandidate merges the caller's candidate node and replaces its HAS_SKILL edges
+func (r *CandidateRepository) UpsertCandidate(ctx context.Context, candidate domain.Candidate) error {
+	if candidate.ID == "" {
+		return fmt.Errorf("candidate id is required")
+	}
+
+	// Neo4j properties cannot hold nested maps, so experience is stored as JSON
+	experience, err := json.Marshal(candidate.Experience)
+	if err != nil {
+		return fmt.Errorf("failed to encode experience: %w", err)
+	}
+
+	skillsData := make([]map[string]interface{}, 0, len(candidate.Skills))
+	for _, skill := range candidate.Skills {
+		skillsData = append(skillsData, map[string]interface{}{
+			"id":   skill.ID,
+			"name": skill.Name,
+		})
+	}
+
+	var remote interface{}
+	if candidate.Preferences.Remote != nil {
+		remote = *candidate.Preferences.Remote
+	}
+
+	// Candidate IDs are chosen by the caller, so the node is keyed by owner
+	// too. Profiles stored before ownership was tracked have no ownerId; a
+	// single-user server adopts them instead of creating a duplicate
+	query := `
+		OPTIONAL MATCH (legacy:Candidate {id: $candidate.id})
+		WHERE legacy.ownerId IS NULL AND $ownerId = ''
+		SET legacy.ownerId = $ownerId
+		WITH count(*) as adopted
+		MERGE (c:Candidate {id: $candidate.id, ownerId: $ownerId})
+		SET c.name = $candidate.name,
+		    c.headline = $candidate.headline,
+		    c.summary = $candidate.summary,
+		    c.experience = $candidate.experience,
+		    c.preferredLocations = $candidate.locations,
+		    c.salaryFloor = $candidate.salaryFloor,
+		    c.remotePreference = $candidate.remote,
+		    c.updatedAt = datetime({epochMillis: $candidate.updatedAt})
+		WITH c
+		OPTIONAL MATCH (c)-[old:HAS_SKILL]->(:Skill)
+		DELETE old
+		WITH DISTINCT c
+		FOREACH (skill IN $candidate.skills |
+			MERGE (s:Skill {id: skill.id})
+			ON CREATE SET s.name = skill.name
+			MERGE (c)-[:HAS_SKILL]->(s)
+		)
+	`
+
+	params := map[string]interface{}{
+		"candidate": map[string]interface{}{
+			"id":          candidate.ID,
+			"name":        candidate.Name,
+			"headline":    candidate.Headline,
+			"summary":     candidate.Summary,
+			"experience":  string(experience),
+			"locations":   candidate.Preferences.Locations,
+			"salaryFloor": candidate.Preferences.SalaryFloor,
+			"remote":      remote,
+			"updatedAt":   candidate.UpdatedAt.UnixMilli(),
+			"skills":      skillsData,
+		},
+		"ownerId": identity.OwnerID(ctx),
+	}
+
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute candidate upsert query: %w", err)
+		}
+		return result.Consume(ctx)
+	})
+
+	return err
+}
+
+// GetCandidate loads one of the caller's candidates and its skills; the bool
+// reports whether it exists
+func (r *CandidateRepository) GetCandidate(ctx context.Context, id string) (domain.Candidate, bool, error) {
+	if id == "" {
+		return domain.Candidate{}, false, nil
+	}
+
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (c:Candidate {id: $id})
+		WHERE coalesce(c.ownerId, '') = $ownerId
+		OPTIONAL MATCH (c)-[:HAS_SKILL]->(s:Skill)
+		RETURN c, collect(DISTINCT s) as skills
+	`
+
+	record, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, query, map[string]interface{}{
+			"id":      id,
+			"ownerId": identity.OwnerID(ctx),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if res.Next(ctx) {
+			return res.Record(), nil
+		}
+		return nil, res.Err()
+	})
+	if err != nil {
+		return domain.Candidate{}, false, err
+	}
+	if record == nil {
+		return domain.Candidate{}, false, nil
+	}
+
+	candidate, err := parseCandidateRecord(record.(*neo4j.Record))
+	if err != nil {
+		return domain.Candidate{}, false, err
+	}
+	return candidate, true, nil
+}
+
+func parseCandidateRecord(record *neo4j.Record) (domain.Candidate, error) {
+	nodeVal, _ := record.Get("c")
+	node, ok := nodeVal.(neo4j.Node)
+	if !ok {
+		return domain.Candidate{}, fmt.Errorf("unexpected candidate value %T", nodeVal)
+	}
+	props := node.Props
+
+	candidate := domain.Candidate{
+		ID:        getStringProp(props, "id"),
+		Name:      getStringProp(props, "name"),
+		Headline:  getStringProp(props, "headline"),
+		Summary:   getStringProp(props, "summary"),
+		UpdatedAt: getTimeProp(props, "updatedAt"),
+		Preferences: domain.CandidatePreferences{
+			Locations:   getStringListProp(props, "preferredLocations"),
+			SalaryFloor: getFloatProp(props, "salaryFloor"),
+		},
+	}
+	if v, ok := props["remotePreference"].(bool); ok {
+		candidate.Preferences.Remote = &v
+	}
+
+	if raw := getStringProp(props, "experience"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &candidate.Experience); err != nil {
+			return domain.Candidate{}, fmt.Errorf("failed to decode experience for candidate %s: %w", candidate.ID, err)
+		}
+	}
+
+	if skillsVal, ok := record.Get("skills"); ok {
+		if skillsList, ok := skillsVal.([]interface{}); ok {
+			for _, sv := range skillsList {
+				if skillNode, ok := sv.(neo4j.Node); ok {
+					candidate.Skills = append(candidate.Skills, domain.SkillRef{
+						ID:   getStringProp(skillNode.Props, "id"),
+						Name: getStringProp(skillNode.Props, "name"),
+					})
+				}
+			}
+		}
+	}
+
+	return candidate, nil
+}
+
+func getStringListProp(props map[string]interface{}, key string) []string {
+	list, ok := props[key].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	result := make([]string, 0, len(list))
+	for _, v := range list {
+		if s, ok := v.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -0,0 +1,300 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/repository"
+	pkgneo4j "github.com/honeycarbs/project-ets/pkg/neo4j"
+)
+
+var _ repository.CompanyRepository = (*CompanyRepository)(nil)
+
+// CompanyRepository implements repository.CompanyRepository with Neo4j
+type CompanyRepository struct {
+	client *pkgneo4j.Client
+}
+
+// NewCompanyRepository creates a CompanyRepository with a Neo4j client
+func NewCompanyRepository(client *pkgneo4j.Client) *CompanyRepository {
+	return &CompanyRepository{
+		client: client,
+	}
+}
+
+// EnsureCompanyAliases creates the unique constraint that indexes
+// CompanyAlias nodes and backfills them from the aliases lists of companies
+// merged before alias nodes existed. It is safe to run on every start
+func EnsureCompanyAliases(ctx context.Context, client *pkgneo4j.Client) error {
+	session := client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	constraint := `
+		CREATE CONSTRAINT company_alias_id IF NOT EXISTS
+		FOR (a:CompanyAlias) REQUIRE a.id IS UNIQUE
+	`
+	backfill := `
+		MATCH (c:Company)
+		WHERE size(coalesce(c.aliases, [])) > 0
+		UNWIND c.aliases AS alias
+		MERGE (a:CompanyAlias {id: alias})
+		MERGE (a)-[:ALIAS_OF]->(c)
+	`
+
+	// Schema and data changes cannot share a transaction
+	for _, query := range []string{constraint, backfill} {
+		_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+			res, err := tx.Run(ctx, query, nil)
+			if err != nil {
+				return nil, err
+			}
+			return res.Consume(ctx)
+		})
+		if err != nil {
+			return fmt.Errorf("ensure company aliases: %w", err)
+		}
+	}
+	return nil
+}
+
+// resolveCompanyAliases maps each of ids that is the alias of a merged
+// company to the company's ID, with one indexed lookup for the whole batch
+func resolveCompanyAliases(ctx context.Context, tx neo4j.ManagedTransaction, ids []string) (map[string]interface{}, error) {
+	aliases := make(map[string]interface{})
+	if len(ids) == 0 {
+		return aliases, nil
+	}
+
+	query := `
+		MATCH (a:CompanyAlias)-[:ALIAS_OF]->(c:Company)
+		WHERE a.id IN $ids
+		RETURN a.id as alias, c.id as companyId
+	`
+	res, err := tx.Run(ctx, query, map[string]interface{}{"ids": ids})
+	if err != nil {
+		return nil, err
+	}
+	for res.Next(ctx) {
+		record := res.Record()
+		alias, _ := record.Get("alias")
+		companyID, _ := record.Get("companyId")
+		if a, ok := alias.(string); ok {
+			aliases[a] = companyID
+		}
+	}
+	return aliases, res.Err()
+}
+
+// ResolveCompany finds the company whose ID or alias is id, preferring an exact ID
+func (r *CompanyRepository) ResolveCompany(ctx context.Context, id string) (repository.CompanyRecord, bool, error) {
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := `
+		OPTIONAL MATCH (exact:Company {id: $id})
+		OPTIONAL MATCH (:CompanyAlias {id: $id})-[:ALIAS_OF]->(merged:Company)
+		WITH coalesce(exact, merged) as c
+		WHERE c IS NOT NULL
+		RETURN c
+		LIMIT 1
+	`
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, query, map[string]interface{}{"id": id})
+		if err != nil {
+			return nil, err
+		}
+		return res.Collect(ctx)
+	})
+	if err != nil {
+		return repository.CompanyRecord{}, false, err
+	}
+
+	records := result.([]*neo4j.Record)
+	if len(records) == 0 {
+		return repository.CompanyRecord{}, false, nil
+	}
+	val, _ := records[0].Get("c")
+	node, ok := val.(neo4j.Node)
+	if !ok {
+		return repository.CompanyRecord{}, false, nil
+	}
+	return companyFromNode(node), true, nil
+}
+
+// ListCompanyJobs returns the company's jobs with their skills, newest first
+func (r *CompanyRepository) ListCompanyJobs(ctx context.Context, companyID string) ([]domain.Job, error) {
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (j:Job)-[:WORKED_AT]->(c:Company {id: $companyId})
+		OPTIONAL MATCH (j)-[:REQUIRES]->(s:Skill)
+		WITH j, c, collect(DISTINCT s) as skills
+		RETURN j, c, skills
+		ORDER BY j.postedAt DESC
+	`
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, query, map[string]interface{}{"companyId": companyID})
+		if err != nil {
+			return nil, err
+		}
+		return res.Collect(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records := result.([]*neo4j.Record)
+	jobs := make([]domain.Job, 0, len(records))
+	for _, record := range records {
+		jobVal, _ := record.Get("j")
+		jobNode, ok := jobVal.(neo4j.Node)
+		if !ok {
+			continue
+		}
+		job, ok := jobFromNode(jobNode)
+		if !ok {
+			continue
+		}
+		if companyVal, ok := record.Get("c"); ok {
+			if companyNode, ok := companyVal.(neo4j.Node); ok {
+				job.Company = domain.CompanyRef{
+					ID:   getStringProp(companyNode.Props, "id"),
+					Name: getStringProp(companyNode.Props, "name"),
+				}
+			}
+		}
+		if skillsVal, ok := record.Get("skills"); ok {
+			if skillsList, ok := skillsVal.([]interface{}); ok {
+				for _, skillVal := range skillsList {
+					if skillNode, ok := skillVal.(neo4j.Node); ok {
+						job.Skills = append(job.Skills, domain.SkillRef{
+							ID:   getStringProp(skillNode.Props, "id"),
+							Name: getStringProp(skillNode.Props, "name"),
+						})
+					}
+				}
+			}
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// MergeCompanies folds source companies, with their postings and contacts, into the target in a single transaction.
+// Source IDs without a node are still recorded as aliases so later postings resolve to the target. Every alias also
+// gets a CompanyAlias node pointing at the target, which is what ingestion looks aliases up by
+func (r *CompanyRepository) MergeCompanies(ctx context.Context, targetID string, sourceIDs []string) (repository.CompanyRecord, error) {
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	params := map[string]interface{}{
+		"targetId":  targetID,
+		"sourceIds": sourceIDs,
+	}
+
+	moveJobs := `
+		MATCH (t:Company {id: $targetId})
+		MATCH (j:Job)-[rel:WORKED_AT]->(s:Company)
+		WHERE s.id IN $sourceIds AND s.id <> t.id
+		MERGE (j)-[:WORKED_AT]->(t)
+		DELETE rel
+	`
+
+	moveContacts := `
+		MATCH (t:Company {id: $targetId})
+		MATCH (ct:Contact)-[rel:WORKS_AT]->(s:Company)
+		WHERE s.id IN $sourceIds AND s.id <> t.id
+		MERGE (ct)-[:WORKS_AT]->(t)
+		DELETE rel
+	`
+
+	mergeNodes := `
+		MATCH (t:Company {id: $targetId})
+		OPTIONAL MATCH (s:Company)
+		WHERE s.id IN $sourceIds AND s.id <> t.id
+		WITH t, collect(s) as sources
+		WITH t, sources,
+		     $sourceIds + reduce(acc = [], s IN sources | acc + coalesce(s.aliases, [])) as newAliases,
+		     reduce(acc = [], s IN sources | acc + coalesce(s.names, []) + CASE WHEN s.name IS NULL THEN [] ELSE [s.name] END) as newNames
+		SET t.aliases = reduce(acc = coalesce(t.aliases, []), a IN newAliases |
+		                  CASE WHEN a = t.id OR a IN acc THEN acc ELSE acc + a END),
+		    t.names = reduce(acc = coalesce(t.names, []), n IN newNames |
+		                  CASE WHEN n = "" OR n IN acc THEN acc ELSE acc + n END)
+		FOREACH (s IN sources | DETACH DELETE s)
+		RETURN t as c
+	`
+
+	// Aliases of the sources lost their ALIAS_OF edges with the source nodes;
+	// an alias pointing at another company is moved, as the merge claims it
+	linkAliases := `
+		MATCH (t:Company {id: $targetId})
+		UNWIND coalesce(t.aliases, []) AS alias
+		MERGE (a:CompanyAlias {id: alias})
+		WITH t, a
+		OPTIONAL MATCH (a)-[old:ALIAS_OF]->(other:Company)
+		WHERE other <> t
+		DELETE old
+		WITH DISTINCT t, a
+		MERGE (a)-[:ALIAS_OF]->(t)
+	`
+
+	result, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		for _, move := range []string{moveJobs, moveContacts} {
+			res, err := tx.Run(ctx, move, params)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := res.Consume(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		res, err := tx.Run(ctx, mergeNodes, params)
+		if err != nil {
+			return nil, err
+		}
+		records, err := res.Collect(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err = tx.Run(ctx, linkAliases, params)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := res.Consume(ctx); err != nil {
+			return nil, err
+		}
+		return records, nil
+	})
+	if err != nil {
+		return repository.CompanyRecord{}, err
+	}
+
+	records := result.([]*neo4j.Record)
+	if len(records) == 0 {
+		return repository.CompanyRecord{}, fmt.Errorf("company %q not found", targetID)
+	}
+	val, _ := records[0].Get("c")
+	node, ok := val.(neo4j.Node)
+	if !ok {
+		return repository.CompanyRecord{}, fmt.Errorf("company %q not found", targetID)
+	}
+	return companyFromNode(node), nil
+}
+
+func companyFromNode(node neo4j.Node) repository.CompanyRecord {
+	return repository.CompanyRecord{
+		ID:      getStringProp(node.Props, "id"),
+		Name:    getStringProp(node.Props, "name"),
+		Names:   getStringListProp(node.Props, "names"),
+		Aliases: getStringListProp(node.Props, "aliases"),
+	}
+}
@@ -0,0 +1,278 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/identity"
+	"github.com/honeycarbs/project-ets/internal/repository"
+	pkgneo4j "github.com/honeycarbs/project-ets/pkg/neo4j"
+)
+
+var _ repository.ContactRepository = (*ContactRepository)(nil)
+
+// ContactRepository implements repository.ContactRepository with Neo4j
+type ContactRepository struct {
+	client *pkgneo4j.Client
+}
+
+// NewContactRepository creates a ContactRepository with a Neo4j client
+func NewContactRepository(client *pkgneo4j.Client) *ContactRepository {
+	return &ContactRepository{
+		client: client,
+	}
+}
+
+// UpsertContact writes the contact, its company and its links in one
+// transaction. The company resolves through aliases like job ingestion
+func (r *ContactRepository) UpsertContact(ctx context.Context, contact domain.Contact, links []domain.ContactLink) (domain.Contact, error) {
+	if contact.ID == "" {
+		return domain.Contact{}, fmt.Errorf("contact id is required")
+	}
+
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	// A contact ID owned by another user matches nothing after the MERGE,
+	// so the upsert cannot overwrite it
+	upsertQuery := `
+		MERGE (ct:Contact {id: $contact.id})
+		ON CREATE SET ct.createdAt = datetime({epochMillis: $contact.at}),
+		              ct.ownerId = $ownerId
+		WITH ct
+		WHERE coalesce(ct.ownerId, '') = $ownerId
+		SET ct.name = CASE WHEN $contact.name = '' THEN ct.name ELSE $contact.name END,
+		    ct.role = CASE WHEN $contact.role = '' THEN ct.role ELSE $contact.role END,
+		    ct.email = CASE WHEN $contact.email = '' THEN ct.email ELSE $contact.email END,
+		    ct.linkedinUrl = CASE WHEN $contact.linkedinUrl = '' THEN ct.linkedinUrl ELSE $contact.linkedinUrl END,
+		    ct.notes = CASE WHEN $contact.notes = '' THEN ct.notes ELSE $contact.notes END,
+		    ct.updatedAt = datetime({epochMillis: $contact.at})
+	`
+
+	companyQuery := `
+		MATCH (ct:Contact {id: $contact.id})
+		WHERE coalesce(ct.ownerId, '') = $ownerId
+		OPTIONAL MATCH (:CompanyAlias {id: $company.id})-[:ALIAS_OF]->(merged:Company)
+		WITH ct, coalesce(head(collect(merged.id)), $company.id) as companyId
+		MERGE (c:Company {id: companyId})
+		SET c.name = CASE WHEN coalesce(c.name, "") = "" THEN $company.name ELSE c.name END
+		WITH ct, c
+		OPTIONAL MATCH (ct)-[old:WORKS_AT]->(other:Company)
+		WHERE other <> c
+		DELETE old
+		WITH DISTINCT ct, c
+		MERGE (ct)-[:WORKS_AT]->(c)
+	`
+
+	linkQuery := `
+		MATCH (ct:Contact {id: $contact.id})
+		WHERE coalesce(ct.ownerId, '') = $ownerId
+		UNWIND $links AS link
+		MATCH (j:Job {id: link.jobId})
+		FOREACH (_ IN CASE WHEN link.kind = $referred THEN [1] ELSE [] END |
+			MERGE (ct)-[rel:REFERRED {candidateId: link.candidateId}]->(j)
+			ON CREATE SET rel.at = datetime({epochMillis: link.at})
+		)
+		FOREACH (_ IN CASE WHEN link.kind = $interviewed THEN [1] ELSE [] END |
+			MERGE (j)-[rel:INTERVIEWED_BY {candidateId: link.candidateId}]->(ct)
+			ON CREATE SET rel.at = datetime({epochMillis: link.at})
+		)
+	`
+
+	linksData := make([]map[string]interface{}, 0, len(links))
+	for _, link := range links {
+		linksData = append(linksData, map[string]interface{}{
+			"kind":        link.Kind,
+			"jobId":       link.Job.ID.String(),
+			"candidateId": link.CandidateID,
+			"at":          link.At.UnixMilli(),
+		})
+	}
+
+	params := map[string]interface{}{
+		"contact": map[string]interface{}{
+			"id":          contact.ID,
+			"name":        contact.Name,
+			"role":        contact.Role,
+			"email":       contact.Email,
+			"linkedinUrl": contact.LinkedInURL,
+			"notes":       contact.Notes,
+			"at":          contact.UpdatedAt.UnixMilli(),
+		},
+		"company": map[string]interface{}{
+			"id":   contact.Company.ID,
+			"name": contact.Company.Name,
+		},
+		"ownerId":     identity.OwnerID(ctx),
+		"links":       linksData,
+		"referred":    domain.ContactReferred,
+		"interviewed": domain.ContactInterviewed,
+	}
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		queries := []string{upsertQuery}
+		if contact.Company.ID != "" {
+			queries = append(queries, companyQuery)
+		}
+		if len(linksData) > 0 {
+			queries = append(queries, linkQuery)
+		}
+		for _, query := range queries {
+			res, err := tx.Run(ctx, query, params)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := res.Consume(ctx); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return domain.Contact{}, err
+	}
+
+	stored, err := r.search(ctx, repository.ContactFilter{Limit: 1}, []string{contact.ID})
+	if err != nil {
+		return domain.Contact{}, err
+	}
+	if len(stored) == 0 {
+		return domain.Contact{}, fmt.Errorf("contact %s not found after upsert", contact.ID)
+	}
+	return stored[0], nil
+}
+
+// SearchContacts returns the caller's matching contacts with their company and
+// links, ordered by name
+func (r *ContactRepository) SearchContacts(ctx context.Context, filter repository.ContactFilter) ([]domain.Contact, error) {
+	return r.search(ctx, filter, nil)
+}
+
+func (r *ContactRepository) search(ctx context.Context, filter repository.ContactFilter, ids []string) ([]domain.Contact, error) {
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (ct:Contact)
+		WHERE coalesce(ct.ownerId, '') = $ownerId
+		  AND (size($ids) = 0 OR ct.id IN $ids)
+		OPTIONAL MATCH (ct)-[:WORKS_AT]->(c:Company)
+		WITH ct, head(collect(c)) as c
+		WHERE ($query = '' OR toLower(coalesce(ct.name, '')) CONTAINS $query
+		                   OR toLower(coalesce(ct.role, '')) CONTAINS $query
+		                   OR toLower(coalesce(ct.email, '')) CONTAINS $query
+		                   OR toLower(coalesce(ct.notes, '')) CONTAINS $query)
+		  AND ($email = '' OR toLower(coalesce(ct.email, '')) = $email)
+		  AND (size($companyIds) = 0 OR c.id IN $companyIds)
+		  AND ($jobId = '' OR EXISTS { (ct)-[:REFERRED]->(:Job {id: $jobId}) }
+		                   OR EXISTS { (:Job {id: $jobId})-[:INTERVIEWED_BY]->(ct) })
+		  AND ($candidateId = '' OR EXISTS { (ct)-[x:REFERRED]->(:Job) WHERE x.candidateId = $candidateId }
+		                         OR EXISTS { (:Job)-[y:INTERVIEWED_BY]->(ct) WHERE y.candidateId = $candidateId })
+		OPTIONAL MATCH (ct)-[ref:REFERRED]->(rj:Job)
+		WITH ct, c, collect(CASE WHEN rj IS NULL THEN null ELSE
+			{kind: $referred, jobId: rj.id, jobTitle: rj.title, candidateId: ref.candidateId, at: ref.at} END) as referred
+		OPTIONAL MATCH (ij:Job)-[iv:INTERVIEWED_BY]->(ct)
+		WITH ct, c, referred, collect(CASE WHEN ij IS NULL THEN null ELSE
+			{kind: $interviewed, jobId: ij.id, jobTitle: ij.title, candidateId: iv.candidateId, at: iv.at} END) as interviewed
+		RETURN ct, c, referred + interviewed as links
+		ORDER BY toLower(coalesce(ct.name, ''))
+		LIMIT $limit
+	`
+
+	params := map[string]interface{}{
+		"ids":         ids,
+		"ownerId":     identity.OwnerID(ctx),
+		"query":       strings.ToLower(strings.TrimSpace(filter.Query)),
+		"email":       strings.ToLower(strings.TrimSpace(filter.Email)),
+		"companyIds":  filter.CompanyIDs,
+		"jobId":       filter.JobID,
+		"candidateId": filter.CandidateID,
+		"referred":    domain.ContactReferred,
+		"interviewed": domain.ContactInterviewed,
+		"limit":       filter.Limit,
+	}
+	if ids == nil {
+		params["ids"] = []string{}
+	}
+	if filter.CompanyIDs == nil {
+		params["companyIds"] = []string{}
+	}
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		return res.Collect(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records := result.([]*neo4j.Record)
+	contacts := make([]domain.Contact, 0, len(records))
+	for _, record := range records {
+		if contact, ok := contactFromRecord(record); ok {
+			contacts = append(contacts, contact)
+		}
+	}
+
+	return contacts, nil
+}
+
+// contactFromRecord reads the ct, c and links columns
+func contactFromRecord(record *neo4j.Record) (domain.Contact, bool) {
+	val, _ := record.Get("ct")
+	node, ok := val.(neo4j.Node)
+	if !ok {
+		return domain.Contact{}, false
+	}
+
+	contact := domain.Contact{
+		ID:          getStringProp(node.Props, "id"),
+		Name:        getStringProp(node.Props, "name"),
+		Role:        getStringProp(node.Props, "role"),
+		Email:       getStringProp(node.Props, "email"),
+		LinkedInURL: getStringProp(node.Props, "linkedinUrl"),
+		Notes:       getStringProp(node.Props, "notes"),
+		CreatedAt:   getTimeProp(node.Props, "createdAt"),
+		UpdatedAt:   getTimeProp(node.Props, "updatedAt"),
+	}
+
+	if companyVal, ok := record.Get("c"); ok {
+		if companyNode, ok := companyVal.(neo4j.Node); ok {
+			contact.Company = domain.CompanyRef{
+				ID:   getStringProp(companyNode.Props, "id"),
+				Name: getStringProp(companyNode.Props, "name"),
+			}
+		}
+	}
+
+	if linksVal, ok := record.Get("links"); ok {
+		if list, ok := linksVal.([]interface{}); ok {
+			for _, item := range list {
+				m, ok := item.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				jobID, err := uuid.Parse(getStringFromMap(m, "jobId"))
+				if err != nil {
+					continue
+				}
+				contact.Links = append(contact.Links, domain.ContactLink{
+					Kind:        getStringFromMap(m, "kind"),
+					Job:         domain.Job{ID: jobID, Title: getStringFromMap(m, "jobTitle")},
+					CandidateID: getStringFromMap(m, "candidateId"),
+					At:          getTimeProp(m, "at"),
+				})
+			}
+		}
+	}
+
+	return contact, true
+}
@@ -0,0 +1,201 @@
+package neo4j
+
+import (
+	"context"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/identity"
+	"github.com/honeycarbs/project-ets/internal/repository"
+	pkgneo4j "github.com/honeycarbs/project-ets/pkg/neo4j"
+)
+
+var _ repository.EventRepository = (*EventRepository)(nil)
+
+// EventRepository implements repository.EventRepository with Neo4j
+type EventRepository struct {
+	client *pkgneo4j.Client
+}
+
+// NewEventRepository creates an EventRepository with a Neo4j client
+func NewEventRepository(client *pkgneo4j.Client) *EventRepository {
+	return &EventRepository{
+		client: client,
+	}
+}
+
+// CreateEvent creates the caller's Event node linked to its job and,
+// optionally, its candidate, and returns it with the job and company
+func (r *EventRepository) CreateEvent(ctx context.Context, event domain.Event) (domain.Event, bool, error) {
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (j:Job {id: $jobId})
+		OPTIONAL MATCH (cand:Candidate {id: $candidateId})
+		WHERE coalesce(cand.ownerId, '') = $ownerId
+		WITH j, cand
+		WHERE $candidateId = '' OR cand IS NOT NULL
+		CREATE (e:Event {
+			id: $event.id,
+			ownerId: $ownerId,
+			kind: $event.kind,
+			title: $event.title,
+			startsAt: datetime({epochMillis: $event.startsAt}),
+			endsAt: CASE WHEN $event.endsAt IS NULL THEN null ELSE datetime({epochMillis: $event.endsAt}) END,
+			allDay: $event.allDay,
+			location: $event.location,
+			notes: $event.notes,
+			round: $event.round,
+			interviewers: $event.interviewers,
+			createdAt: datetime({epochMillis: $event.createdAt})
+		})
+		CREATE (e)-[:FOR_JOB]->(j)
+		FOREACH (_ IN CASE WHEN cand IS NULL THEN [] ELSE [1] END |
+			CREATE (cand)-[:HAS_EVENT]->(e)
+		)
+		WITH e, j, cand
+		OPTIONAL MATCH (j)-[:WORKED_AT]->(c:Company)
+		RETURN e, j, head(collect(c)) as c, cand.id as candidateId
+	`
+
+	eventData := map[string]interface{}{
+		"id":           event.ID,
+		"kind":         event.Kind,
+		"title":        event.Title,
+		"startsAt":     event.Start.UnixMilli(),
+		"endsAt":       nil,
+		"allDay":       event.AllDay,
+		"location":     event.Location,
+		"notes":        event.Notes,
+		"round":        event.Round,
+		"interviewers": event.Interviewers,
+		"createdAt":    event.CreatedAt.UnixMilli(),
+	}
+	if !event.End.IsZero() {
+		eventData["endsAt"] = event.End.UnixMilli()
+	}
+	if event.Interviewers == nil {
+		eventData["interviewers"] = []string{}
+	}
+
+	result, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, query, map[string]interface{}{
+			"jobId":       event.Job.ID.String(),
+			"candidateId": event.CandidateID,
+			"ownerId":     identity.OwnerID(ctx),
+			"event":       eventData,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res.Collect(ctx)
+	})
+	if err != nil {
+		return domain.Event{}, false, err
+	}
+
+	records := result.([]*neo4j.Record)
+	if len(records) == 0 {
+		return domain.Event{}, false, nil
+	}
+	created, ok := eventFromRecord(records[0])
+	return created, ok, nil
+}
+
+// ListEvents returns the caller's events with their job and company, earliest first
+func (r *EventRepository) ListEvents(ctx context.Context, filter repository.EventFilter) ([]domain.Event, error) {
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (e:Event)-[:FOR_JOB]->(j:Job)
+		WHERE coalesce(e.ownerId, '') = $ownerId
+		OPTIONAL MATCH (cand:Candidate)-[:HAS_EVENT]->(e)
+		WITH e, j, cand
+		WHERE ($candidateId = '' OR cand.id = $candidateId)
+		  AND (size($jobIds) = 0 OR j.id IN $jobIds)
+		  AND (size($kinds) = 0 OR e.kind IN $kinds)
+		  AND ($from IS NULL OR coalesce(e.endsAt, e.startsAt) >= datetime({epochMillis: $from}))
+		  AND ($to IS NULL OR e.startsAt < datetime({epochMillis: $to}))
+		OPTIONAL MATCH (j)-[:WORKED_AT]->(c:Company)
+		WITH e, j, cand, head(collect(c)) as c
+		RETURN e, j, c, cand.id as candidateId
+		ORDER BY e.startsAt
+		LIMIT $limit
+	`
+
+	params := map[string]interface{}{
+		"candidateId": filter.CandidateID,
+		"ownerId":     identity.OwnerID(ctx),
+		"jobIds":      filter.JobIDs,
+		"kinds":       filter.Kinds,
+		"from":        nil,
+		"to":          nil,
+		"limit":       filter.Limit,
+	}
+	if filter.JobIDs == nil {
+		params["jobIds"] = []string{}
+	}
+	if filter.Kinds == nil {
+		params["kinds"] = []string{}
+	}
+	if !filter.From.IsZero() {
+		params["from"] = filter.From.UnixMilli()
+	}
+	if !filter.To.IsZero() {
+		params["to"] = filter.To.UnixMilli()
+	}
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		return res.Collect(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records := result.([]*neo4j.Record)
+	events := make([]domain.Event, 0, len(records))
+	for _, record := range records {
+		event, ok := eventFromRecord(record)
+		if ok {
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+// eventFromRecord reads the e, j, c and candidateId columns
+func eventFromRecord(record *neo4j.Record) (domain.Event, bool) {
+	eventVal, _ := record.Get("e")
+	node, ok := eventVal.(neo4j.Node)
+	if !ok {
+		return domain.Event{}, false
+	}
+	job, ok := jobWithNeighbors(record)
+	if !ok {
+		return domain.Event{}, false
+	}
+
+	return domain.Event{
+		ID:           getStringProp(node.Props, "id"),
+		Kind:         getStringProp(node.Props, "kind"),
+		Title:        getStringProp(node.Props, "title"),
+		Start:        getTimeProp(node.Props, "startsAt"),
+		End:          getTimeProp(node.Props, "endsAt"),
+		AllDay:       getBoolProp(node.Props, "allDay"),
+		Location:     getStringProp(node.Props, "location"),
+		Notes:        getStringProp(node.Props, "notes"),
+		Round:        getIntProp(node.Props, "round"),
+		Interviewers: getStringListProp(node.Props, "interviewers"),
+		CandidateID:  getRecordString(record, "candidateId"),
+		Job:          job,
+		CreatedAt:    getTimeProp(node.Props, "createdAt"),
+	}, true
+}
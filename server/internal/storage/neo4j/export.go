@@ -0,0 +1,152 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/identity"
+	"github.com/honeycarbs/project-ets/internal/repository"
+	pkgneo4j "github.com/honeycarbs/project-ets/pkg/neo4j"
+)
+
+var _ repository.ExportRepository = (*ExportRepository)(nil)
+
+// ExportRepository reads the job subgraph for export
+type ExportRepository struct {
+	client *pkgneo4j.Client
+}
+
+// NewExportRepository creates an ExportRepository with a Neo4j client
+func NewExportRepository(client *pkgneo4j.Client) *ExportRepository {
+	return &ExportRepository{
+		client: client,
+	}
+}
+
+// ExportJobs returns the jobs matching filter with their company, skills and
+// the caller's keywords
+func (r *ExportRepository) ExportJobs(ctx context.Context, filter repository.ExportFilter) ([]repository.ExportedJob, error) {
+	// The time property cannot be parameterized, so only whitelisted values are interpolated
+	field := repository.TimeFieldFetchedAt
+	switch filter.TimeField {
+	case "", repository.TimeFieldFetchedAt:
+	case repository.TimeFieldPostedAt:
+		field = repository.TimeFieldPostedAt
+	default:
+		return nil, fmt.Errorf("unsupported time field %q", filter.TimeField)
+	}
+
+	query := fmt.Sprintf(`
+		MATCH (j:Job)
+		WHERE (size($jobIds) = 0 OR j.id IN $jobIds)
+		  AND ($from IS NULL OR j.%[1]s >= datetime({epochMillis: $from}))
+		  AND ($to IS NULL OR j.%[1]s < datetime({epochMillis: $to}))
+		OPTIONAL MATCH (j)-[:WORKED_AT]->(c:Company)
+		WITH j, head(collect(c)) as c
+		OPTIONAL MATCH (j)-[:REQUIRES]->(s:Skill)
+		WITH j, c, collect(DISTINCT s) as skills
+		OPTIONAL MATCH (j)-[hk:HAS_KEYWORD]->(k:Keyword)
+		WHERE coalesce(hk.ownerId, '') = $ownerId
+		WITH j, c, skills,
+		     collect(DISTINCT CASE WHEN k IS NULL THEN null ELSE
+		       {value: k.value, notes: coalesce(hk.notes, CASE WHEN hk.ownerId IS NULL THEN k.notes END),
+		        source: hk.source, confidence: hk.confidence} END) as keywords
+		RETURN j, c, skills, keywords
+		ORDER BY j.fetchedAt, j.id
+	`, field)
+
+	params := map[string]interface{}{
+		"jobIds":  filter.JobIDs,
+		"ownerId": identity.OwnerID(ctx),
+		"from":    nil,
+		"to":      nil,
+	}
+	if filter.JobIDs == nil {
+		params["jobIds"] = []string{}
+	}
+	if !filter.From.IsZero() {
+		params["from"] = filter.From.UnixMilli()
+	}
+	if !filter.To.IsZero() {
+		params["to"] = filter.To.UnixMilli()
+	}
+
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		return res.Collect(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records := result.([]*neo4j.Record)
+	jobs := make([]repository.ExportedJob, 0, len(records))
+	for _, record := range records {
+		jobVal, _ := record.Get("j")
+		jobNode, ok := jobVal.(neo4j.Node)
+		if !ok {
+			continue
+		}
+		job, ok := jobFromNode(jobNode)
+		if !ok {
+			continue
+		}
+
+		if companyVal, ok := record.Get("c"); ok {
+			if companyNode, ok := companyVal.(neo4j.Node); ok {
+				job.Company = domain.CompanyRef{
+					ID:   getStringProp(companyNode.Props, "id"),
+					Name: getStringProp(companyNode.Props, "name"),
+				}
+			}
+		}
+
+		if skillsVal, ok := record.Get("skills"); ok {
+			if skillsList, ok := skillsVal.([]interface{}); ok {
+				for _, skillVal := range skillsList {
+					if skillNode, ok := skillVal.(neo4j.Node); ok {
+						job.Skills = append(job.Skills, domain.SkillRef{
+							ID:   getStringProp(skillNode.Props, "id"),
+							Name: getStringProp(skillNode.Props, "name"),
+						})
+					}
+				}
+			}
+		}
+
+		keywords := make([]repository.ExportedKeyword, 0)
+		if keywordsVal, ok := record.Get("keywords"); ok {
+			if keywordsList, ok := keywordsVal.([]interface{}); ok {
+				for _, keywordVal := range keywordsList {
+					m, ok := keywordVal.(map[string]interface{})
+					if !ok {
+						continue
+					}
+					value := getStringFromMap(m, "value")
+					if value == "" {
+						continue
+					}
+					keywords = append(keywords, repository.ExportedKeyword{
+						Value:      value,
+						Notes:      getStringFromMap(m, "notes"),
+						Source:     getStringFromMap(m, "source"),
+						Confidence: getFloatProp(m, "confidence"),
+					})
+				}
+			}
+		}
+
+		jobs = append(jobs, repository.ExportedJob{Job: job, Keywords: keywords})
+	}
+
+	return jobs, nil
+}
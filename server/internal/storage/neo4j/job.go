@@ -27,18 +27,33 @@ func NewJobRepository(client *pkgneo4j.Client) *JobRepository {
 	}
 }
 
-// UpsertJobs will merge and set job data in Neo4j
-func (r *JobRepository) UpsertJobs(ctx context.Context, jobs []domain.Job) error {
-	if len(jobs) == 0 {
-		return nil
-	}
-
-	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
-	defer session.Close(ctx)
-
-	query := `
+// upsertJobsQuery merges a batch of jobs with their lifecycle, snapshots,
+// company and skills. The graph export writes the same statement per job
+// into its Cypher scripts; keep the two in step
+const upsertJobsQuery = `
 		UNWIND $jobs AS job
 		MERGE (j:Job {source: job.source, externalId: job.externalId})
+		WITH j, job, datetime({epochMillis: job.fetchedAt}) as seenAt,
+		     coalesce(j.lastSeenAt, j.fetchedAt) as previousSeen,
+		     coalesce(j.firstSeenAt, j.fetchedAt) as firstSeen,
+		     coalesce(j.contentHash, "") <> job.contentHash as changed
+		// A sighting counts once per fetch time, so re-importing an export is a no-op
+		WITH j, job, seenAt, previousSeen, firstSeen, changed,
+		     previousSeen IS NULL OR seenAt > previousSeen as newSighting
+		// A new sighting reopens jobs closed for going missing, but not ones the provider confirmed gone
+		WITH j, job, seenAt, previousSeen, firstSeen, changed, newSighting,
+		     newSighting AND NOT (j.status = 'closed' AND j.closedReason = 'provider') as reopen
+		SET j.firstSeenAt = CASE WHEN firstSeen IS NULL OR seenAt < firstSeen THEN seenAt ELSE firstSeen END,
+		    j.lastSeenAt = CASE WHEN newSighting THEN seenAt ELSE previousSeen END,
+		    j.seenCount = coalesce(j.seenCount, CASE WHEN previousSeen IS NULL THEN 0 ELSE 1 END) +
+		                  CASE WHEN newSighting THEN 1 ELSE 0 END,
+		    j.status = CASE WHEN reopen OR j.status IS NULL THEN 'open' ELSE j.status END,
+		    j.closedAt = CASE WHEN reopen THEN null ELSE j.closedAt END,
+		    j.closedReason = CASE WHEN reopen THEN null ELSE j.closedReason END,
+		    j.missedRefreshes = CASE WHEN reopen THEN 0 ELSE j.missedRefreshes END
+		// Providers derive job.id from source and externalId, so it only differs
+		// from the stored id for jobs saved with a random one; those move to the
+		// stable id once, and the search result names the id that is stored
 		SET j.id = job.id,
 		    j.title = job.title,
 		    j.location = job.location,
@@ -47,11 +62,30 @@ func (r *JobRepository) UpsertJobs(ctx context.Context, jobs []domain.Job) error
 		    j.postedAt = datetime({epochMillis: job.postedAt}),
 		    j.description = job.description,
 		    j.score = job.score,
-		    j.fetchedAt = datetime({epochMillis: job.fetchedAt})
-		WITH j, job
-		MERGE (c:Company {id: job.company.id})
-		SET c.name = job.company.name
-		MERGE (j)-[:WORKED_AT]->(c)
+		    j.fetchedAt = datetime({epochMillis: job.fetchedAt}),
+		    j.contentHash = job.contentHash
+		// Every material change is kept as a snapshot, starting with the first version
+		FOREACH (_ IN CASE WHEN changed THEN [1] ELSE [] END |
+			CREATE (snap:JobSnapshot {
+				hash: job.contentHash,
+				capturedAt: seenAt,
+				title: job.title,
+				description: job.description,
+				location: job.location,
+				remote: job.remote,
+				url: job.url,
+				score: job.score
+			})
+			CREATE (j)-[:HAS_SNAPSHOT]->(snap)
+		)
+		WITH j, job, coalesce($aliases[job.company.id], job.company.id) as companyId
+		FOREACH (_ IN CASE WHEN coalesce(companyId, "") = "" THEN [] ELSE [1] END |
+			MERGE (c:Company {id: companyId})
+			SET c.name = CASE WHEN coalesce(c.name, "") = "" THEN job.company.name ELSE c.name END,
+			    c.names = CASE WHEN job.company.name = "" OR job.company.name IN coalesce(c.names, []) THEN coalesce(c.names, [])
+			                   ELSE coalesce(c.names, []) + job.company.name END
+			MERGE (j)-[:WORKED_AT]->(c)
+		)
 		WITH j, job
 		FOREACH (skill IN job.skills |
 			MERGE (s:Skill {id: skill.id})
@@ -60,8 +94,21 @@ func (r *JobRepository) UpsertJobs(ctx context.Context, jobs []domain.Job) error
 		)
 	`
 
+// UpsertJobs will merge and set job data in Neo4j
+func (r *JobRepository) UpsertJobs(ctx context.Context, jobs []domain.Job) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
 	jobsData := make([]map[string]interface{}, 0, len(jobs))
+	companyIDs := make([]string, 0, len(jobs))
 	for _, job := range jobs {
+		if job.Company.ID != "" {
+			companyIDs = append(companyIDs, job.Company.ID)
+		}
 		skillsData := make([]map[string]interface{}, 0, len(job.Skills))
 		for _, skill := range job.Skills {
 			skillsData = append(skillsData, map[string]interface{}{
@@ -84,11 +131,18 @@ func (r *JobRepository) UpsertJobs(ctx context.Context, jobs []domain.Job) error
 			"skills":      skillsData,
 			"score":       job.Score,
 			"fetchedAt":   job.FetchedAt.UnixMilli(),
+			"contentHash": job.ContentHash(),
 		})
 	}
 
 	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
-		result, err := tx.Run(ctx, query, map[string]interface{}{"jobs": jobsData})
+		// Merged companies are resolved once for the batch, not per job
+		aliases, err := resolveCompanyAliases(ctx, tx, companyIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := tx.Run(ctx, upsertJobsQuery, map[string]interface{}{"jobs": jobsData, "aliases": aliases})
 		if err != nil {
 			return nil, err
 		}
@@ -215,6 +269,11 @@ func (r *JobRepository) FindByIDs(ctx context.Context, ids []domain.JobID) ([]do
 			Skills:      jobSkills,
 			Score:       getFloatProp(props, "score"),
 			FetchedAt:   fetchedAt,
+			FirstSeenAt: getTimeProp(props, "firstSeenAt"),
+			LastSeenAt:  getTimeProp(props, "lastSeenAt"),
+			SeenCount:   getIntProp(props, "seenCount"),
+			Status:      getStringProp(props, "status"),
+			ClosedAt:    getTimeProp(props, "closedAt"),
 		}
 
 		jobs = append(jobs, job)
@@ -222,3 +281,32 @@ func (r *JobRepository) FindByIDs(ctx context.Context, ids []domain.JobID) ([]do
 
 	return jobs, nil
 }
+
+// jobFromNode maps a Job node's properties; ok is false when the id is not a UUID
+func jobFromNode(node neo4j.Node) (domain.Job, bool) {
+	props := node.Props
+	jobID, err := uuid.Parse(getStringProp(props, "id"))
+	if err != nil {
+		return domain.Job{}, false
+	}
+
+	return domain.Job{
+		ID:          jobID,
+		Title:       getStringProp(props, "title"),
+		Location:    getStringProp(props, "location"),
+		Remote:      getBoolProp(props, "remote"),
+		URL:         getStringProp(props, "url"),
+		Source:      getStringProp(props, "source"),
+		ExternalID:  getStringProp(props, "externalId"),
+		PostedAt:    getTimeProp(props, "postedAt"),
+		Description: getStringProp(props, "description"),
+		Skills:      []domain.SkillRef{},
+		Score:       getFloatProp(props, "score"),
+		FetchedAt:   getTimeProp(props, "fetchedAt"),
+		FirstSeenAt: getTimeProp(props, "firstSeenAt"),
+		LastSeenAt:  getTimeProp(props, "lastSeenAt"),
+		SeenCount:   getIntProp(props, "seenCount"),
+		Status:      getStringProp(props, "status"),
+		ClosedAt:    getTimeProp(props, "closedAt"),
+	}, true
+}
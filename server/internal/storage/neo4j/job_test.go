@@ -0,0 +1,75 @@
+package neo4j
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/domain/graphio"
+	"github.com/honeycarbs/project-ets/internal/repository"
+)
+
+type exportedJobs []repository.ExportedJob
+
+func (e exportedJobs) ExportJobs(context.Context, repository.ExportFilter) ([]repository.ExportedJob, error) {
+	return e, nil
+}
+
+var (
+	setProperty     = regexp.MustCompile(`\b(j|c|s)\.(\w+) =`)
+	snapshotLiteral = regexp.MustCompile(`(?s)JobSnapshot \{(.*?)\}`)
+	literalKey      = regexp.MustCompile(`(\w+):`)
+)
+
+// writtenProperties lists the node properties a statement sets, as
+// label.property
+func writtenProperties(statement string) []string {
+	labels := map[string]string{"j": "Job", "c": "Company", "s": "Skill"}
+	var props []string
+	for _, m := range setProperty.FindAllStringSubmatch(statement, -1) {
+		props = append(props, labels[m[1]]+"."+m[2])
+	}
+	for _, m := range snapshotLiteral.FindAllStringSubmatch(statement, -1) {
+		for _, key := range literalKey.FindAllStringSubmatch(m[1], -1) {
+			props = append(props, "JobSnapshot."+key[1])
+		}
+	}
+	slices.Sort(props)
+	return slices.Compact(props)
+}
+
+func TestCypherExportWritesWhatUpsertJobsWrites(t *testing.T) {
+	job := domain.Job{
+		ID:          uuid.New(),
+		Title:       "Go Developer",
+		Company:     domain.CompanyRef{ID: "acme", Name: "Acme"},
+		Source:      "adzuna",
+		ExternalID:  "123",
+		Description: "Build services",
+		PostedAt:    time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		FetchedAt:   time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC),
+		Skills:      []domain.SkillRef{{ID: "go", Name: "Go"}},
+	}
+	var sb strings.Builder
+	if _, err := graphio.NewExporter(exportedJobs{{Job: job}}).Export(context.Background(), &sb, graphio.FormatCypher, repository.ExportFilter{}); err != nil {
+		t.Fatal(err)
+	}
+	script := sb.String()
+
+	want := writtenProperties(upsertJobsQuery)
+	if got := writtenProperties(script); !slices.Equal(got, want) {
+		t.Errorf("script writes %v, UpsertJobs writes %v", got, want)
+	}
+	if !strings.Contains(script, "contentHash: '"+job.ContentHash()+"'") {
+		t.Errorf("script does not carry the content hash:\n%s", script)
+	}
+	if !strings.Contains(script, "(:CompanyAlias {id: job.company.id})-[:ALIAS_OF]->") {
+		t.Errorf("script does not resolve merged companies:\n%s", script)
+	}
+}
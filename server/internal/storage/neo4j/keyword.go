@@ -6,6 +6,8 @@ import (
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/identity"
 	"github.com/honeycarbs/project-ets/internal/mcp/tools"
 	pkgneo4j "github.com/honeycarbs/project-ets/pkg/neo4j"
 )
@@ -22,7 +24,8 @@ func NewKeywordRepository(client *pkgneo4j.Client) *KeywordRepository {
 	}
 }
 
-// PersistKeywords stores keyword records in Neo4j, linking them to existing Job nodes
+// PersistKeywords stores the caller's keyword records in Neo4j, linking them
+// to existing Job nodes
 func (r *KeywordRepository) PersistKeywords(ctx context.Context, records []tools.KeywordRecord) error {
 	if len(records) == 0 {
 		return nil
@@ -31,28 +34,46 @@ func (r *KeywordRepository) PersistKeywords(ctx context.Context, records []tools
 	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
 	defer session.Close(ctx)
 
+	// Keyword nodes are a shared vocabulary; the HAS_KEYWORD edge and its
+	// notes belong to the caller. Edges stored before ownership was tracked
+	// have no ownerId; a single-user server adopts them instead of adding a
+	// second edge
 	query := `
 		UNWIND $records AS record
 		MATCH (j:Job {id: record.jobId})
 		WITH j, record
 		UNWIND record.keywords AS keyword
 		MERGE (k:Keyword {value: keyword.value})
-		SET k.notes = coalesce(CASE WHEN keyword.notes <> "" THEN keyword.notes ELSE null END, k.notes)
-		MERGE (j)-[rel:HAS_KEYWORD]->(k)
+		WITH j, record, keyword, k
+		OPTIONAL MATCH (j)-[legacy:HAS_KEYWORD]->(k)
+		WHERE legacy.ownerId IS NULL AND $ownerId = ''
+		SET legacy.ownerId = $ownerId,
+		    legacy.notes = coalesce(legacy.notes, k.notes)
+		WITH DISTINCT j, record, keyword, k
+		MERGE (j)-[rel:HAS_KEYWORD {ownerId: $ownerId}]->(k)
 		SET rel.createdAt = coalesce(rel.createdAt, datetime()),
-		    rel.source = coalesce(CASE WHEN record.source <> "" THEN record.source ELSE null END, rel.source)
+		    rel.source = coalesce(CASE WHEN record.source <> "" THEN record.source ELSE null END, rel.source),
+		    rel.confidence = coalesce(keyword.confidence, rel.confidence),
+		    rel.notes = coalesce(CASE WHEN keyword.notes <> "" THEN keyword.notes ELSE null END, rel.notes)
 	`
 
 	recordsData := make([]map[string]interface{}, 0, len(records))
 	for _, record := range records {
 		keywordsData := make([]map[string]interface{}, 0, len(record.Keywords))
 		for _, keyword := range record.Keywords {
+			value := domain.NormalizeKeyword(keyword.Value)
+			if value == "" {
+				continue
+			}
 			keywordData := map[string]interface{}{
-				"value": keyword.Value,
+				"value": value,
 			}
 			if keyword.Notes != "" {
 				keywordData["notes"] = keyword.Notes
 			}
+			if keyword.Confidence > 0 {
+				keywordData["confidence"] = keyword.Confidence
+			}
 			keywordsData = append(keywordsData, keywordData)
 		}
 
@@ -67,7 +88,10 @@ func (r *KeywordRepository) PersistKeywords(ctx context.Context, records []tools
 	}
 
 	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
-		result, err := tx.Run(ctx, query, map[string]interface{}{"records": recordsData})
+		result, err := tx.Run(ctx, query, map[string]interface{}{
+			"records": recordsData,
+			"ownerId": identity.OwnerID(ctx),
+		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to execute keyword persistence query: %w", err)
 		}
@@ -77,3 +101,72 @@ func (r *KeywordRepository) PersistKeywords(ctx context.Context, records []tools
 	return err
 }
 
+// NormalizeKeywords re-applies domain.NormalizeKeyword to every stored
+// Keyword value, for data written under older normalization rules. A value
+// that changes is merged into the node of its canonical form: its
+// HAS_KEYWORD edges move there, keeping the properties the canonical edge
+// lacks, and the old node is deleted. It returns how many values changed
+func (r *KeywordRepository) NormalizeKeywords(ctx context.Context) (int, error) {
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	values, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, `MATCH (k:Keyword) RETURN k.value as value`, nil)
+		if err != nil {
+			return nil, err
+		}
+		var values []string
+		for res.Next(ctx) {
+			if v, ok := res.Record().Values[0].(string); ok {
+				values = append(values, v)
+			}
+		}
+		return values, res.Err()
+	})
+	if err != nil {
+		return 0, fmt.Errorf("list keywords: %w", err)
+	}
+
+	renames := make([]map[string]interface{}, 0)
+	for _, value := range values.([]string) {
+		if canonical := domain.NormalizeKeyword(value); canonical != "" && canonical != value {
+			renames = append(renames, map[string]interface{}{"from": value, "to": canonical})
+		}
+	}
+	if len(renames) == 0 {
+		return 0, nil
+	}
+
+	// Edges stored before ownership was tracked move as the single-user
+	// owner, as PersistKeywords adopts them
+	query := `
+		UNWIND $renames AS rename
+		MATCH (old:Keyword {value: rename.from})
+		MERGE (k:Keyword {value: rename.to})
+		WITH old, k
+		CALL {
+			WITH old, k
+			MATCH (j:Job)-[rel:HAS_KEYWORD]->(old)
+			MERGE (j)-[moved:HAS_KEYWORD {ownerId: coalesce(rel.ownerId, '')}]->(k)
+			SET moved.createdAt = coalesce(moved.createdAt, rel.createdAt),
+			    moved.source = coalesce(moved.source, rel.source),
+			    moved.confidence = coalesce(moved.confidence, rel.confidence),
+			    moved.notes = coalesce(moved.notes, rel.notes, CASE WHEN rel.ownerId IS NULL THEN old.notes END)
+			RETURN count(moved) as moved
+		}
+		DETACH DELETE old
+	`
+
+	_, err = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, query, map[string]interface{}{"renames": renames})
+		if err != nil {
+			return nil, err
+		}
+		return result.Consume(ctx)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("merge keywords: %w", err)
+	}
+	return len(renames), nil
+}
+
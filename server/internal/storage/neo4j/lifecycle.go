@@ -0,0 +1,189 @@
+package neo4j
+
+import (
+	"context"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/repository"
+	pkgneo4j "github.com/honeycarbs/project-ets/pkg/neo4j"
+)
+
+var _ repository.LifecycleRepository = (*LifecycleRepository)(nil)
+
+// LifecycleRepository implements repository.LifecycleRepository with Neo4j
+type LifecycleRepository struct {
+	client *pkgneo4j.Client
+}
+
+// NewLifecycleRepository creates a LifecycleRepository with a Neo4j client
+func NewLifecycleRepository(client *pkgneo4j.Client) *LifecycleRepository {
+	return &LifecycleRepository{
+		client: client,
+	}
+}
+
+// RecordRefresh tags returned postings with the query key, then counts a miss
+// on every other posting carrying the key. Jobs become unknown on the first
+// miss and closed once they reach refresh.CloseAfter
+func (r *LifecycleRepository) RecordRefresh(ctx context.Context, refresh repository.Refresh) (repository.RefreshOutcome, error) {
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	tagQuery := `
+		MATCH (j:Job {source: $source})
+		WHERE j.externalId IN $externalIds
+		SET j.refreshKeys = CASE WHEN $key IN coalesce(j.refreshKeys, []) THEN j.refreshKeys
+		                         ELSE coalesce(j.refreshKeys, []) + $key END
+	`
+
+	missQuery := `
+		MATCH (j:Job {source: $source})
+		WHERE $key IN coalesce(j.refreshKeys, [])
+		  AND NOT j.externalId IN $externalIds
+		  AND coalesce(j.status, 'open') <> 'closed'
+		WITH j, coalesce(j.missedRefreshes, 0) + 1 as missed
+		WITH j, missed, missed >= $closeAfter as closing
+		SET j.missedRefreshes = missed,
+		    j.status = CASE WHEN closing THEN 'closed' ELSE 'unknown' END,
+		    j.closedAt = CASE WHEN closing THEN datetime({epochMillis: $at}) ELSE null END,
+		    j.closedReason = CASE WHEN closing THEN $reason ELSE null END
+		RETURN count(j) as missing, sum(CASE WHEN closing THEN 1 ELSE 0 END) as closed
+	`
+
+	params := map[string]interface{}{
+		"source":      refresh.Source,
+		"key":         refresh.QueryKey,
+		"externalIds": refresh.ExternalIDs,
+		"closeAfter":  refresh.CloseAfter,
+		"at":          refresh.At.UnixMilli(),
+		"reason":      repository.ClosedReasonMissing,
+	}
+	if refresh.ExternalIDs == nil {
+		params["externalIds"] = []string{}
+	}
+
+	result, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, tagQuery, params)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := res.Consume(ctx); err != nil {
+			return nil, err
+		}
+
+		res, err = tx.Run(ctx, missQuery, params)
+		if err != nil {
+			return nil, err
+		}
+		return res.Single(ctx)
+	})
+	if err != nil {
+		return repository.RefreshOutcome{}, err
+	}
+
+	record := result.(*neo4j.Record)
+	return repository.RefreshOutcome{
+		Missing: int(getRecordInt(record, "missing")),
+		Closed:  int(getRecordInt(record, "closed")),
+	}, nil
+}
+
+// ListJobsByStatus returns jobs in the given statuses, least recently seen first
+func (r *LifecycleRepository) ListJobsByStatus(ctx context.Context, filter repository.StatusFilter) ([]domain.Job, error) {
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (j:Job)
+		WHERE (size($jobIds) = 0 OR j.id IN $jobIds)
+		  AND (size($statuses) = 0 OR coalesce(j.status, 'open') IN $statuses)
+		OPTIONAL MATCH (j)-[:WORKED_AT]->(c:Company)
+		WITH j, head(collect(c)) as c
+		RETURN j, c
+		ORDER BY coalesce(j.lastSeenAt, j.fetchedAt)
+		LIMIT $limit
+	`
+
+	params := map[string]interface{}{
+		"jobIds":   filter.JobIDs,
+		"statuses": filter.Statuses,
+		"limit":    filter.Limit,
+	}
+	if filter.JobIDs == nil {
+		params["jobIds"] = []string{}
+	}
+	if filter.Statuses == nil {
+		params["statuses"] = []string{}
+	}
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		return res.Collect(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records := result.([]*neo4j.Record)
+	jobs := make([]domain.Job, 0, len(records))
+	for _, record := range records {
+		job, ok := jobWithNeighbors(record)
+		if !ok {
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// SetJobStatuses records provider check results. A closed job keeps its
+// original closedAt; reopening clears the closure and the miss counter
+func (r *LifecycleRepository) SetJobStatuses(ctx context.Context, updates []repository.StatusUpdate) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	query := `
+		UNWIND $updates AS update
+		MATCH (j:Job {id: update.jobId})
+		WITH j, update, update.status = 'closed' as closing, j.status = 'closed' as wasClosed
+		SET j.status = update.status,
+		    j.checkedAt = datetime({epochMillis: update.at}),
+		    j.closedAt = CASE WHEN NOT closing THEN null
+		                      WHEN wasClosed AND j.closedAt IS NOT NULL THEN j.closedAt
+		                      ELSE datetime({epochMillis: update.at}) END,
+		    j.closedReason = CASE WHEN closing THEN $reason ELSE null END,
+		    j.missedRefreshes = CASE WHEN closing THEN j.missedRefreshes ELSE 0 END
+	`
+
+	updatesData := make([]map[string]interface{}, 0, len(updates))
+	for _, u := range updates {
+		updatesData = append(updatesData, map[string]interface{}{
+			"jobId":  u.JobID,
+			"status": u.Status,
+			"at":     u.At.UnixMilli(),
+		})
+	}
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, query, map[string]interface{}{
+			"updates": updatesData,
+			"reason":  repository.ClosedReasonProvider,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result.Consume(ctx)
+	})
+
+	return err
+}
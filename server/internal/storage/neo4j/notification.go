@@ -0,0 +1,114 @@
+package neo4j
+
+import (
+	"context"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	"github.com/honeycarbs/project-ets/internal/identity"
+	"github.com/honeycarbs/project-ets/internal/repository"
+	pkgneo4j "github.com/honeycarbs/project-ets/pkg/neo4j"
+)
+
+var _ repository.NotificationRepository = (*NotificationRepository)(nil)
+
+// NotificationRepository implements repository.NotificationRepository with Neo4j
+type NotificationRepository struct {
+	client *pkgneo4j.Client
+}
+
+// NewNotificationRepository creates a NotificationRepository with a Neo4j client
+func NewNotificationRepository(client *pkgneo4j.Client) *NotificationRepository {
+	return &NotificationRepository{
+		client: client,
+	}
+}
+
+// Unannounced filters out jobs with an ANNOUNCED edge to the channel owned
+// by the caller. The edge hangs off the Job node, so it survives refreshes
+// that reassign j.id; edges stored before ownership was tracked belong to
+// the single-user owner
+func (r *NotificationRepository) Unannounced(ctx context.Context, channel string, jobIDs []string) ([]string, error) {
+	if len(jobIDs) == 0 {
+		return nil, nil
+	}
+
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := `
+		UNWIND $jobIds AS jobId
+		MATCH (j:Job {id: jobId})
+		WHERE NOT EXISTS {
+			MATCH (j)-[a:ANNOUNCED]->(:NotificationChannel {name: $channel})
+			WHERE coalesce(a.ownerId, '') = $ownerId
+		}
+		RETURN collect(jobId) as jobIds
+	`
+
+	params := map[string]interface{}{
+		"jobIds":  jobIDs,
+		"channel": channel,
+		"ownerId": identity.OwnerID(ctx),
+	}
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		return res.Single(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make(map[string]bool)
+	for _, id := range getStringSlice(result.(*neo4j.Record), "jobIds") {
+		pending[id] = true
+	}
+	out := make([]string, 0, len(pending))
+	for _, id := range jobIDs {
+		if pending[id] {
+			out = append(out, id)
+			delete(pending, id)
+		}
+	}
+	return out, nil
+}
+
+// MarkAnnounced merges (:Job)-[:ANNOUNCED {ownerId, at}]->(:NotificationChannel {name})
+func (r *NotificationRepository) MarkAnnounced(ctx context.Context, channel string, jobIDs []string, at time.Time) error {
+	if len(jobIDs) == 0 {
+		return nil
+	}
+
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	query := `
+		MERGE (ch:NotificationChannel {name: $channel})
+		WITH ch
+		UNWIND $jobIds AS jobId
+		MATCH (j:Job {id: jobId})
+		MERGE (j)-[a:ANNOUNCED {ownerId: $ownerId}]->(ch)
+		ON CREATE SET a.at = datetime({epochMillis: $at})
+	`
+
+	params := map[string]interface{}{
+		"channel": channel,
+		"jobIds":  jobIDs,
+		"at":      at.UnixMilli(),
+		"ownerId": identity.OwnerID(ctx),
+	}
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		return res.Consume(ctx)
+	})
+	return err
+}
@@ -0,0 +1,207 @@
+package neo4j
+
+import (
+	"context"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/repository"
+	pkgneo4j "github.com/honeycarbs/project-ets/pkg/neo4j"
+)
+
+var _ repository.PostingRepository = (*PostingRepository)(nil)
+
+// PostingRepository implements repository.PostingRepository with Neo4j
+type PostingRepository struct {
+	client *pkgneo4j.Client
+}
+
+// NewPostingRepository creates a PostingRepository with a Neo4j client
+func NewPostingRepository(client *pkgneo4j.Client) *PostingRepository {
+	return &PostingRepository{
+		client: client,
+	}
+}
+
+// ListRepostCandidates returns each job with the other postings of its company
+func (r *PostingRepository) ListRepostCandidates(ctx context.Context, jobIDs []string) ([]repository.RepostCandidates, error) {
+	if len(jobIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		MATCH (j:Job)-[:WORKED_AT]->(c:Company)<-[:WORKED_AT]-(other:Job)
+		WHERE j.id IN $jobIds AND other <> j
+		RETURN j, c, collect(DISTINCT other) as others
+	`
+
+	records, err := r.collect(ctx, query, map[string]interface{}{"jobIds": jobIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]repository.RepostCandidates, 0, len(records))
+	for _, record := range records {
+		job, ok := jobWithNeighbors(record)
+		if !ok {
+			continue
+		}
+		var others []domain.Job
+		if othersVal, ok := record.Get("others"); ok {
+			if othersList, ok := othersVal.([]interface{}); ok {
+				for _, otherVal := range othersList {
+					otherNode, ok := otherVal.(neo4j.Node)
+					if !ok {
+						continue
+					}
+					if other, ok := jobFromNode(otherNode); ok {
+						other.Company = job.Company
+						others = append(others, other)
+					}
+				}
+			}
+		}
+		candidates = append(candidates, repository.RepostCandidates{Job: job, Others: others})
+	}
+
+	return candidates, nil
+}
+
+// LinkReposts merges REPOST_OF relationships, skipping pairs already linked the other way
+func (r *PostingRepository) LinkReposts(ctx context.Context, links []repository.RepostLink) error {
+	if len(links) == 0 {
+		return nil
+	}
+
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	query := `
+		UNWIND $links AS link
+		MATCH (j:Job {id: link.jobId})
+		MATCH (o:Job {id: link.originalId})
+		WHERE NOT (o)-[:REPOST_OF]->(j)
+		MERGE (j)-[r:REPOST_OF]->(o)
+		SET r.similarity = link.similarity,
+		    r.detectedAt = coalesce(r.detectedAt, datetime())
+	`
+
+	linksData := make([]map[string]interface{}, 0, len(links))
+	for _, link := range links {
+		linksData = append(linksData, map[string]interface{}{
+			"jobId":      link.JobID,
+			"originalId": link.OriginalID,
+			"similarity": link.Similarity,
+		})
+	}
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, query, map[string]interface{}{"links": linksData})
+		if err != nil {
+			return nil, err
+		}
+		return result.Consume(ctx)
+	})
+
+	return err
+}
+
+// ListPostingSignals returns the matching jobs with their company, skills and repost chain
+func (r *PostingRepository) ListPostingSignals(ctx context.Context, filter repository.PostingFilter) ([]repository.PostingSignals, error) {
+	query := `
+		MATCH (j:Job)
+		WHERE size($jobIds) = 0 OR j.id IN $jobIds
+		OPTIONAL MATCH (j)-[:WORKED_AT]->(c:Company)
+		WITH j, head(collect(c)) as c
+		WHERE $companyId = "" OR (c IS NOT NULL AND (c.id = $companyId OR $companyId IN coalesce(c.aliases, [])))
+		OPTIONAL MATCH (j)-[:REQUIRES]->(s:Skill)
+		WITH j, c, collect(DISTINCT s) as skills
+		// Reposts are chained newest to oldest; five hops covers any realistic chain
+		OPTIONAL MATCH (j)-[:REPOST_OF*1..5]-(other:Job)
+		WHERE other <> j
+		RETURN j, c, skills, collect(DISTINCT other.id) as reposts
+		ORDER BY j.fetchedAt DESC
+	`
+
+	params := map[string]interface{}{
+		"jobIds":    filter.JobIDs,
+		"companyId": filter.CompanyID,
+	}
+	if filter.JobIDs == nil {
+		params["jobIds"] = []string{}
+	}
+
+	records, err := r.collect(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	signals := make([]repository.PostingSignals, 0, len(records))
+	for _, record := range records {
+		job, ok := jobWithNeighbors(record)
+		if !ok {
+			continue
+		}
+		signals = append(signals, repository.PostingSignals{
+			Job:     job,
+			Reposts: getStringSlice(record, "reposts"),
+		})
+	}
+
+	return signals, nil
+}
+
+func (r *PostingRepository) collect(ctx context.Context, query string, params map[string]interface{}) ([]*neo4j.Record, error) {
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		return res.Collect(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]*neo4j.Record), nil
+}
+
+// jobWithNeighbors reads the j, c and skills columns of a record
+func jobWithNeighbors(record *neo4j.Record) (domain.Job, bool) {
+	jobVal, _ := record.Get("j")
+	jobNode, ok := jobVal.(neo4j.Node)
+	if !ok {
+		return domain.Job{}, false
+	}
+	job, ok := jobFromNode(jobNode)
+	if !ok {
+		return domain.Job{}, false
+	}
+
+	if companyVal, ok := record.Get("c"); ok {
+		if companyNode, ok := companyVal.(neo4j.Node); ok {
+			job.Company = domain.CompanyRef{
+				ID:   getStringProp(companyNode.Props, "id"),
+				Name: getStringProp(companyNode.Props, "name"),
+			}
+		}
+	}
+
+	if skillsVal, ok := record.Get("skills"); ok {
+		if skillsList, ok := skillsVal.([]interface{}); ok {
+			for _, skillVal := range skillsList {
+				if skillNode, ok := skillVal.(neo4j.Node); ok {
+					job.Skills = append(job.Skills, domain.SkillRef{
+						ID:   getStringProp(skillNode.Props, "id"),
+						Name: getStringProp(skillNode.Props, "name"),
+					})
+				}
+			}
+		}
+	}
+
+	return job, true
+}
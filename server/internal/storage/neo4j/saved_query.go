@@ -0,0 +1,88 @@
+package neo4j
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/repository"
+	pkgneo4j "github.com/honeycarbs/project-ets/pkg/neo4j"
+)
+
+// Ensure SavedQueryRepository implements repository.SavedQueryRepository
+var _ repository.SavedQueryRepository = (*SavedQueryRepository)(nil)
+
+// SavedQueryRepository implements repository.SavedQueryRepository with Neo4j
+type SavedQueryRepository struct {
+	client *pkgneo4j.Client
+}
+
+// NewSavedQueryRepository creates a SavedQueryRepository with a Neo4j client
+func NewSavedQueryRepository(client *pkgneo4j.Client) *SavedQueryRepository {
+	return &SavedQueryRepository{
+		client: client,
+	}
+}
+
+// ListSavedQueries loads (:SavedQuery {name, description, cypher, params}) nodes;
+// params is a JSON-encoded list of parameter definitions
+func (r *SavedQueryRepository) ListSavedQueries(ctx context.Context) ([]domain.SavedQuery, error) {
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (q:SavedQuery)
+		WHERE q.name IS NOT NULL AND q.cypher IS NOT NULL
+		RETURN q
+		ORDER BY q.name
+	`
+
+	records, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var allRecords []*neo4j.Record
+		for res.Next(ctx) {
+			allRecords = append(allRecords, res.Record())
+		}
+
+		if err := res.Err(); err != nil {
+			return nil, err
+		}
+
+		return allRecords, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	allRecords := records.([]*neo4j.Record)
+	queries := make([]domain.SavedQuery, 0, len(allRecords))
+	for _, record := range allRecords {
+		val, _ := record.Get("q")
+		node, ok := val.(neo4j.Node)
+		if !ok {
+			continue
+		}
+
+		q := domain.SavedQuery{
+			Name:        getStringProp(node.Props, "name"),
+			Description: getStringProp(node.Props, "description"),
+			Cypher:      getStringProp(node.Props, "cypher"),
+			Source:      "graph",
+		}
+		if raw := getStringProp(node.Props, "params"); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &q.Params); err != nil {
+				return nil, fmt.Errorf("saved query %q: invalid params: %w", q.Name, err)
+			}
+		}
+		queries = append(queries, q)
+	}
+
+	return queries, nil
+}
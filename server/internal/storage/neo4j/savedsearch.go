@@ -0,0 +1,408 @@
+package neo4j
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/identity"
+	"github.com/honeycarbs/project-ets/internal/repository"
+	pkgneo4j "github.com/honeycarbs/project-ets/pkg/neo4j"
+)
+
+var _ repository.SavedSearchRepository = (*SavedSearchRepository)(nil)
+
+// SavedSearchRepository implements repository.SavedSearchRepository with Neo4j
+type SavedSearchRepository struct {
+	client *pkgneo4j.Client
+}
+
+// NewSavedSearchRepository creates a SavedSearchRepository with a Neo4j client
+func NewSavedSearchRepository(client *pkgneo4j.Client) *SavedSearchRepository {
+	return &SavedSearchRepository{
+		client: client,
+	}
+}
+
+// searchFilters is the JSON form of domain.JobSearchFilters kept in
+// SavedSearch.filters, since Neo4j cannot store nested maps
+type searchFilters struct {
+	Location      string   `json:"location,omitempty"`
+	Remote        *bool    `json:"remote,omitempty"`
+	Skills        []string `json:"skills,omitempty"`
+	IncludeClosed bool     `json:"includeClosed,omitempty"`
+}
+
+// SaveSearch merges the (:SavedSearch) node by id. Run state (lastRunAt,
+// lease, counters) is only written by FinishRun
+func (r *SavedSearchRepository) SaveSearch(ctx context.Context, search domain.SavedSearch) (domain.SavedSearch, error) {
+	if search.ID == "" {
+		return domain.SavedSearch{}, fmt.Errorf("saved search id is required")
+	}
+
+	filters, err := json.Marshal(searchFilters{
+		Location:      search.Filters.Location,
+		Remote:        search.Filters.Remote,
+		Skills:        search.Filters.Skills,
+		IncludeClosed: search.Filters.IncludeClosed,
+	})
+	if err != nil {
+		return domain.SavedSearch{}, fmt.Errorf("encode filters: %w", err)
+	}
+
+	channels := search.Channels
+	if channels == nil {
+		channels = []string{}
+	}
+
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	// A search ID owned by another user matches nothing after the MERGE, so
+	// the save cannot overwrite it
+	query := `
+		MERGE (s:SavedSearch {id: $search.id})
+		ON CREATE SET s.createdAt = datetime({epochMillis: $search.updatedAt}),
+		              s.ownerId = $ownerId
+		WITH s
+		WHERE coalesce(s.ownerId, '') = $ownerId
+		SET s.name = $search.name,
+		    s.query = $search.query,
+		    s.filters = $search.filters,
+		    s.schedule = $search.schedule,
+		    s.enabled = $search.enabled,
+		    s.channels = $search.channels,
+		    s.nextRunAt = datetime({epochMillis: $search.nextRunAt}),
+		    s.updatedAt = datetime({epochMillis: $search.updatedAt})
+		RETURN s
+	`
+
+	params := map[string]interface{}{
+		"search": map[string]interface{}{
+			"id":        search.ID,
+			"name":      search.Name,
+			"query":     search.Query,
+			"filters":   string(filters),
+			"schedule":  search.Schedule,
+			"enabled":   search.Enabled,
+			"channels":  channels,
+			"nextRunAt": search.NextRunAt.UnixMilli(),
+			"updatedAt": search.UpdatedAt.UnixMilli(),
+		},
+		"ownerId": identity.OwnerID(ctx),
+	}
+
+	result, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		return res.Collect(ctx)
+	})
+	if err != nil {
+		return domain.SavedSearch{}, err
+	}
+
+	records := result.([]*neo4j.Record)
+	if len(records) == 0 {
+		return domain.SavedSearch{}, fmt.Errorf("saved search %s not found", search.ID)
+	}
+	stored, ok := savedSearchFromRecord(records[0])
+	if !ok {
+		return domain.SavedSearch{}, fmt.Errorf("saved search %s not returned after save", search.ID)
+	}
+	return stored, nil
+}
+
+// ListSearches returns the caller's matching searches, or every owner's with
+// AllOwners, ordered by name
+func (r *SavedSearchRepository) ListSearches(ctx context.Context, filter repository.SavedSearchFilter) ([]domain.SavedSearch, error) {
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (s:SavedSearch)
+		WHERE ($allOwners OR coalesce(s.ownerId, '') = $ownerId)
+		  AND (size($ids) = 0 OR s.id IN $ids)
+		  AND ($dueBefore IS NULL OR (s.enabled AND s.nextRunAt <= datetime({epochMillis: $dueBefore})))
+		RETURN s
+		ORDER BY CASE WHEN $dueBefore IS NULL THEN null ELSE s.nextRunAt END, toLower(s.name)
+		LIMIT $limit
+	`
+
+	params := map[string]interface{}{
+		"ids":       filter.IDs,
+		"ownerId":   identity.OwnerID(ctx),
+		"allOwners": filter.AllOwners,
+		"dueBefore": nil,
+		"limit":     filter.Limit,
+	}
+	if filter.IDs == nil {
+		params["ids"] = []string{}
+	}
+	if !filter.DueBefore.IsZero() {
+		params["dueBefore"] = filter.DueBefore.UnixMilli()
+	}
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		return res.Collect(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records := result.([]*neo4j.Record)
+	searches := make([]domain.SavedSearch, 0, len(records))
+	for _, record := range records {
+		if search, ok := savedSearchFromRecord(record); ok {
+			searches = append(searches, search)
+		}
+	}
+	return searches, nil
+}
+
+// DeleteSearch detaches and deletes the SavedSearch node
+func (r *SavedSearchRepository) DeleteSearch(ctx context.Context, id string) (bool, error) {
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (s:SavedSearch {id: $id})
+		WHERE coalesce(s.ownerId, '') = $ownerId
+		DETACH DELETE s
+		RETURN count(*) as deleted
+	`
+
+	result, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, query, map[string]interface{}{
+			"id":      id,
+			"ownerId": identity.OwnerID(ctx),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res.Single(ctx)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return getRecordInt(result.(*neo4j.Record), "deleted") > 0, nil
+}
+
+// ClaimRun sets runningUntil when no unexpired lease exists. The check and
+// the write happen in one statement, so two replicas cannot both claim a run
+func (r *SavedSearchRepository) ClaimRun(ctx context.Context, id string, now, leaseUntil time.Time) (bool, error) {
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (s:SavedSearch {id: $id})
+		WHERE coalesce(s.ownerId, '') = $ownerId
+		  AND (s.runningUntil IS NULL OR s.runningUntil <= datetime({epochMillis: $now}))
+		SET s.runningUntil = datetime({epochMillis: $leaseUntil})
+		RETURN count(s) as claimed
+	`
+
+	params := map[string]interface{}{
+		"id":         id,
+		"ownerId":    identity.OwnerID(ctx),
+		"now":        now.UnixMilli(),
+		"leaseUntil": leaseUntil.UnixMilli(),
+	}
+
+	result, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		return res.Single(ctx)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return getRecordInt(result.(*neo4j.Record), "claimed") > 0, nil
+}
+
+// FinishRun merges (s)-[:FOUND {firstFoundAt, lastFoundAt}]->(j) for the
+// returned jobs and stores the run outcome on the search. A failed run keeps
+// the previous lastRunAt so new_since_last_run still shows the last good run
+func (r *SavedSearchRepository) FinishRun(ctx context.Context, run domain.SearchRun) (int, error) {
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	foundQuery := `
+		MATCH (s:SavedSearch {id: $run.searchId})
+		WHERE coalesce(s.ownerId, '') = $ownerId
+		UNWIND $run.jobIds AS jobId
+		MATCH (j:Job {id: jobId})
+		OPTIONAL MATCH (s)-[existing:FOUND]->(j)
+		WITH s, j, existing IS NULL as isNew
+		MERGE (s)-[f:FOUND]->(j)
+		ON CREATE SET f.firstFoundAt = datetime({epochMillis: $run.startedAt})
+		SET f.lastFoundAt = datetime({epochMillis: $run.startedAt})
+		RETURN sum(CASE WHEN isNew THEN 1 ELSE 0 END) as newJobs
+	`
+
+	finishQuery := `
+		MATCH (s:SavedSearch {id: $run.searchId})
+		WHERE coalesce(s.ownerId, '') = $ownerId
+		SET s.runningUntil = null,
+		    s.nextRunAt = datetime({epochMillis: $run.nextRunAt}),
+		    s.lastRunStatus = $run.status,
+		    s.lastError = $run.err,
+		    s.lastFinishedAt = datetime({epochMillis: $run.finishedAt})
+		FOREACH (_ IN CASE WHEN $run.err = '' THEN [1] ELSE [] END |
+			SET s.lastRunAt = datetime({epochMillis: $run.startedAt}),
+			    s.lastFound = size($run.jobIds),
+			    s.lastNew = $newJobs
+		)
+	`
+
+	status := domain.SearchRunOK
+	if run.Err != "" {
+		status = domain.SearchRunFailed
+	}
+	jobIDs := run.JobIDs
+	if jobIDs == nil {
+		jobIDs = []string{}
+	}
+
+	params := map[string]interface{}{
+		"run": map[string]interface{}{
+			"searchId":   run.SearchID,
+			"startedAt":  run.StartedAt.UnixMilli(),
+			"finishedAt": run.FinishedAt.UnixMilli(),
+			"nextRunAt":  run.NextRunAt.UnixMilli(),
+			"jobIds":     jobIDs,
+			"status":     status,
+			"err":        run.Err,
+		},
+		"ownerId": identity.OwnerID(ctx),
+	}
+
+	result, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		newJobs := int64(0)
+		if len(jobIDs) > 0 && run.Err == "" {
+			res, err := tx.Run(ctx, foundQuery, params)
+			if err != nil {
+				return nil, err
+			}
+			record, err := res.Single(ctx)
+			if err != nil {
+				return nil, err
+			}
+			newJobs = getRecordInt(record, "newJobs")
+		}
+
+		params["newJobs"] = newJobs
+		res, err := tx.Run(ctx, finishQuery, params)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := res.Consume(ctx); err != nil {
+			return nil, err
+		}
+		return newJobs, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.(int64)), nil
+}
+
+// ListNewJobs returns jobs whose FOUND edge was created at or after since
+func (r *SavedSearchRepository) ListNewJobs(ctx context.Context, id string, since time.Time, limit int) ([]domain.Job, error) {
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (s:SavedSearch {id: $id})-[f:FOUND]->(j:Job)
+		WHERE coalesce(s.ownerId, '') = $ownerId
+		  AND f.firstFoundAt >= datetime({epochMillis: $since})
+		OPTIONAL MATCH (j)-[:WORKED_AT]->(c:Company)
+		OPTIONAL MATCH (j)-[:REQUIRES]->(sk:Skill)
+		WITH j, f, head(collect(DISTINCT c)) as c, collect(DISTINCT sk) as skills
+		RETURN j, c, skills
+		ORDER BY f.firstFoundAt DESC, j.postedAt DESC
+		LIMIT $limit
+	`
+
+	params := map[string]interface{}{
+		"id":      id,
+		"ownerId": identity.OwnerID(ctx),
+		"since":   since.UnixMilli(),
+		"limit":   limit,
+	}
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		return res.Collect(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records := result.([]*neo4j.Record)
+	jobs := make([]domain.Job, 0, len(records))
+	for _, record := range records {
+		if job, ok := jobWithNeighbors(record); ok {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
+// savedSearchFromRecord reads the s column
+func savedSearchFromRecord(record *neo4j.Record) (domain.SavedSearch, bool) {
+	val, _ := record.Get("s")
+	node, ok := val.(neo4j.Node)
+	if !ok {
+		return domain.SavedSearch{}, false
+	}
+
+	search := domain.SavedSearch{
+		ID:            getStringProp(node.Props, "id"),
+		OwnerID:       getStringProp(node.Props, "ownerId"),
+		Name:          getStringProp(node.Props, "name"),
+		Query:         getStringProp(node.Props, "query"),
+		Schedule:      getStringProp(node.Props, "schedule"),
+		Enabled:       getBoolProp(node.Props, "enabled"),
+		Channels:      getStringListProp(node.Props, "channels"),
+		CreatedAt:     getTimeProp(node.Props, "createdAt"),
+		UpdatedAt:     getTimeProp(node.Props, "updatedAt"),
+		NextRunAt:     getTimeProp(node.Props, "nextRunAt"),
+		LastRunAt:     getTimeProp(node.Props, "lastRunAt"),
+		LastRunStatus: getStringProp(node.Props, "lastRunStatus"),
+		LastError:     getStringProp(node.Props, "lastError"),
+		LastFound:     getIntProp(node.Props, "lastFound"),
+		LastNew:       getIntProp(node.Props, "lastNew"),
+	}
+
+	if raw := getStringProp(node.Props, "filters"); raw != "" {
+		var f searchFilters
+		if err := json.Unmarshal([]byte(raw), &f); err == nil {
+			search.Filters = domain.JobSearchFilters{
+				Location:      f.Location,
+				Remote:        f.Remote,
+				Skills:        f.Skills,
+				IncludeClosed: f.IncludeClosed,
+			}
+		}
+	}
+
+	return search, true
+}
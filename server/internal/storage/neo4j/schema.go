@@ -0,0 +1,285 @@
+package neo4j
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	"github.com/honeycarbs/project-ets/internal/repository"
+	"github.com/honeycarbs/project-ets/pkg/logging"
+	pkgneo4j "github.com/honeycarbs/project-ets/pkg/neo4j"
+)
+
+var _ repository.SchemaRepository = (*SchemaRepository)(nil)
+
+// SchemaRepository introspects labels, relationship types, properties and constraints
+type SchemaRepository struct {
+	client *pkgneo4j.Client
+	logger *logging.Logger
+}
+
+// NewSchemaRepository creates a schema repository
+func NewSchemaRepository(client *pkgneo4j.Client, logger *logging.Logger) *SchemaRepository {
+	return &SchemaRepository{
+		client: client,
+		logger: logger,
+	}
+}
+
+// GetGraphSchema reads the live schema. Label and relationship counts come
+// from a scan of the graph, which is cheap at the sizes this server handles
+func (r *SchemaRepository) GetGraphSchema(ctx context.Context) (repository.GraphSchema, error) {
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	queries := map[string]string{
+		"labels": `
+			MATCH (n)
+			UNWIND labels(n) AS label
+			RETURN label, count(*) AS count
+		`,
+		"nodeProps": `
+			CALL db.schema.nodeTypeProperties()
+			YIELD nodeLabels, propertyName, propertyTypes, mandatory
+			RETURN nodeLabels, propertyName, propertyTypes, mandatory
+		`,
+		"relPatterns": `
+			MATCH (a)-[rel]->(b)
+			RETURN type(rel) AS type, labels(a) AS fromLabels, labels(b) AS toLabels, count(*) AS count
+		`,
+		"relProps": `
+			CALL db.schema.relTypeProperties()
+			YIELD relType, propertyName, propertyTypes, mandatory
+			RETURN relType, propertyName, propertyTypes, mandatory
+		`,
+		"propertyKeys": `
+			CALL db.propertyKeys() YIELD propertyKey
+			RETURN propertyKey
+		`,
+	}
+
+	results, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		out := make(map[string][]*neo4j.Record, len(queries))
+		for name, query := range queries {
+			res, err := tx.Run(ctx, query, nil)
+			if err != nil {
+				return nil, err
+			}
+			records, err := res.Collect(ctx)
+			if err != nil {
+				return nil, err
+			}
+			out[name] = records
+		}
+		return out, nil
+	})
+	if err != nil {
+		return repository.GraphSchema{}, err
+	}
+	records := results.(map[string][]*neo4j.Record)
+
+	schema := repository.GraphSchema{
+		Labels:        buildLabelSchemas(records["labels"], records["nodeProps"]),
+		Relationships: buildRelationshipSchemas(records["relPatterns"], records["relProps"]),
+		PropertyKeys:  []string{},
+		Constraints:   []repository.ConstraintSchema{},
+		FetchedAt:     time.Now().UTC(),
+	}
+	for _, record := range records["propertyKeys"] {
+		if key, ok := record.Get("propertyKey"); ok {
+			if s, ok := key.(string); ok {
+				schema.PropertyKeys = append(schema.PropertyKeys, s)
+			}
+		}
+	}
+	sort.Strings(schema.PropertyKeys)
+
+	// SHOW is not allowed inside every deployment's read transactions; a
+	// missing constraint list should not hide the rest of the schema
+	constraints, err := r.getConstraints(ctx, session)
+	if err != nil {
+		if r.logger != nil {
+			r.logger.Warn("failed to read constraints", "err", err)
+		}
+	} else {
+		schema.Constraints = constraints
+	}
+
+	return schema, nil
+}
+
+func (r *SchemaRepository) getConstraints(ctx context.Context, session neo4j.SessionWithContext) ([]repository.ConstraintSchema, error) {
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, `
+			SHOW CONSTRAINTS
+			YIELD name, type, entityType, labelsOrTypes, properties
+			RETURN name, type, entityType, labelsOrTypes, properties
+			ORDER BY name
+		`, nil)
+		if err != nil {
+			return nil, err
+		}
+		return res.Collect(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records := result.([]*neo4j.Record)
+	constraints := make([]repository.ConstraintSchema, 0, len(records))
+	for _, record := range records {
+		constraints = append(constraints, repository.ConstraintSchema{
+			Name:       getRecordString(record, "name"),
+			Type:       getRecordString(record, "type"),
+			EntityType: getRecordString(record, "entityType"),
+			Labels:     getStringSlice(record, "labelsOrTypes"),
+			Properties: getStringSlice(record, "properties"),
+		})
+	}
+	return constraints, nil
+}
+
+func buildLabelSchemas(countRecords, propRecords []*neo4j.Record) []repository.LabelSchema {
+	byLabel := make(map[string]*repository.LabelSchema)
+	get := func(label string) *repository.LabelSchema {
+		ls, ok := byLabel[label]
+		if !ok {
+			ls = &repository.LabelSchema{Label: label, Properties: []repository.PropertySchema{}}
+			byLabel[label] = ls
+		}
+		return ls
+	}
+
+	for _, record := range countRecords {
+		ls := get(getRecordString(record, "label"))
+		ls.Count = getRecordInt(record, "count")
+	}
+
+	for _, record := range propRecords {
+		key := getRecordString(record, "propertyName")
+		if key == "" {
+			continue
+		}
+		for _, label := range getStringSlice(record, "nodeLabels") {
+			ls := get(label)
+			ls.Properties = mergeProperty(ls.Properties, repository.PropertySchema{
+				Key:       key,
+				Types:     getStringSlice(record, "propertyTypes"),
+				Mandatory: getRecordBool(record, "mandatory"),
+			})
+		}
+	}
+
+	labels := make([]repository.LabelSchema, 0, len(byLabel))
+	for _, ls := range byLabel {
+		sortProperties(ls.Properties)
+		labels = append(labels, *ls)
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Label < labels[j].Label })
+	return labels
+}
+
+func buildRelationshipSchemas(patternRecords, propRecords []*neo4j.Record) []repository.RelationshipSchema {
+	byType := make(map[string]*repository.RelationshipSchema)
+	get := func(relType string) *repository.RelationshipSchema {
+		rs, ok := byType[relType]
+		if !ok {
+			rs = &repository.RelationshipSchema{
+				Type:       relType,
+				Patterns:   []repository.RelationshipPattern{},
+				Properties: []repository.PropertySchema{},
+			}
+			byType[relType] = rs
+		}
+		return rs
+	}
+
+	for _, record := range patternRecords {
+		rs := get(getRecordString(record, "type"))
+		count := getRecordInt(record, "count")
+		rs.Count += count
+		rs.Patterns = append(rs.Patterns, repository.RelationshipPattern{
+			From:  strings.Join(getStringSlice(record, "fromLabels"), ":"),
+			To:    strings.Join(getStringSlice(record, "toLabels"), ":"),
+			Count: count,
+		})
+	}
+
+	for _, record := range propRecords {
+		key := getRecordString(record, "propertyName")
+		if key == "" {
+			continue
+		}
+		// relType is reported as :`TYPE`
+		relType := strings.Trim(strings.TrimPrefix(getRecordString(record, "relType"), ":"), "`")
+		rs := get(relType)
+		rs.Properties = mergeProperty(rs.Properties, repository.PropertySchema{
+			Key:       key,
+			Types:     getStringSlice(record, "propertyTypes"),
+			Mandatory: getRecordBool(record, "mandatory"),
+		})
+	}
+
+	rels := make([]repository.RelationshipSchema, 0, len(byType))
+	for _, rs := range byType {
+		sort.Slice(rs.Patterns, func(i, j int) bool { return rs.Patterns[i].Count > rs.Patterns[j].Count })
+		sortProperties(rs.Properties)
+		rels = append(rels, *rs)
+	}
+	sort.Slice(rels, func(i, j int) bool { return rels[i].Type < rels[j].Type })
+	return rels
+}
+
+// mergeProperty adds p, combining types when a multi-label node type reports the key again
+func mergeProperty(props []repository.PropertySchema, p repository.PropertySchema) []repository.PropertySchema {
+	if p.Types == nil {
+		p.Types = []string{}
+	}
+	for i := range props {
+		if props[i].Key != p.Key {
+			continue
+		}
+		for _, t := range p.Types {
+			if !containsString(props[i].Types, t) {
+				props[i].Types = append(props[i].Types, t)
+			}
+		}
+		props[i].Mandatory = props[i].Mandatory && p.Mandatory
+		return props
+	}
+	return append(props, p)
+}
+
+func sortProperties(props []repository.PropertySchema) {
+	sort.Slice(props, func(i, j int) bool { return props[i].Key < props[j].Key })
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func getRecordString(record *neo4j.Record, key string) string {
+	val, ok := record.Get(key)
+	if !ok || val == nil {
+		return ""
+	}
+	s, _ := val.(string)
+	return s
+}
+
+func getRecordInt(record *neo4j.Record, key string) int64 {
+	val, ok := record.Get(key)
+	if !ok || val == nil {
+		return 0
+	}
+	n, _ := val.(int64)
+	return n
+}
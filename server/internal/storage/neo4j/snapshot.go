@@ -0,0 +1,88 @@
+package neo4j
+
+import (
+	"context"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/repository"
+	pkgneo4j "github.com/honeycarbs/project-ets/pkg/neo4j"
+)
+
+var _ repository.SnapshotRepository = (*SnapshotRepository)(nil)
+
+// SnapshotRepository implements repository.SnapshotRepository with Neo4j
+type SnapshotRepository struct {
+	client *pkgneo4j.Client
+}
+
+// NewSnapshotRepository creates a SnapshotRepository with a Neo4j client
+func NewSnapshotRepository(client *pkgneo4j.Client) *SnapshotRepository {
+	return &SnapshotRepository{
+		client: client,
+	}
+}
+
+// ListSnapshots returns the job with its company and its snapshots ordered by capture time
+func (r *SnapshotRepository) ListSnapshots(ctx context.Context, jobID string) (domain.Job, []domain.JobSnapshot, bool, error) {
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (j:Job {id: $jobId})
+		OPTIONAL MATCH (j)-[:WORKED_AT]->(c:Company)
+		WITH j, head(collect(c)) as c
+		OPTIONAL MATCH (j)-[:HAS_SNAPSHOT]->(snap:JobSnapshot)
+		WITH j, c, snap
+		ORDER BY snap.capturedAt
+		RETURN j, c, collect(snap) as snapshots
+	`
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, query, map[string]interface{}{"jobId": jobID})
+		if err != nil {
+			return nil, err
+		}
+		return res.Collect(ctx)
+	})
+	if err != nil {
+		return domain.Job{}, nil, false, err
+	}
+
+	records := result.([]*neo4j.Record)
+	if len(records) == 0 {
+		return domain.Job{}, nil, false, nil
+	}
+
+	record := records[0]
+	job, ok := jobWithNeighbors(record)
+	if !ok {
+		return domain.Job{}, nil, false, nil
+	}
+
+	var snapshots []domain.JobSnapshot
+	if snapshotsVal, ok := record.Get("snapshots"); ok {
+		if snapshotsList, ok := snapshotsVal.([]interface{}); ok {
+			for _, snapVal := range snapshotsList {
+				snapNode, ok := snapVal.(neo4j.Node)
+				if !ok {
+					continue
+				}
+				props := snapNode.Props
+				snapshots = append(snapshots, domain.JobSnapshot{
+					Hash:        getStringProp(props, "hash"),
+					CapturedAt:  getTimeProp(props, "capturedAt"),
+					Title:       getStringProp(props, "title"),
+					Description: getStringProp(props, "description"),
+					Location:    getStringProp(props, "location"),
+					Remote:      getBoolProp(props, "remote"),
+					URL:         getStringProp(props, "url"),
+					Score:       getFloatProp(props, "score"),
+				})
+			}
+		}
+	}
+
+	return job, snapshots, true, nil
+}
@@ -0,0 +1,258 @@
+package neo4j_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/identity"
+	"github.com/honeycarbs/project-ets/internal/mcp/tools"
+	"github.com/honeycarbs/project-ets/internal/repository"
+	storage "github.com/honeycarbs/project-ets/internal/storage/neo4j"
+	"github.com/honeycarbs/project-ets/pkg/logging"
+	pkgneo4j "github.com/honeycarbs/project-ets/pkg/neo4j"
+)
+
+// tenantFixture is one shared job and two users writing their own data
+// about it. The tests need a disposable database: set NEO4J_TEST_URI,
+// NEO4J_TEST_USERNAME and NEO4J_TEST_PASSWORD to run them
+type tenantFixture struct {
+	client     *pkgneo4j.Client
+	job        domain.Job
+	alice, bob context.Context
+	aliceID    string
+}
+
+func newTenantFixture(t *testing.T) *tenantFixture {
+	t.Helper()
+	uri := os.Getenv("NEO4J_TEST_URI")
+	if uri == "" {
+		t.Skip("NEO4J_TEST_URI is not set")
+	}
+	client, err := pkgneo4j.NewClient(pkgneo4j.Config{
+		URI:      uri,
+		Username: os.Getenv("NEO4J_TEST_USERNAME"),
+		Password: os.Getenv("NEO4J_TEST_PASSWORD"),
+	})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+
+	ctx := context.Background()
+	run := uuid.NewString()
+	f := &tenantFixture{
+		client:  client,
+		aliceID: "alice-" + run,
+		job: domain.Job{
+			ID:         uuid.New(),
+			Title:      "Platform Engineer",
+			Company:    domain.CompanyRef{ID: "tenant-test-" + run, Name: "Tenant Test"},
+			Source:     "tenant-test",
+			ExternalID: run,
+			PostedAt:   time.Now().UTC(),
+			FetchedAt:  time.Now().UTC(),
+		},
+	}
+	f.alice = identity.NewContext(ctx, domain.User{ID: f.aliceID})
+	f.bob = identity.NewContext(ctx, domain.User{ID: "bob-" + run})
+
+	t.Cleanup(func() {
+		session := client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+		defer session.Close(ctx)
+		params := map[string]interface{}{
+			"owners":  []string{f.aliceID, "bob-" + run},
+			"run":     run,
+			"company": f.job.Company.ID,
+		}
+		_, _ = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+			for _, query := range []string{
+				`MATCH (:Job {source: 'tenant-test', externalId: $run})-[:HAS_SNAPSHOT]->(s) DETACH DELETE s`,
+				`MATCH (n) WHERE n.ownerId IN $owners DETACH DELETE n`,
+				`MATCH (n) WHERE (n:Job AND n.source = 'tenant-test' AND n.externalId = $run)
+				   OR (n:Company AND n.id = $company) DETACH DELETE n`,
+			} {
+				res, err := tx.Run(ctx, query, params)
+				if err != nil {
+					return nil, err
+				}
+				if _, err := res.Consume(ctx); err != nil {
+					return nil, err
+				}
+			}
+			return nil, nil
+		})
+		_ = client.Close(ctx)
+	})
+
+	if err := storage.NewJobRepository(client).UpsertJobs(ctx, []domain.Job{f.job}); err != nil {
+		t.Fatalf("upsert job: %v", err)
+	}
+	return f
+}
+
+func TestTenantCandidatesAndApplications(t *testing.T) {
+	f := newTenantFixture(t)
+	candidates := storage.NewCandidateRepository(f.client)
+	applications := storage.NewApplicationRepository(f.client)
+
+	// Both users pick the same candidate ID
+	for ctx, name := range map[context.Context]string{f.alice: "Alice", f.bob: "Bob"} {
+		if err := candidates.UpsertCandidate(ctx, domain.Candidate{ID: "me", Name: name, UpdatedAt: time.Now()}); err != nil {
+			t.Fatalf("upsert %s: %v", name, err)
+		}
+	}
+	got, found, err := candidates.GetCandidate(f.alice, "me")
+	if err != nil || !found {
+		t.Fatalf("alice's candidate: found=%v err=%v", found, err)
+	}
+	if got.Name != "Alice" {
+		t.Fatalf("alice sees candidate %q, want Alice", got.Name)
+	}
+
+	app := domain.Application{
+		CandidateID: "me",
+		Job:         domain.Job{ID: f.job.ID},
+		Status:      domain.ApplicationApplied,
+		AppliedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	if ok, err := applications.SaveApplication(f.alice, app, ""); err != nil || !ok {
+		t.Fatalf("save application: ok=%v err=%v", ok, err)
+	}
+
+	if _, found, err := applications.GetApplication(f.bob, "me", f.job.ID.String()); err != nil || found {
+		t.Fatalf("bob sees alice's application: found=%v err=%v", found, err)
+	}
+	listed, err := applications.ListApplications(f.bob, repository.ApplicationFilter{JobIDs: []string{f.job.ID.String()}, Limit: 10})
+	if err != nil {
+		t.Fatalf("list applications: %v", err)
+	}
+	if len(listed) != 0 {
+		t.Fatalf("bob lists %d applications for the job, want 0", len(listed))
+	}
+}
+
+func TestTenantKeywords(t *testing.T) {
+	f := newTenantFixture(t)
+	keywords := storage.NewKeywordRepository(f.client)
+	analysis := storage.NewAnalysisRepository(f.client, logging.New("error"))
+	export := storage.NewExportRepository(f.client)
+
+	err := keywords.PersistKeywords(f.alice, []tools.KeywordRecord{{
+		JobID:    f.job.ID.String(),
+		Keywords: []tools.KeywordEntry{{Value: "kubernetes", Notes: "alice's private note"}},
+	}})
+	if err != nil {
+		t.Fatalf("persist keywords: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		ctx  context.Context
+		want int
+	}{
+		{"owner", f.alice, 1},
+		{"other user", f.bob, 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			subgraphs, err := analysis.GetJobSubgraphs(tc.ctx, []string{f.job.ID.String()})
+			if err != nil || len(subgraphs) != 1 {
+				t.Fatalf("subgraphs: %d, err=%v", len(subgraphs), err)
+			}
+			if got := len(subgraphs[0].Keywords); got != tc.want {
+				t.Errorf("analysis sees %d keywords, want %d", got, tc.want)
+			}
+
+			exported, err := export.ExportJobs(tc.ctx, repository.ExportFilter{JobIDs: []string{f.job.ID.String()}})
+			if err != nil || len(exported) != 1 {
+				t.Fatalf("export: %d jobs, err=%v", len(exported), err)
+			}
+			if got := len(exported[0].Keywords); got != tc.want {
+				t.Errorf("export sees %d keywords, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTenantSavedSearches(t *testing.T) {
+	f := newTenantFixture(t)
+	searches := storage.NewSavedSearchRepository(f.client)
+
+	now := time.Now().UTC()
+	saved, err := searches.SaveSearch(f.alice, domain.SavedSearch{
+		ID:        uuid.NewString(),
+		Name:      "alice's search",
+		Query:     "platform engineer",
+		Schedule:  "@daily",
+		Enabled:   true,
+		UpdatedAt: now,
+		NextRunAt: now.Add(-time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("save search: %v", err)
+	}
+
+	listed, err := searches.ListSearches(f.bob, repository.SavedSearchFilter{IDs: []string{saved.ID}, Limit: 1})
+	if err != nil || len(listed) != 0 {
+		t.Fatalf("bob lists alice's search: %d, err=%v", len(listed), err)
+	}
+	hijack := saved
+	hijack.Name = "bob's now"
+	if _, err := searches.SaveSearch(f.bob, hijack); err == nil {
+		t.Fatal("bob overwrote alice's search")
+	}
+	if deleted, err := searches.DeleteSearch(f.bob, saved.ID); err != nil || deleted {
+		t.Fatalf("bob deleted alice's search: deleted=%v err=%v", deleted, err)
+	}
+
+	// The scheduler lists every owner's due searches and runs each as its owner
+	due, err := searches.ListSearches(context.Background(), repository.SavedSearchFilter{IDs: []string{saved.ID}, DueBefore: now, AllOwners: true, Limit: 1})
+	if err != nil || len(due) != 1 {
+		t.Fatalf("due searches: %d, err=%v", len(due), err)
+	}
+	if due[0].OwnerID != f.aliceID || due[0].Name != "alice's search" {
+		t.Fatalf("due search = %q owned by %q", due[0].Name, due[0].OwnerID)
+	}
+}
+
+func TestTenantContactsAndEvents(t *testing.T) {
+	f := newTenantFixture(t)
+	contacts := storage.NewContactRepository(f.client)
+	events := storage.NewEventRepository(f.client)
+
+	contact, err := contacts.UpsertContact(f.alice, domain.Contact{
+		ID:        uuid.NewString(),
+		Name:      "Recruiter",
+		UpdatedAt: time.Now(),
+	}, nil)
+	if err != nil {
+		t.Fatalf("upsert contact: %v", err)
+	}
+	if found, err := contacts.SearchContacts(f.bob, repository.ContactFilter{Query: "recruiter", Limit: 10}); err != nil || len(found) != 0 {
+		t.Fatalf("bob finds alice's contact: %d, err=%v", len(found), err)
+	}
+	if _, err := contacts.UpsertContact(f.bob, domain.Contact{ID: contact.ID, Notes: "overwritten", UpdatedAt: time.Now()}, nil); err == nil {
+		t.Fatal("bob overwrote alice's contact")
+	}
+
+	_, ok, err := events.CreateEvent(f.alice, domain.Event{
+		ID:        uuid.NewString(),
+		Kind:      domain.EventInterview,
+		Title:     "Onsite",
+		Start:     time.Now(),
+		Job:       domain.Job{ID: f.job.ID},
+		CreatedAt: time.Now(),
+	})
+	if err != nil || !ok {
+		t.Fatalf("create event: ok=%v err=%v", ok, err)
+	}
+	listed, err := events.ListEvents(f.bob, repository.EventFilter{JobIDs: []string{f.job.ID.String()}, Limit: 10})
+	if err != nil || len(listed) != 0 {
+		t.Fatalf("bob lists alice's events: %d, err=%v", len(listed), err)
+	}
+}
@@ -0,0 +1,118 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	"github.com/honeycarbs/project-ets/internal/identity"
+	"github.com/honeycarbs/project-ets/internal/repository"
+)
+
+// trendPatterns map a term kind to the pattern binding job j, the term
+// expression and the predicate limiting keywords to the caller's
+var trendPatterns = map[string]struct {
+	match string
+	term  string
+	where string
+}{
+	"skill":   {match: "(j:Job)-[:REQUIRES]->(t:Skill)", term: "t.name", where: "true"},
+	"keyword": {match: "(j:Job)-[hk:HAS_KEYWORD]->(t:Keyword)", term: "t.value", where: "coalesce(hk.ownerId, '') = $ownerId"},
+}
+
+// GetSkillTrends counts jobs requiring each skill in the current and previous windows
+func (r *AnalysisRepository) GetSkillTrends(ctx context.Context, q repository.TrendQuery) ([]repository.TermTrend, error) {
+	return r.getTermTrends(ctx, "skill", q)
+}
+
+// GetKeywordTrends counts jobs the caller tagged with each keyword in the
+// current and previous windows
+func (r *AnalysisRepository) GetKeywordTrends(ctx context.Context, q repository.TrendQuery) ([]repository.TermTrend, error) {
+	return r.getTermTrends(ctx, "keyword", q)
+}
+
+func (r *AnalysisRepository) getTermTrends(ctx context.Context, kind string, q repository.TrendQuery) ([]repository.TermTrend, error) {
+	pattern := trendPatterns[kind]
+
+	// Property names and ORDER BY cannot be parameterized, so only whitelisted values are interpolated
+	var field string
+	switch q.TimeField {
+	case repository.TimeFieldFetchedAt, repository.TimeFieldPostedAt:
+		field = q.TimeField
+	default:
+		return nil, fmt.Errorf("unsupported time field %q", q.TimeField)
+	}
+
+	orderBy := "current DESC, term ASC"
+	if q.OrderBy == repository.TrendOrderDelta {
+		orderBy = "(current - previous) DESC, current DESC, term ASC"
+	}
+
+	query := fmt.Sprintf(`
+		MATCH %[1]s
+		WHERE %[5]s
+		WITH j, %[2]s as term,
+		     (j.%[3]s >= datetime({epochMillis: $currentFrom}) AND j.%[3]s < datetime({epochMillis: $currentTo})) as inCurrent,
+		     (j.%[3]s >= datetime({epochMillis: $previousFrom}) AND j.%[3]s < datetime({epochMillis: $previousTo})) as inPrevious
+		WHERE term IS NOT NULL AND (inCurrent OR inPrevious)
+		WITH term,
+		     count(DISTINCT CASE WHEN inCurrent THEN j END) as current,
+		     count(DISTINCT CASE WHEN inPrevious THEN j END) as previous
+		RETURN term, current, previous
+		ORDER BY %[4]s
+		LIMIT $limit
+	`, pattern.match, pattern.term, field, orderBy, pattern.where)
+
+	params := map[string]interface{}{
+		"currentFrom":  q.Current.From.UnixMilli(),
+		"currentTo":    q.Current.To.UnixMilli(),
+		"previousFrom": q.Previous.From.UnixMilli(),
+		"previousTo":   q.Previous.To.UnixMilli(),
+		"ownerId":      identity.OwnerID(ctx),
+		"limit":        q.Limit,
+	}
+
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	// Collect all records INSIDE the transaction
+	records, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+
+		var allRecords []*neo4j.Record
+		for res.Next(ctx) {
+			allRecords = append(allRecords, res.Record())
+		}
+
+		if err := res.Err(); err != nil {
+			return nil, err
+		}
+
+		return allRecords, nil
+	})
+	if err != nil {
+		r.logger.Error("AnalysisRepository.getTermTrends: Neo4j query failed", "kind", kind, "err", err)
+		return nil, err
+	}
+
+	allRecords := records.([]*neo4j.Record)
+	trends := make([]repository.TermTrend, 0, len(allRecords))
+	for _, record := range allRecords {
+		term, _ := record.Get("term")
+		termStr, ok := term.(string)
+		if !ok || termStr == "" {
+			continue
+		}
+		trends = append(trends, repository.TermTrend{
+			Term:     termStr,
+			Current:  int(getRecordFloat(record, "current")),
+			Previous: int(getRecordFloat(record, "previous")),
+		})
+	}
+
+	return trends, nil
+}
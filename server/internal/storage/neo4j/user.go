@@ -0,0 +1,79 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	"github.com/honeycarbs/project-ets/internal/domain"
+	"github.com/honeycarbs/project-ets/internal/repository"
+	pkgneo4j "github.com/honeycarbs/project-ets/pkg/neo4j"
+)
+
+var _ repository.UserRepository = (*UserRepository)(nil)
+
+// UserRepository implements repository.UserRepository with Neo4j
+type UserRepository struct {
+	client *pkgneo4j.Client
+}
+
+// NewUserRepository creates a UserRepository with a Neo4j client
+func NewUserRepository(client *pkgneo4j.Client) *UserRepository {
+	return &UserRepository{
+		client: client,
+	}
+}
+
+// ResolveUser merges (:User {issuer, subject}) and keeps the id it was
+// created with
+func (r *UserRepository) ResolveUser(ctx context.Context, user domain.User, at time.Time) (domain.User, error) {
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	query := `
+		MERGE (u:User {issuer: $issuer, subject: $subject})
+		ON CREATE SET u.id = $id, u.createdAt = $at
+		SET u.lastSeenAt = $at,
+		    u.email = CASE WHEN $email = '' THEN u.email ELSE $email END,
+		    u.name = CASE WHEN $name = '' THEN u.name ELSE $name END
+		RETURN u
+	`
+
+	params := map[string]interface{}{
+		"id":      user.ID,
+		"issuer":  user.Issuer,
+		"subject": user.Subject,
+		"email":   user.Email,
+		"name":    user.Name,
+		"at":      at.UTC(),
+	}
+
+	result, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		return res.Single(ctx)
+	})
+	if err != nil {
+		return domain.User{}, fmt.Errorf("resolve user: %w", err)
+	}
+
+	val, _ := result.(*neo4j.Record).Get("u")
+	node, ok := val.(neo4j.Node)
+	if !ok {
+		return domain.User{}, fmt.Errorf("resolve user: unexpected result")
+	}
+	props := node.Props
+	return domain.User{
+		ID:        getStringProp(props, "id"),
+		Issuer:    getStringProp(props, "issuer"),
+		Subject:   getStringProp(props, "subject"),
+		Email:     getStringProp(props, "email"),
+		Name:      getStringProp(props, "name"),
+		CreatedAt: getTimeProp(props, "createdAt"),
+		LastSeen:  getTimeProp(props, "lastSeenAt"),
+	}, nil
+}
@@ -104,6 +104,42 @@ func (c *Client) SearchJobs(ctx context.Context, query string, params SearchPara
 	return jobs, nil
 }
 
+// CheckPosting requests a posting's redirect URL and reports whether it is
+// still listed. Adzuna has no per-posting lookup, so the landing page status
+// is the only signal; anything other than success or 404/410 is unknown
+func (c *Client) CheckPosting(ctx context.Context, postingURL string) (PostingState, error) {
+	if c == nil {
+		return PostingUnknown, fmt.Errorf("adzuna: client is nil")
+	}
+	if postingURL == "" {
+		return PostingUnknown, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, postingURL, nil)
+	if err != nil {
+		return PostingUnknown, fmt.Errorf("adzuna: build request: %w", err)
+	}
+	req.Header.Set("Accept", "text/html")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return PostingUnknown, fmt.Errorf("adzuna: request failed: %w", err)
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+		_ = resp.Body.Close()
+	}()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return PostingLive, nil
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return PostingGone, nil
+	default:
+		return PostingUnknown, nil
+	}
+}
+
 func (c *Client) buildSearchURL(query string, params SearchParams) (string, error) {
 	if query == "" {
 		return "", fmt.Errorf("adzuna: query is required")
@@ -80,3 +80,15 @@ type Job struct {
 	SalaryMax   float64
 	FetchedAt   time.Time
 }
+
+// PostingState is the availability of a posting's landing page
+type PostingState int
+
+const (
+	// PostingUnknown means the landing page gave no clear answer
+	PostingUnknown PostingState = iota
+	// PostingLive means the landing page loaded
+	PostingLive
+	// PostingGone means the landing page returned 404 or 410
+	PostingGone
+)
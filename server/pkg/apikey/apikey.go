@@ -0,0 +1,142 @@
+// Package apikey verifies static bearer API keys against their stored
+// SHA-256 hashes, so the plaintext keys never have to be kept on the server
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	hashPrefix = "sha256:"
+	keyPrefix  = "ets_"
+)
+
+// Key is a stored API key. Hash is "sha256:" followed by the hex digest of
+// the plaintext key
+type Key struct {
+	Name      string    `json:"name"`
+	Hash      string    `json:"hash"`
+	Scopes    []string  `json:"scopes"`
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+}
+
+// File is the JSON layout of a key file
+type File struct {
+	Keys []Key `json:"keys"`
+}
+
+// Generate returns a new random key and the hash to store for it
+func Generate() (key, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("generate api key: %w", err)
+	}
+	key = keyPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return key, Hash(key), nil
+}
+
+// Hash returns the stored form of a plaintext key
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hashPrefix + hex.EncodeToString(sum[:])
+}
+
+// LoadFile reads the keys of a key file
+func LoadFile(path string) ([]Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read api keys: %w", err)
+	}
+	var file File
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("api keys %s: %w", path, err)
+	}
+	return file.Keys, nil
+}
+
+// Store looks up keys by their plaintext value
+type Store struct {
+	keys   []Key
+	hashes [][]byte
+	clock  func() time.Time
+}
+
+// NewStore validates keys and creates a store over them
+func NewStore(keys []Key) (*Store, error) {
+	s := &Store{clock: time.Now}
+	names := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		if strings.TrimSpace(k.Name) == "" {
+			return nil, fmt.Errorf("api key name is required")
+		}
+		if names[k.Name] {
+			return nil, fmt.Errorf("duplicate api key %q", k.Name)
+		}
+		names[k.Name] = true
+
+		digest, err := decodeHash(k.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("api key %q: %w", k.Name, err)
+		}
+		if len(k.Scopes) == 0 {
+			return nil, fmt.Errorf("api key %q: at least one scope is required", k.Name)
+		}
+		s.keys = append(s.keys, k)
+		s.hashes = append(s.hashes, digest)
+	}
+	return s, nil
+}
+
+// Len returns the number of keys in the store
+func (s *Store) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.keys)
+}
+
+// Lookup returns the unexpired key matching the plaintext token. Every key
+// is compared in constant time so the result does not leak through timing
+func (s *Store) Lookup(token string) (Key, bool) {
+	if s == nil || token == "" {
+		return Key{}, false
+	}
+	sum := sha256.Sum256([]byte(token))
+
+	match := -1
+	for i, h := range s.hashes {
+		if subtle.ConstantTimeCompare(sum[:], h) == 1 {
+			match = i
+		}
+	}
+	if match < 0 {
+		return Key{}, false
+	}
+
+	key := s.keys[match]
+	if !key.ExpiresAt.IsZero() && !s.clock().Before(key.ExpiresAt) {
+		return Key{}, false
+	}
+	return key, true
+}
+
+func decodeHash(hash string) ([]byte, error) {
+	hexDigest, ok := strings.CutPrefix(hash, hashPrefix)
+	if !ok {
+		return nil, fmt.Errorf("hash must start with %q", hashPrefix)
+	}
+	digest, err := hex.DecodeString(hexDigest)
+	if err != nil || len(digest) != sha256.Size {
+		return nil, fmt.Errorf("hash must be a hex SHA-256 digest")
+	}
+	return digest, nil
+}
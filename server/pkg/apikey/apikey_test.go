@@ -0,0 +1,88 @@
+package apikey
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGeneratedKeyMatchesItsHash(t *testing.T) {
+	key, hash, err := Generate()
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if !strings.HasPrefix(key, keyPrefix) || !strings.HasPrefix(hash, hashPrefix) {
+		t.Fatalf("unexpected key %q or hash %q", key, hash)
+	}
+	if strings.Contains(hash, key) {
+		t.Fatal("hash contains the plaintext key")
+	}
+
+	store, err := NewStore([]Key{{Name: "ci", Hash: hash, Scopes: []string{"read"}}})
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+	got, ok := store.Lookup(key)
+	if !ok || got.Name != "ci" {
+		t.Fatalf("lookup = %+v, %v; want ci", got, ok)
+	}
+	if _, ok := store.Lookup(key + "x"); ok {
+		t.Fatal("lookup accepted a wrong key")
+	}
+	if _, ok := store.Lookup(""); ok {
+		t.Fatal("lookup accepted an empty key")
+	}
+}
+
+func TestLookupRejectsExpiredKeys(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	store, err := NewStore([]Key{
+		{Name: "old", Hash: Hash("old-key"), Scopes: []string{"read"}, ExpiresAt: now},
+		{Name: "new", Hash: Hash("new-key"), Scopes: []string{"read"}, ExpiresAt: now.Add(time.Hour)},
+	})
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+	store.clock = func() time.Time { return now }
+
+	if _, ok := store.Lookup("old-key"); ok {
+		t.Error("expired key accepted")
+	}
+	if _, ok := store.Lookup("new-key"); !ok {
+		t.Error("unexpired key rejected")
+	}
+}
+
+func TestNewStoreValidatesKeys(t *testing.T) {
+	valid := Hash("k")
+	cases := map[string][]Key{
+		"missing name":   {{Hash: valid, Scopes: []string{"read"}}},
+		"duplicate name": {{Name: "a", Hash: valid, Scopes: []string{"read"}}, {Name: "a", Hash: Hash("j"), Scopes: []string{"read"}}},
+		"plaintext hash": {{Name: "a", Hash: "k", Scopes: []string{"read"}}},
+		"short digest":   {{Name: "a", Hash: "sha256:abcd", Scopes: []string{"read"}}},
+		"no scopes":      {{Name: "a", Hash: valid}},
+	}
+	for name, keys := range cases {
+		if _, err := NewStore(keys); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	data := `{"keys": [{"name": "agent", "hash": "` + Hash("secret") + `", "scopes": ["read", "write"], "expires_at": "2030-01-01T00:00:00Z"}]}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(keys) != 1 || keys[0].Name != "agent" || len(keys[0].Scopes) != 2 || keys[0].ExpiresAt.Year() != 2030 {
+		t.Fatalf("unexpected keys: %+v", keys)
+	}
+}
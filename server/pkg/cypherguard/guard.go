@@ -0,0 +1,295 @@
+// Package cypherguard classifies ad-hoc Cypher queries and refuses anything
+// that is not a bounded read: write and admin clauses, LOAD CSV, procedures
+// outside an allowlist and side-effecting functions
+package cypherguard
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Violation codes
+const (
+	CodeSyntax             = "syntax"
+	CodeMultipleStatements = "multiple_statements"
+	CodeWriteClause        = "write_clause"
+	CodeAdminClause        = "admin_clause"
+	CodeLoadCSV            = "load_csv"
+	CodeProcedure          = "procedure"
+	CodeFunction           = "function"
+	CodeLimit              = "limit"
+)
+
+var writeClauses = map[string]bool{
+	"CREATE": true, "MERGE": true, "SET": true, "DELETE": true,
+	"DETACH": true, "REMOVE": true, "FOREACH": true,
+}
+
+var adminClauses = map[string]bool{
+	"DROP": true, "ALTER": true, "GRANT": true, "REVOKE": true, "DENY": true,
+	"RENAME": true, "START": true, "STOP": true, "TERMINATE": true,
+	"SHOW": true, "USE": true, "PERIODIC": true, "TRANSACTIONS": true,
+}
+
+// readClauses are recorded for classification only
+var readClauses = map[string]bool{
+	"MATCH": true, "OPTIONAL": true, "WHERE": true, "WITH": true, "RETURN": true,
+	"UNWIND": true, "ORDER": true, "SKIP": true, "LIMIT": true, "UNION": true,
+	"CALL": true, "YIELD": true,
+}
+
+// DefaultProcedures are read-only schema procedures
+var DefaultProcedures = []string{
+	"db.labels",
+	"db.relationshipTypes",
+	"db.propertyKeys",
+	"db.schema.visualization",
+	"db.schema.nodeTypeProperties",
+	"db.schema.relTypeProperties",
+}
+
+// DefaultFunctionPrefixes are namespaces of pure functions
+var DefaultFunctionPrefixes = []string{
+	"apoc.text.", "apoc.coll.", "apoc.map.", "apoc.date.", "apoc.convert.", "apoc.math.",
+}
+
+// Policy controls what the guard accepts
+type Policy struct {
+	// MaxLimit is injected as LIMIT when the final RETURN has none and caps
+	// larger literal limits. Zero disables limit handling
+	MaxLimit int
+	// Procedures lists fully qualified procedure names that may be CALLed
+	Procedures []string
+	// FunctionPrefixes lists namespaces whose functions may be used;
+	// unqualified built-in functions are always allowed
+	FunctionPrefixes []string
+}
+
+// DefaultPolicy returns a policy with the default allowlists and the given limit
+func DefaultPolicy(maxLimit int) Policy {
+	return Policy{
+		MaxLimit:         maxLimit,
+		Procedures:       DefaultProcedures,
+		FunctionPrefixes: DefaultFunctionPrefixes,
+	}
+}
+
+// Violation explains why a query was refused
+type Violation struct {
+	Code   string `json:"code"`
+	Clause string `json:"clause,omitempty"`
+	Reason string `json:"reason"`
+}
+
+func (v *Violation) Error() string {
+	if v.Clause != "" {
+		return fmt.Sprintf("query refused (%s: %s): %s", v.Code, v.Clause, v.Reason)
+	}
+	return fmt.Sprintf("query refused (%s): %s", v.Code, v.Reason)
+}
+
+// Analysis is the classification of an accepted query
+type Analysis struct {
+	// Query is the query to execute, possibly with an injected or capped LIMIT
+	Query         string
+	Clauses       []string
+	Procedures    []string
+	LimitInjected bool
+	LimitCapped   bool
+}
+
+// Check classifies query and returns the query to run, or a *Violation
+func (p Policy) Check(query string) (Analysis, error) {
+	query = strings.TrimSpace(query)
+	tokens, err := lex(query)
+	if err != nil {
+		return Analysis{}, &Violation{Code: CodeSyntax, Reason: err.Error()}
+	}
+
+	// A single trailing semicolon is harmless; anything else is a second statement
+	if n := len(tokens); n > 0 && tokens[n-1].text == ";" && tokens[n-1].kind == tokPunct {
+		query = strings.TrimSpace(query[:tokens[n-1].start])
+		tokens = tokens[:n-1]
+	}
+	if len(tokens) == 0 {
+		return Analysis{}, &Violation{Code: CodeSyntax, Reason: "query is empty"}
+	}
+
+	analysis := Analysis{Query: query}
+	seenClauses := make(map[string]bool)
+
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		if tok.kind == tokPunct && tok.text == ";" {
+			return Analysis{}, &Violation{Code: CodeMultipleStatements, Reason: "only a single statement is allowed"}
+		}
+
+		// Only unquoted, unqualified names can be built-in functions; quoted
+		// or namespaced calls must be on the allowlist. This runs before the
+		// property and label shortcut, since a call can follow a colon as a
+		// map value ({x: apoc.cypher.run(...)})
+		name, next := qualifiedName(tokens, i)
+		if next < len(tokens) && tokens[next].text == "(" && (strings.Contains(name, ".") || hasQuotedIdent(tokens[i:next])) {
+			if !p.functionAllowed(name) {
+				return Analysis{}, &Violation{Code: CodeFunction, Clause: name, Reason: "function namespace is not on the allowlist"}
+			}
+			i = next - 1
+			continue
+		}
+
+		if isPropertyOrLabel(tokens, i) {
+			continue
+		}
+		kw := tok.upper()
+		if kw == "" && tok.kind != tokQuotedIdent {
+			continue
+		}
+
+		switch {
+		case writeClauses[kw]:
+			return Analysis{}, &Violation{Code: CodeWriteClause, Clause: kw, Reason: "graph_tool is read-only"}
+		case kw == "LOAD" && nextUpper(tokens, i) == "CSV":
+			return Analysis{}, &Violation{Code: CodeLoadCSV, Clause: "LOAD CSV", Reason: "loading external files is not allowed"}
+		case adminClauses[kw]:
+			return Analysis{}, &Violation{Code: CodeAdminClause, Clause: kw, Reason: "administration and transaction control are not allowed"}
+		}
+
+		if readClauses[kw] && !seenClauses[kw] {
+			seenClauses[kw] = true
+			analysis.Clauses = append(analysis.Clauses, kw)
+		}
+
+		if kw == "CALL" {
+			if i+1 < len(tokens) && tokens[i+1].text == "{" {
+				continue // subquery; its clauses are checked as the scan continues
+			}
+			procName, after := qualifiedName(tokens, i+1)
+			if procName == "" {
+				return Analysis{}, &Violation{Code: CodeSyntax, Clause: "CALL", Reason: "expected a procedure name or subquery"}
+			}
+			if !p.procedureAllowed(procName) {
+				return Analysis{}, &Violation{Code: CodeProcedure, Clause: procName, Reason: "procedure is not on the allowlist"}
+			}
+			analysis.Procedures = append(analysis.Procedures, procName)
+			i = after - 1
+			continue
+		}
+	}
+
+	if p.MaxLimit > 0 {
+		if err := p.enforceLimit(tokens, &analysis); err != nil {
+			return Analysis{}, err
+		}
+	}
+
+	return analysis, nil
+}
+
+// enforceLimit injects or caps the LIMIT of the final top-level RETURN. In a
+// UNION only the last branch is affected; callers should still cap rows
+func (p Policy) enforceLimit(tokens []token, analysis *Analysis) error {
+	returnIdx := -1
+	for i, tok := range tokens {
+		if tok.depth == 0 && tok.upper() == "RETURN" && !isPropertyOrLabel(tokens, i) {
+			returnIdx = i
+		}
+	}
+	if returnIdx < 0 {
+		return nil
+	}
+
+	for i := returnIdx + 1; i < len(tokens); i++ {
+		if tokens[i].depth != 0 || tokens[i].upper() != "LIMIT" {
+			continue
+		}
+		if i+1 >= len(tokens) || tokens[i+1].kind != tokNumber {
+			return &Violation{Code: CodeLimit, Clause: "LIMIT", Reason: "LIMIT must be an integer literal"}
+		}
+		if i+2 < len(tokens) {
+			return &Violation{Code: CodeLimit, Clause: "LIMIT", Reason: "LIMIT must be the last clause of the query"}
+		}
+		n, err := strconv.Atoi(tokens[i+1].text)
+		if err != nil {
+			return &Violation{Code: CodeLimit, Clause: "LIMIT", Reason: "LIMIT must be an integer literal"}
+		}
+		if n > p.MaxLimit {
+			lit := tokens[i+1]
+			analysis.Query = analysis.Query[:lit.start] + strconv.Itoa(p.MaxLimit) + analysis.Query[lit.end:]
+			analysis.LimitCapped = true
+		}
+		return nil
+	}
+
+	analysis.Query = fmt.Sprintf("%s\nLIMIT %d", analysis.Query, p.MaxLimit)
+	analysis.LimitInjected = true
+	return nil
+}
+
+func (p Policy) procedureAllowed(name string) bool {
+	for _, allowed := range p.Procedures {
+		if strings.EqualFold(allowed, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Policy) functionAllowed(name string) bool {
+	lower := strings.ToLower(name)
+	for _, prefix := range p.FunctionPrefixes {
+		if strings.HasPrefix(lower, strings.ToLower(prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPropertyOrLabel reports whether the identifier at i is a property key
+// (n.set), a label or relationship type (:Create) or a map key ({delete: 1})
+func isPropertyOrLabel(tokens []token, i int) bool {
+	if i > 0 && tokens[i-1].kind == tokPunct && (tokens[i-1].text == "." || tokens[i-1].text == ":") {
+		return true
+	}
+	if i+1 < len(tokens) && tokens[i+1].kind == tokPunct && tokens[i+1].text == ":" {
+		return true
+	}
+	return false
+}
+
+func nextUpper(tokens []token, i int) string {
+	if i+1 < len(tokens) {
+		return tokens[i+1].upper()
+	}
+	return ""
+}
+
+// qualifiedName joins ident(.ident)* starting at i and returns it with the
+// index after it. Parts may be backtick-quoted and dots may be surrounded by
+// whitespace, as Cypher resolves `apoc` . cypher.run to apoc.cypher.run
+func qualifiedName(tokens []token, i int) (string, int) {
+	if i >= len(tokens) || !isName(tokens[i]) {
+		return "", i
+	}
+
+	parts := []string{tokens[i].name()}
+	j := i + 1
+	for j+1 < len(tokens) && tokens[j].kind == tokPunct && tokens[j].text == "." && isName(tokens[j+1]) {
+		parts = append(parts, tokens[j+1].name())
+		j += 2
+	}
+	return strings.Join(parts, "."), j
+}
+
+func isName(t token) bool {
+	return t.kind == tokIdent || t.kind == tokQuotedIdent
+}
+
+func hasQuotedIdent(tokens []token) bool {
+	for _, t := range tokens {
+		if t.kind == tokQuotedIdent {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package cypherguard
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckRefusesUnsafeQueries(t *testing.T) {
+	policy := DefaultPolicy(100)
+
+	cases := []struct {
+		name  string
+		query string
+		code  string
+	}{
+		{"create", "CREATE (n:Job {id: 'x'}) RETURN n", CodeWriteClause},
+		{"detach delete", "MATCH (n) DETACH DELETE n", CodeWriteClause},
+		{"set after match", "MATCH (j:Job) SET j.score = 0 RETURN j", CodeWriteClause},
+		{"merge in subquery", "CALL { MERGE (n:X) RETURN n } RETURN n", CodeWriteClause},
+		{"load csv", "LOAD CSV FROM 'file:///etc/passwd' AS row RETURN row", CodeLoadCSV},
+		{"drop index", "DROP INDEX job_id", CodeAdminClause},
+		{"show users", "SHOW USERS", CodeAdminClause},
+		{"in transactions", "CALL { MATCH (n) RETURN n } IN TRANSACTIONS RETURN n", CodeAdminClause},
+		{"procedure", "CALL dbms.killQuery('q-1')", CodeProcedure},
+		{"apoc procedure", "CALL apoc.periodic.iterate('MATCH (n) RETURN n', 'DELETE n', {})", CodeProcedure},
+		{"apoc function", "RETURN apoc.cypher.runFirstColumnSingle('MATCH (n) DELETE n', {})", CodeFunction},
+		{"quoted namespace", "RETURN `apoc`.cypher.runFirstColumnMany('MATCH (n) DETACH DELETE n', {})", CodeFunction},
+		{"quoted function", "RETURN `apoc.cypher.runFirstColumnMany`('MATCH (n) DETACH DELETE n', {})", CodeFunction},
+		{"quoted last part", "RETURN apoc.cypher.`runFirstColumnMany`('MATCH (n) DETACH DELETE n', {})", CodeFunction},
+		{"spaced namespace", "RETURN apoc . cypher . runFirstColumnMany('MATCH (n) DETACH DELETE n', {})", CodeFunction},
+		{"spaced quoted namespace", "RETURN `apoc` .cypher. runFirstColumnSingle('MATCH (n) DELETE n', {})", CodeFunction},
+		{"function as map value", "RETURN {x: apoc.cypher.runFirstColumnMany('MATCH (n) DETACH DELETE n', {})} AS m", CodeFunction},
+		{"do.when as map value", "RETURN {x: apoc.do.when(true, 'MATCH (n) DETACH DELETE n', '', {})} AS m", CodeFunction},
+		{"quoted function as map value", "MATCH (n) RETURN n {.name, x: `apoc`.cypher.runFirstColumnSingle('MATCH (m) DELETE m', {})}", CodeFunction},
+		{"quoted procedure", "CALL `apoc`.`periodic`.iterate('MATCH (n) RETURN n', 'DELETE n', {})", CodeProcedure},
+		{"two statements", "MATCH (n) RETURN n; MATCH (m) DETACH DELETE m", CodeMultipleStatements},
+		{"limit parameter", "MATCH (n) RETURN n LIMIT $n", CodeLimit},
+		{"unterminated string", "MATCH (n) WHERE n.title = 'go RETURN n", CodeSyntax},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := policy.Check(tc.query)
+			var v *Violation
+			if !errors.As(err, &v) {
+				t.Fatalf("expected violation, got %v", err)
+			}
+			if v.Code != tc.code {
+				t.Fatalf("expected code %q, got %q (%v)", tc.code, v.Code, v)
+			}
+		})
+	}
+}
+
+func TestCheckAcceptsReads(t *testing.T) {
+	policy := DefaultPolicy(100)
+
+	cases := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "injects limit",
+			query: "MATCH (j:Job) RETURN j.title",
+			want:  "MATCH (j:Job) RETURN j.title\nLIMIT 100",
+		},
+		{
+			name:  "caps limit",
+			query: "MATCH (j:Job) RETURN j LIMIT 5000;",
+			want:  "MATCH (j:Job) RETURN j LIMIT 100",
+		},
+		{
+			name:  "keeps small limit",
+			query: "MATCH (j:Job) RETURN j LIMIT 10",
+			want:  "MATCH (j:Job) RETURN j LIMIT 10",
+		},
+		{
+			name:  "keywords in strings and properties",
+			query: "MATCH (j:Job) WHERE j.title CONTAINS 'DELETE' AND j.set = true RETURN {create: j.id} AS m LIMIT 1",
+			want:  "MATCH (j:Job) WHERE j.title CONTAINS 'DELETE' AND j.set = true RETURN {create: j.id} AS m LIMIT 1",
+		},
+		{
+			name:  "allowed function namespace",
+			query: "RETURN apoc . text.join(['a', 'b'], ',') AS s, `apoc`.coll.sum([1, 2]) AS n",
+			want:  "RETURN apoc . text.join(['a', 'b'], ',') AS s, `apoc`.coll.sum([1, 2]) AS n\nLIMIT 100",
+		},
+		{
+			name:  "allowed function as map value",
+			query: "MATCH (j:Job) RETURN j {.title, skills: apoc.coll.sort(j.skills), n: size(j.skills)}",
+			want:  "MATCH (j:Job) RETURN j {.title, skills: apoc.coll.sort(j.skills), n: size(j.skills)}\nLIMIT 100",
+		},
+		{
+			name:  "quoted properties and labels",
+			query: "MATCH (j:`Job`) RETURN j.`title`, toUpper(j.title), {`delete`: 1} AS m",
+			want:  "MATCH (j:`Job`) RETURN j.`title`, toUpper(j.title), {`delete`: 1} AS m\nLIMIT 100",
+		},
+		{
+			name:  "allowed procedure",
+			query: "CALL db.labels() YIELD label RETURN label",
+			want:  "CALL db.labels() YIELD label RETURN label\nLIMIT 100",
+		},
+		{
+			name:  "inner limit untouched",
+			query: "MATCH (j:Job) CALL { WITH j MATCH (j)-[:REQUIRES]->(s) RETURN s LIMIT 3 } RETURN j, s",
+			want:  "MATCH (j:Job) CALL { WITH j MATCH (j)-[:REQUIRES]->(s) RETURN s LIMIT 3 } RETURN j, s\nLIMIT 100",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			analysis, err := policy.Check(tc.query)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if analysis.Query != tc.want {
+				t.Fatalf("query mismatch:\n got: %q\nwant: %q", analysis.Query, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package cypherguard
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokIdent tokenKind = iota
+	tokQuotedIdent
+	tokString
+	tokNumber
+	tokParam
+	tokPunct
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	start int
+	end   int
+	depth int // nesting depth of (), {} and [] at the token
+}
+
+// upper returns the keyword form of an unquoted identifier
+func (t token) upper() string {
+	if t.kind != tokIdent {
+		return ""
+	}
+	return strings.ToUpper(t.text)
+}
+
+// lex splits a Cypher query into tokens, dropping whitespace and comments.
+// String literals and backtick identifiers become single tokens so that
+// keywords inside them are never mistaken for clauses
+func lex(query string) ([]token, error) {
+	var tokens []token
+	depth := 0
+	runes := []rune(query)
+	// byte offsets are needed for rewriting, so track them alongside rune indices
+	offsets := make([]int, len(runes)+1)
+	for i, off := 0, 0; i < len(runes); i++ {
+		offsets[i] = off
+		off += len(string(runes[i]))
+		offsets[i+1] = off
+	}
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '/':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i += 2
+			for i+1 < len(runes) && !(runes[i] == '*' && runes[i+1] == '/') {
+				i++
+			}
+			if i+1 >= len(runes) {
+				return nil, fmt.Errorf("unterminated block comment")
+			}
+			i += 2
+
+		case r == '\'' || r == '"' || r == '`':
+			start := i
+			i++
+			for i < len(runes) && runes[i] != r {
+				if runes[i] == '\\' && r != '`' {
+					i++
+				}
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated quoted text starting at offset %d", offsets[start])
+			}
+			i++
+			kind := tokString
+			if r == '`' {
+				kind = tokQuotedIdent
+			}
+			tokens = append(tokens, token{kind: kind, text: string(runes[start:i]), start: offsets[start], end: offsets[i], depth: depth})
+
+		case r == '$':
+			start := i
+			i++
+			for i < len(runes) && isIdentRune(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokParam, text: string(runes[start:i]), start: offsets[start], end: offsets[i], depth: depth})
+
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: string(runes[start:i]), start: offsets[start], end: offsets[i], depth: depth})
+
+		case isIdentRune(r):
+			start := i
+			for i < len(runes) && isIdentRune(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: string(runes[start:i]), start: offsets[start], end: offsets[i], depth: depth})
+
+		default:
+			switch r {
+			case '(', '{', '[':
+				tokens = append(tokens, token{kind: tokPunct, text: string(r), start: offsets[i], end: offsets[i+1], depth: depth})
+				depth++
+			case ')', '}', ']':
+				depth--
+				if depth < 0 {
+					return nil, fmt.Errorf("unbalanced %q at offset %d", r, offsets[i])
+				}
+				tokens = append(tokens, token{kind: tokPunct, text: string(r), start: offsets[i], end: offsets[i+1], depth: depth})
+			default:
+				tokens = append(tokens, token{kind: tokPunct, text: string(r), start: offsets[i], end: offsets[i+1], depth: depth})
+			}
+			i++
+		}
+	}
+
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced brackets")
+	}
+	return tokens, nil
+}
+
+// name returns an identifier without its backticks
+func (t token) name() string {
+	if t.kind == tokQuotedIdent && len(t.text) >= 2 {
+		return strings.ReplaceAll(t.text[1:len(t.text)-1], "``", "`")
+	}
+	return t.text
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
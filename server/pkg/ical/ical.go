@@ -0,0 +1,138 @@
+// Package ical writes RFC 5545 iCalendar feeds
+package ical
+
+import (
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	dateTimeLayout = "20060102T150405Z"
+	dateLayout     = "20060102"
+	// maxLineOctets is the content line limit before folding, excluding CRLF
+	maxLineOctets = 75
+)
+
+// Event is a VEVENT component
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Categories  []string
+	Start       time.Time
+	End         time.Time // optional; exclusive
+	AllDay      bool      // Start and End are dates in the floating time zone
+	Created     time.Time
+	Modified    time.Time
+}
+
+// Calendar is a VCALENDAR object
+type Calendar struct {
+	ProdID string
+	Name   string // X-WR-CALNAME shown by calendar apps
+	Events []Event
+}
+
+// Encode writes the calendar with CRLF line endings and folded lines. stamp
+// is used as DTSTAMP for every event
+func (c Calendar) Encode(w io.Writer, stamp time.Time) error {
+	e := &encoder{w: w}
+	e.line("BEGIN", "VCALENDAR")
+	e.line("VERSION", "2.0")
+	e.line("PRODID", c.ProdID)
+	e.line("CALSCALE", "GREGORIAN")
+	e.line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		e.line("X-WR-CALNAME", Escape(c.Name))
+	}
+
+	for _, ev := range c.Events {
+		e.line("BEGIN", "VEVENT")
+		e.line("UID", ev.UID)
+		e.line("DTSTAMP", formatDateTime(stamp))
+		if ev.AllDay {
+			e.line("DTSTART;VALUE=DATE", ev.Start.Format(dateLayout))
+			if !ev.End.IsZero() {
+				e.line("DTEND;VALUE=DATE", ev.End.Format(dateLayout))
+			}
+		} else {
+			e.line("DTSTART", formatDateTime(ev.Start))
+			if !ev.End.IsZero() {
+				e.line("DTEND", formatDateTime(ev.End))
+			}
+		}
+		e.line("SUMMARY", Escape(ev.Summary))
+		if ev.Description != "" {
+			e.line("DESCRIPTION", Escape(ev.Description))
+		}
+		if ev.Location != "" {
+			e.line("LOCATION", Escape(ev.Location))
+		}
+		if ev.URL != "" {
+			e.line("URL", ev.URL)
+		}
+		if len(ev.Categories) > 0 {
+			escaped := make([]string, 0, len(ev.Categories))
+			for _, cat := range ev.Categories {
+				escaped = append(escaped, Escape(cat))
+			}
+			e.line("CATEGORIES", strings.Join(escaped, ","))
+		}
+		if !ev.Created.IsZero() {
+			e.line("CREATED", formatDateTime(ev.Created))
+		}
+		if !ev.Modified.IsZero() {
+			e.line("LAST-MODIFIED", formatDateTime(ev.Modified))
+		}
+		e.line("END", "VEVENT")
+	}
+
+	e.line("END", "VCALENDAR")
+	return e.err
+}
+
+// Escape escapes a TEXT value: backslash, semicolon, comma and newlines
+func Escape(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`, "\r", `\n`).Replace(s)
+}
+
+func formatDateTime(t time.Time) string {
+	return t.UTC().Format(dateTimeLayout)
+}
+
+type encoder struct {
+	w   io.Writer
+	err error
+}
+
+// line writes name:value folded at 75 octets without splitting UTF-8
+// sequences; continuation lines start with a single space
+func (e *encoder) line(name, value string) {
+	if e.err != nil {
+		return
+	}
+	content := name + ":" + value
+
+	var sb strings.Builder
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		sb.WriteString(content[:cut])
+		sb.WriteString("\r\n ")
+		content = content[cut:]
+		// The leading space counts towards the next line's length
+		limit = maxLineOctets - 1
+	}
+	sb.WriteString(content)
+	sb.WriteString("\r\n")
+
+	_, e.err = io.WriteString(e.w, sb.String())
+}
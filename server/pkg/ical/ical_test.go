@@ -0,0 +1,73 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncodeFoldsAndEscapes(t *testing.T) {
+	start := time.Date(2026, 3, 4, 15, 30, 0, 0, time.FixedZone("EST", -5*3600))
+	cal := Calendar{
+		ProdID: "-//test//EN",
+		Events: []Event{{
+			UID:         "1@test",
+			Summary:     "Interview; round 2, onsite",
+			Description: strings.Repeat("é", 60) + "\nbring ID",
+			Start:       start,
+			End:         start.Add(time.Hour),
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := cal.Encode(&buf, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	out := buf.String()
+
+	if !strings.HasSuffix(out, "END:VCALENDAR\r\n") {
+		t.Fatalf("missing CRLF terminated END:VCALENDAR:\n%s", out)
+	}
+	for _, want := range []string{
+		"DTSTART:20260304T203000Z\r\n",
+		"DTEND:20260304T213000Z\r\n",
+		"DTSTAMP:20260301T000000Z\r\n",
+		`SUMMARY:Interview\; round 2\, onsite` + "\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+
+	var unfolded strings.Builder
+	for i, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("line %d is %d octets: %q", i, len(line), line)
+		}
+		if strings.HasPrefix(line, " ") {
+			unfolded.WriteString(line[1:])
+			continue
+		}
+		unfolded.WriteString("\n" + line)
+	}
+	wantDesc := "DESCRIPTION:" + strings.Repeat("é", 60) + `\nbring ID`
+	if !strings.Contains(unfolded.String(), wantDesc) {
+		t.Errorf("folded description does not unfold to %q", wantDesc)
+	}
+}
+
+func TestEncodeAllDay(t *testing.T) {
+	day := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	cal := Calendar{ProdID: "-//test//EN", Events: []Event{{
+		UID: "d@test", Summary: "Deadline", Start: day, End: day.AddDate(0, 0, 1), AllDay: true,
+	}}}
+
+	var buf bytes.Buffer
+	if err := cal.Encode(&buf, day); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	if !strings.Contains(buf.String(), "DTSTART;VALUE=DATE:20260501\r\nDTEND;VALUE=DATE:20260502\r\n") {
+		t.Fatalf("unexpected all-day dates:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,85 @@
+package jwtauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ServerMetadata is the part of the authorization server metadata (RFC
+// 8414, OpenID Connect Discovery) the validator needs
+type ServerMetadata struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+// Discover fetches the issuer's metadata, trying the OAuth authorization
+// server document first and the OpenID configuration second
+func Discover(ctx context.Context, issuer string, client *http.Client) (ServerMetadata, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	urls, err := metadataURLs(issuer)
+	if err != nil {
+		return ServerMetadata{}, err
+	}
+
+	var errs []string
+	for _, u := range urls {
+		meta, err := fetchMetadata(ctx, client, u)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		if meta.Issuer != issuer {
+			return ServerMetadata{}, fmt.Errorf("metadata at %s is for issuer %q, want %q", u, meta.Issuer, issuer)
+		}
+		if meta.JWKSURI == "" {
+			return ServerMetadata{}, fmt.Errorf("metadata at %s has no jwks_uri", u)
+		}
+		return meta, nil
+	}
+	return ServerMetadata{}, fmt.Errorf("discover %s: %s", issuer, strings.Join(errs, "; "))
+}
+
+// metadataURLs returns the well-known locations for issuer. RFC 8414 inserts
+// the well-known segment before the issuer path, OpenID appends it
+func metadataURLs(issuer string) ([]string, error) {
+	u, err := url.Parse(issuer)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid issuer URL %q", issuer)
+	}
+	path := strings.TrimSuffix(u.Path, "/")
+	base := u.Scheme + "://" + u.Host
+	return []string{
+		base + "/.well-known/oauth-authorization-server" + path,
+		base + path + "/.well-known/openid-configuration",
+	}, nil
+}
+
+func fetchMetadata(ctx context.Context, client *http.Client, u string) (ServerMetadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return ServerMetadata{}, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return ServerMetadata{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return ServerMetadata{}, fmt.Errorf("%s returned %s", u, resp.Status)
+	}
+
+	var meta ServerMetadata
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxJWKSBytes)).Decode(&meta); err != nil {
+		return ServerMetadata{}, fmt.Errorf("decode %s: %w", u, err)
+	}
+	return meta, nil
+}
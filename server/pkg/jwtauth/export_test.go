@@ -0,0 +1,6 @@
+package jwtauth
+
+import "time"
+
+// SetClock replaces the key set clock so tests can step past refresh limits
+func (s *KeySet) SetClock(clock func() time.Time) { s.clock = clock }
@@ -0,0 +1,275 @@
+// Package jwtauth validates JWT access tokens issued by an OAuth
+// authorization server, using the signing keys it publishes as a JWKS
+package jwtauth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrInvalidToken wraps every rejection caused by the token itself, as
+// opposed to failures fetching the issuer's keys
+var ErrInvalidToken = errors.New("invalid token")
+
+// DefaultLeeway is the clock skew tolerated on time claims
+const DefaultLeeway = 30 * time.Second
+
+// Config selects which tokens a Validator accepts
+type Config struct {
+	Issuer   string        // required iss claim
+	Audience string        // required aud entry, usually the resource URL
+	Leeway   time.Duration // clock skew tolerated on exp, nbf and iat
+}
+
+// Claims are the validated claims of an access token
+type Claims struct {
+	Issuer    string
+	Subject   string
+	Audience  []string
+	ExpiresAt time.Time
+	NotBefore time.Time
+	IssuedAt  time.Time
+	Scopes    []string // from scope (space-separated) or scp
+	Email     string
+	Name      string
+	Raw       map[string]any
+}
+
+// Validator checks the signature and claims of JWT access tokens
+type Validator struct {
+	cfg   Config
+	keys  *KeySet
+	clock func() time.Time
+}
+
+// NewValidator creates a validator for tokens signed with keys
+func NewValidator(cfg Config, keys *KeySet) *Validator {
+	return &Validator{cfg: cfg, keys: keys, clock: time.Now}
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Typ string `json:"typ"`
+}
+
+// Validate verifies token and returns its claims
+func (v *Validator) Validate(ctx context.Context, token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: not a JWS compact token", ErrInvalidToken)
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, fmt.Errorf("%w: header: %v", ErrInvalidToken, err)
+	}
+	hash, ok := algHashes[h.Alg]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported alg %q", ErrInvalidToken, h.Alg)
+	}
+
+	key, err := v.keys.Key(ctx, h.Kid)
+	if errors.Is(err, errUnknownKey) {
+		return nil, fmt.Errorf("%w: %v (kid %q)", ErrInvalidToken, err, h.Kid)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature encoding", ErrInvalidToken)
+	}
+	if err := verifySignature(h.Alg, hash, key, []byte(parts[0]+"."+parts[1]), sig); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	var raw map[string]any
+	if err := decodeSegment(parts[1], &raw); err != nil {
+		return nil, fmt.Errorf("%w: claims: %v", ErrInvalidToken, err)
+	}
+	claims, err := parseClaims(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if err := v.checkClaims(claims); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	return claims, nil
+}
+
+func (v *Validator) checkClaims(c *Claims) error {
+	leeway := v.cfg.Leeway
+	now := v.clock()
+
+	if c.Issuer != v.cfg.Issuer {
+		return fmt.Errorf("issuer %q is not trusted", c.Issuer)
+	}
+	if v.cfg.Audience != "" && !slices.Contains(c.Audience, v.cfg.Audience) {
+		return fmt.Errorf("token is not issued for %q", v.cfg.Audience)
+	}
+	if c.Subject == "" {
+		return fmt.Errorf("sub claim is required")
+	}
+	if c.ExpiresAt.IsZero() {
+		return fmt.Errorf("exp claim is required")
+	}
+	if !now.Before(c.ExpiresAt.Add(leeway)) {
+		return fmt.Errorf("token expired")
+	}
+	if !c.NotBefore.IsZero() && now.Add(leeway).Before(c.NotBefore) {
+		return fmt.Errorf("token is not valid yet")
+	}
+	if !c.IssuedAt.IsZero() && now.Add(leeway).Before(c.IssuedAt) {
+		return fmt.Errorf("token is issued in the future")
+	}
+	return nil
+}
+
+// algHashes lists the accepted algorithms; "none" and HMAC algorithms are
+// deliberately absent
+var algHashes = map[string]crypto.Hash{
+	"RS256": crypto.SHA256,
+	"RS384": crypto.SHA384,
+	"RS512": crypto.SHA512,
+	"PS256": crypto.SHA256,
+	"PS384": crypto.SHA384,
+	"PS512": crypto.SHA512,
+	"ES256": crypto.SHA256,
+	"ES384": crypto.SHA384,
+	"ES512": crypto.SHA512,
+	"EdDSA": 0,
+}
+
+func verifySignature(alg string, hash crypto.Hash, key crypto.PublicKey, signed, sig []byte) error {
+	var digest []byte
+	if hash != 0 {
+		h := hash.New()
+		h.Write(signed)
+		digest = h.Sum(nil)
+	}
+
+	switch alg[:2] {
+	case "RS":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%s needs an RSA key", alg)
+		}
+		if err := rsa.VerifyPKCS1v15(pub, hash, digest, sig); err != nil {
+			return fmt.Errorf("bad signature")
+		}
+	case "PS":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%s needs an RSA key", alg)
+		}
+		if err := rsa.VerifyPSS(pub, hash, digest, sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}); err != nil {
+			return fmt.Errorf("bad signature")
+		}
+	case "ES":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%s needs an EC key", alg)
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return fmt.Errorf("bad signature")
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return fmt.Errorf("bad signature")
+		}
+	case "Ed":
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return fmt.Errorf("%s needs an Ed25519 key", alg)
+		}
+		if !ed25519.Verify(pub, signed, sig) {
+			return fmt.Errorf("bad signature")
+		}
+	}
+	return nil
+}
+
+func decodeSegment(seg string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+func parseClaims(raw map[string]any) (*Claims, error) {
+	c := &Claims{Raw: raw}
+	c.Issuer, _ = raw["iss"].(string)
+	c.Subject, _ = raw["sub"].(string)
+	c.Email, _ = raw["email"].(string)
+	c.Name, _ = raw["name"].(string)
+
+	switch aud := raw["aud"].(type) {
+	case string:
+		c.Audience = []string{aud}
+	case []any:
+		for _, a := range aud {
+			if s, ok := a.(string); ok {
+				c.Audience = append(c.Audience, s)
+			}
+		}
+	}
+
+	if scope, ok := raw["scope"].(string); ok {
+		c.Scopes = strings.Fields(scope)
+	}
+	if scp, ok := raw["scp"].([]any); ok && len(c.Scopes) == 0 {
+		for _, s := range scp {
+			if str, ok := s.(string); ok {
+				c.Scopes = append(c.Scopes, str)
+			}
+		}
+	}
+
+	var err error
+	if c.ExpiresAt, err = numericDate(raw, "exp"); err != nil {
+		return nil, err
+	}
+	if c.NotBefore, err = numericDate(raw, "nbf"); err != nil {
+		return nil, err
+	}
+	if c.IssuedAt, err = numericDate(raw, "iat"); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func numericDate(raw map[string]any, name string) (time.Time, error) {
+	v, ok := raw[name]
+	if !ok {
+		return time.Time{}, nil
+	}
+	n, ok := v.(json.Number)
+	if !ok {
+		return time.Time{}, fmt.Errorf("%s claim must be a number", name)
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s claim must be a number", name)
+	}
+	sec := int64(f)
+	return time.Unix(sec, int64((f-float64(sec))*1e9)).UTC(), nil
+}
@@ -0,0 +1,109 @@
+package jwtauth_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/honeycarbs/project-ets/pkg/jwtauth"
+	"github.com/honeycarbs/project-ets/pkg/jwtauth/jwtauthtest"
+)
+
+const audience = "https://mcp.example.com/mcp/stream"
+
+func newValidator(t *testing.T, iss *jwtauthtest.Issuer) (*jwtauth.Validator, *jwtauth.KeySet) {
+	t.Helper()
+	meta, err := jwtauth.Discover(context.Background(), iss.URL, nil)
+	if err != nil {
+		t.Fatalf("discover: %v", err)
+	}
+	keys := jwtauth.NewKeySet(meta.JWKSURI, nil)
+	return jwtauth.NewValidator(jwtauth.Config{Issuer: iss.URL, Audience: audience, Leeway: jwtauth.DefaultLeeway}, keys), keys
+}
+
+func TestValidateAcceptsIssuerTokens(t *testing.T) {
+	iss := jwtauthtest.NewIssuer()
+	defer iss.Close()
+	v, _ := newValidator(t, iss)
+
+	claims, err := v.Validate(context.Background(), iss.Token("user-1", audience, "read", "write"))
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if claims.Subject != "user-1" || claims.Issuer != iss.URL {
+		t.Errorf("unexpected subject/issuer: %q %q", claims.Subject, claims.Issuer)
+	}
+	if strings.Join(claims.Scopes, " ") != "read write" {
+		t.Errorf("scopes = %v", claims.Scopes)
+	}
+}
+
+func TestValidateRejectsBadTokens(t *testing.T) {
+	iss := jwtauthtest.NewIssuer()
+	defer iss.Close()
+	other := jwtauthtest.NewIssuer()
+	defer other.Close()
+	v, _ := newValidator(t, iss)
+
+	now := time.Now()
+	valid := iss.Token("user-1", audience, "read")
+	parts := strings.Split(valid, ".")
+	noneHeader := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+
+	cases := map[string]string{
+		"wrong audience": iss.Token("user-1", "https://elsewhere.example.com", "read"),
+		"expired": iss.Sign(map[string]any{
+			"iss": iss.URL, "sub": "user-1", "aud": audience, "exp": now.Add(-time.Hour).Unix(),
+		}),
+		"not yet valid": iss.Sign(map[string]any{
+			"iss": iss.URL, "sub": "user-1", "aud": audience, "exp": now.Add(2 * time.Hour).Unix(), "nbf": now.Add(time.Hour).Unix(),
+		}),
+		"missing exp":      iss.Sign(map[string]any{"iss": iss.URL, "sub": "user-1", "aud": audience}),
+		"missing subject":  iss.Sign(map[string]any{"iss": iss.URL, "aud": audience, "exp": now.Add(time.Hour).Unix()}),
+		"other issuer":     other.Token("user-1", audience, "read"),
+		"tampered payload": parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte(`{"iss":"`+iss.URL+`","sub":"admin","aud":"`+audience+`","exp":9999999999}`)) + "." + parts[2],
+		"alg none":         noneHeader + "." + parts[1] + ".",
+		"garbage":          "not-a-jwt",
+	}
+	for name, token := range cases {
+		_, err := v.Validate(context.Background(), token)
+		if !errors.Is(err, jwtauth.ErrInvalidToken) {
+			t.Errorf("%s: err = %v, want ErrInvalidToken", name, err)
+		}
+	}
+}
+
+func TestValidatePicksUpRotatedKeys(t *testing.T) {
+	iss := jwtauthtest.NewIssuer()
+	defer iss.Close()
+	v, keys := newValidator(t, iss)
+
+	now := time.Now()
+	keys.SetClock(func() time.Time { return now })
+	if _, err := v.Validate(context.Background(), iss.Token("user-1", audience)); err != nil {
+		t.Fatalf("validate before rotation: %v", err)
+	}
+
+	iss.Rotate()
+	rotated := iss.Token("user-1", audience)
+	if _, err := v.Validate(context.Background(), rotated); !errors.Is(err, jwtauth.ErrInvalidToken) {
+		t.Fatalf("unknown kid within the refresh limit: err = %v, want ErrInvalidToken", err)
+	}
+
+	keys.SetClock(func() time.Time { return now.Add(time.Minute) })
+	if _, err := v.Validate(context.Background(), rotated); err != nil {
+		t.Fatalf("validate after rotation: %v", err)
+	}
+}
+
+func TestDiscoverRejectsMismatchedIssuer(t *testing.T) {
+	iss := jwtauthtest.NewIssuer()
+	defer iss.Close()
+
+	if _, err := jwtauth.Discover(context.Background(), iss.URL+"/tenant", nil); err == nil {
+		t.Fatal("expected discovery of an unknown issuer path to fail")
+	}
+}
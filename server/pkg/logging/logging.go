@@ -23,6 +23,11 @@ func New(level string) *Logger {
 	return &Logger{s: z.Sugar()}
 }
 
+// Nop returns a logger that discards everything
+func Nop() *Logger {
+	return &Logger{s: zap.NewNop().Sugar()}
+}
+
 func (l *Logger) With(keyvals ...any) *Logger {
 	return &Logger{s: l.s.With(keyvals...)}
 }
@@ -0,0 +1,87 @@
+// Package notify delivers messages to webhooks, Slack and email, retrying
+// transient failures with exponential backoff
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Message is one notification. Text is the rendered body; Payload, when
+// set, is what JSON webhooks receive instead of the subject and text
+type Message struct {
+	Subject string
+	Text    string
+	Payload any
+}
+
+// Sink delivers messages to one destination
+type Sink interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// PermanentError marks a failure that retrying will not fix, such as a
+// rejected request
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string { return e.Err.Error() }
+
+func (e *PermanentError) Unwrap() error { return e.Err }
+
+// Permanent wraps err so Send does not retry it
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+// RetryPolicy controls how often and how quickly Send retries
+type RetryPolicy struct {
+	Attempts   int           // total attempts, at least 1
+	Backoff    time.Duration // wait before the second attempt, doubled after each failure
+	MaxBackoff time.Duration // upper bound for a single wait; 0 means no bound
+}
+
+// DefaultRetryPolicy is used when a policy has no attempts configured
+var DefaultRetryPolicy = RetryPolicy{Attempts: 3, Backoff: 2 * time.Second, MaxBackoff: 30 * time.Second}
+
+// Send delivers msg through sink, retrying failures that are not permanent
+// until the policy's attempts are used up or ctx is done
+func Send(ctx context.Context, sink Sink, msg Message, policy RetryPolicy) error {
+	if policy.Attempts <= 0 {
+		policy = DefaultRetryPolicy
+	}
+
+	wait := policy.Backoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = sink.Send(ctx, msg)
+		if err == nil {
+			return nil
+		}
+
+		var permanent *PermanentError
+		if errors.As(err, &permanent) || attempt >= policy.Attempts {
+			break
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("notify: %w (last error: %v)", ctx.Err(), err)
+		case <-timer.C:
+		}
+
+		wait *= 2
+		if policy.MaxBackoff > 0 && wait > policy.MaxBackoff {
+			wait = policy.MaxBackoff
+		}
+	}
+	return err
+}
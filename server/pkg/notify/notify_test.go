@@ -0,0 +1,296 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/quotedprintable"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var fastRetry = RetryPolicy{Attempts: 3, Backoff: time.Millisecond}
+
+func TestWebhookSendsPayloadAndHeaders(t *testing.T) {
+	var got struct {
+		Search string   `json:"search"`
+		Jobs   []string `json:"jobs"`
+	}
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("content type = %q", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	sink := NewWebhookSink(srv.URL, map[string]string{"Authorization": "Bearer secret"}, srv.Client())
+	msg := Message{Subject: "ignored", Payload: map[string]any{"search": "go", "jobs": []string{"a", "b"}}}
+	if err := Send(context.Background(), sink, msg, fastRetry); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	if auth != "Bearer secret" {
+		t.Errorf("authorization = %q", auth)
+	}
+	if got.Search != "go" || strings.Join(got.Jobs, ",") != "a,b" {
+		t.Errorf("payload = %+v", got)
+	}
+}
+
+func TestWebhookRetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	sink := NewWebhookSink(srv.URL, nil, srv.Client())
+	if err := Send(context.Background(), sink, Message{Text: "hi"}, fastRetry); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("calls = %d, want 3", n)
+	}
+}
+
+func TestWebhookDoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Error(w, "no such hook", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	sink := NewWebhookSink(srv.URL, nil, srv.Client())
+	err := Send(context.Background(), sink, Message{Text: "hi"}, fastRetry)
+	var permanent *PermanentError
+	if !errors.As(err, &permanent) {
+		t.Fatalf("err = %v, want permanent error", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("calls = %d, want 1", n)
+	}
+}
+
+func TestSendGivesUpAfterAttempts(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Error(w, "down", http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	sink := NewWebhookSink(srv.URL, nil, srv.Client())
+	if err := Send(context.Background(), sink, Message{Text: "hi"}, fastRetry); err == nil {
+		t.Fatal("expected error")
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("calls = %d, want 3", n)
+	}
+}
+
+func TestSlackFormatsText(t *testing.T) {
+	var got map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	sink := NewSlackSink(srv.URL, srv.Client())
+	if err := Send(context.Background(), sink, Message{Subject: "2 new jobs", Text: "• Go dev"}, fastRetry); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if want := "*2 new jobs*\n• Go dev"; got["text"] != want {
+		t.Errorf("text = %q, want %q", got["text"], want)
+	}
+}
+
+func TestSMTPDeliversMessage(t *testing.T) {
+	srv := startFakeSMTP(t, 0)
+
+	sink, err := NewSMTPSink(SMTPConfig{Host: "127.0.0.1", Port: srv.port}, "Job Alerts <alerts@example.com>", []string{"me@example.com", "you@example.com"})
+	if err != nil {
+		t.Fatalf("new sink: %v", err)
+	}
+	body := "• Senior Go Engineer at Acme — naïve résumé parsing\nhttps://example.com/jobs/1"
+	if err := Send(context.Background(), sink, Message{Subject: "1 new job for Go", Text: body}, fastRetry); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	mails := srv.messages()
+	if len(mails) != 1 {
+		t.Fatalf("got %d mails, want 1", len(mails))
+	}
+	m := mails[0]
+	if m.from != "alerts@example.com" {
+		t.Errorf("MAIL FROM = %q", m.from)
+	}
+	if strings.Join(m.rcpt, ",") != "me@example.com,you@example.com" {
+		t.Errorf("RCPT TO = %v", m.rcpt)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(m.data))
+	if err != nil {
+		t.Fatalf("parse message: %v", err)
+	}
+	if subject := parsed.Header.Get("Subject"); subject != "1 new job for Go" {
+		t.Errorf("subject = %q", subject)
+	}
+	decoded, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	if err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if got := strings.TrimSpace(strings.ReplaceAll(string(decoded), "\r\n", "\n")); got != body {
+		t.Errorf("body = %q, want %q", got, body)
+	}
+}
+
+func TestSMTPRejectionIsPermanent(t *testing.T) {
+	srv := startFakeSMTP(t, 550)
+
+	sink, err := NewSMTPSink(SMTPConfig{Host: "127.0.0.1", Port: srv.port}, "alerts@example.com", []string{"nobody@example.com"})
+	if err != nil {
+		t.Fatalf("new sink: %v", err)
+	}
+	err = Send(context.Background(), sink, Message{Subject: "x", Text: "y"}, fastRetry)
+	var permanent *PermanentError
+	if !errors.As(err, &permanent) {
+		t.Fatalf("err = %v, want permanent error", err)
+	}
+	if n := srv.sessions.Load(); n != 1 {
+		t.Errorf("sessions = %d, want 1", n)
+	}
+}
+
+type fakeMail struct {
+	from string
+	rcpt []string
+	data string
+}
+
+// fakeSMTP speaks just enough SMTP for net/smtp; rejectRcpt, when set, is
+// the reply code for every RCPT command
+type fakeSMTP struct {
+	port       int
+	rejectRcpt int
+	sessions   atomic.Int32
+
+	mu   sync.Mutex
+	mail []fakeMail
+}
+
+func startFakeSMTP(t *testing.T, rejectRcpt int) *fakeSMTP {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	srv := &fakeSMTP{port: ln.Addr().(*net.TCPAddr).Port, rejectRcpt: rejectRcpt}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go srv.serve(conn)
+		}
+	}()
+	return srv
+}
+
+func (s *fakeSMTP) messages() []fakeMail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]fakeMail(nil), s.mail...)
+}
+
+func (s *fakeSMTP) serve(conn net.Conn) {
+	defer conn.Close()
+	s.sessions.Add(1)
+
+	r := bufio.NewReader(conn)
+	reply := func(code int, text string) {
+		_, _ = io.WriteString(conn, strconv.Itoa(code)+" "+text+"\r\n")
+	}
+	reply(220, "fake ESMTP")
+
+	var current fakeMail
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			_, _ = io.WriteString(conn, "250-fake\r\n250 8BITMIME\r\n")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			current = fakeMail{from: smtpPath(line)}
+			reply(250, "ok")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			if s.rejectRcpt != 0 {
+				reply(s.rejectRcpt, "mailbox unavailable")
+				continue
+			}
+			current.rcpt = append(current.rcpt, smtpPath(line))
+			reply(250, "ok")
+		case cmd == "DATA":
+			reply(354, "go ahead")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(l, "."))
+			}
+			current.data = data.String()
+			s.mu.Lock()
+			s.mail = append(s.mail, current)
+			s.mu.Unlock()
+			reply(250, "queued")
+		case cmd == "QUIT":
+			reply(221, "bye")
+			return
+		default:
+			reply(250, "ok")
+		}
+	}
+}
+
+// smtpPath returns the address between angle brackets of a MAIL or RCPT command
+func smtpPath(line string) string {
+	start := strings.IndexByte(line, '<')
+	end := strings.IndexByte(line, '>')
+	if start < 0 || end < start {
+		return ""
+	}
+	return line[start+1 : end]
+}
@@ -0,0 +1,170 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTPConfig describes the mail server used by SMTPSink
+type SMTPConfig struct {
+	Host     string
+	Port     int // default 587
+	Username string
+	Password string
+	// ImplicitTLS connects with TLS from the start (usually port 465);
+	// otherwise STARTTLS is used when the server offers it
+	ImplicitTLS bool
+	Timeout     time.Duration // default 10s
+}
+
+// SMTPSink emails messages as plain text
+type SMTPSink struct {
+	cfg  SMTPConfig
+	from mail.Address
+	to   []mail.Address
+	now  func() time.Time
+}
+
+// NewSMTPSink creates a sink that mails from one address to the recipients
+func NewSMTPSink(cfg SMTPConfig, from string, to []string) (*SMTPSink, error) {
+	if cfg.Host == "" {
+		return nil, fmt.Errorf("notify: smtp host is required")
+	}
+	if cfg.Port == 0 {
+		cfg.Port = 587
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultHTTPTimeout
+	}
+
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("notify: invalid from address %q", from)
+	}
+	if len(to) == 0 {
+		return nil, fmt.Errorf("notify: at least one recipient is required")
+	}
+	recipients := make([]mail.Address, 0, len(to))
+	for _, addr := range to {
+		parsed, err := mail.ParseAddress(addr)
+		if err != nil {
+			return nil, fmt.Errorf("notify: invalid recipient %q", addr)
+		}
+		recipients = append(recipients, *parsed)
+	}
+
+	return &SMTPSink{cfg: cfg, from: *sender, to: recipients, now: time.Now}, nil
+}
+
+// Send delivers msg in one SMTP transaction. 5xx replies are permanent
+func (s *SMTPSink) Send(ctx context.Context, msg Message) error {
+	err := s.send(ctx, msg)
+	var reply *textproto.Error
+	if errors.As(err, &reply) && reply.Code >= 500 {
+		return Permanent(fmt.Errorf("notify: smtp: %w", err))
+	}
+	if err != nil {
+		return fmt.Errorf("notify: smtp: %w", err)
+	}
+	return nil
+}
+
+func (s *SMTPSink) send(ctx context.Context, msg Message) error {
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	if s.cfg.ImplicitTLS {
+		conn = tls.Client(conn, &tls.Config{ServerName: s.cfg.Host})
+	}
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if !s.cfg.ImplicitTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(&tls.Config{ServerName: s.cfg.Host}); err != nil {
+				return err
+			}
+		}
+	}
+	if s.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(s.from.Address); err != nil {
+		return err
+	}
+	for _, rcpt := range s.to {
+		if err := client.Rcpt(rcpt.Address); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(s.compose(msg)); err != nil {
+		_ = w.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// compose renders a text/plain message with a quoted-printable body
+func (s *SMTPSink) compose(msg Message) []byte {
+	to := make([]string, 0, len(s.to))
+	for _, addr := range s.to {
+		to = append(to, addr.String())
+	}
+
+	var buf bytes.Buffer
+	header := func(key, value string) {
+		buf.WriteString(key + ": " + value + "\r\n")
+	}
+	header("From", s.from.String())
+	header("To", strings.Join(to, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", s.now().Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=utf-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+	buf.WriteString("\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	// In text mode the writer turns every line break into CRLF
+	_, _ = qp.Write([]byte(msg.Text))
+	_ = qp.Close()
+	buf.WriteString("\r\n")
+	return buf.Bytes()
+}
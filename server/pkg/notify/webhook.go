@@ -0,0 +1,98 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const defaultHTTPTimeout = 10 * time.Second
+
+// WebhookSink posts each message as JSON to a URL
+type WebhookSink struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+// NewWebhookSink creates a sink that posts to url with the given extra
+// headers; client may be nil to use a client with a 10s timeout
+func NewWebhookSink(url string, headers map[string]string, client *http.Client) *WebhookSink {
+	if client == nil {
+		client = &http.Client{Timeout: defaultHTTPTimeout}
+	}
+	return &WebhookSink{url: url, headers: headers, client: client}
+}
+
+// Send posts msg.Payload, or {"subject", "text"} when there is no payload
+func (s *WebhookSink) Send(ctx context.Context, msg Message) error {
+	body := msg.Payload
+	if body == nil {
+		body = map[string]string{"subject": msg.Subject, "text": msg.Text}
+	}
+	return postJSON(ctx, s.client, s.url, s.headers, body)
+}
+
+// SlackSink posts messages to a Slack-compatible incoming webhook
+type SlackSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewSlackSink creates a sink for a Slack incoming webhook URL; client may
+// be nil to use a client with a 10s timeout
+func NewSlackSink(url string, client *http.Client) *SlackSink {
+	if client == nil {
+		client = &http.Client{Timeout: defaultHTTPTimeout}
+	}
+	return &SlackSink{url: url, client: client}
+}
+
+// Send posts {"text"} with the subject in bold above the body
+func (s *SlackSink) Send(ctx context.Context, msg Message) error {
+	text := msg.Text
+	if msg.Subject != "" {
+		text = "*" + msg.Subject + "*\n" + text
+	}
+	return postJSON(ctx, s.client, s.url, nil, map[string]string{"text": text})
+}
+
+// postJSON treats 429 and 5xx responses as retryable and other non-2xx
+// responses as permanent failures
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return Permanent(fmt.Errorf("notify: encode payload: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return Permanent(fmt.Errorf("notify: build request: %w", err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("notify: post: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err = fmt.Errorf("notify: webhook returned %s: %s", resp.Status, bytes.TrimSpace(snippet))
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		return err
+	}
+	return Permanent(err)
+}
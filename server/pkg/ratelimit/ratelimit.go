@@ -0,0 +1,318 @@
+// Package ratelimit enforces token bucket rate limits and daily quotas per
+// client, both across all of a client's calls and per named operation, and
+// counts the calls it lets through and refuses
+package ratelimit
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// AnyTool is the Tools key whose policy applies to tools without an entry
+const AnyTool = "*"
+
+// Refusal reasons
+const (
+	ReasonRate  = "rate"  // the token bucket is empty
+	ReasonQuota = "quota" // the daily quota is used up
+)
+
+// Policy limits one stream of calls. Zero fields do not limit
+type Policy struct {
+	PerMinute float64 `json:"per_minute,omitempty"` // sustained calls per minute
+	Burst     int     `json:"burst,omitempty"`      // calls allowed at once; defaults to PerMinute rounded up
+	Daily     int     `json:"daily,omitempty"`      // calls per UTC day
+}
+
+// Policies limit the calls of one client
+type Policies struct {
+	Client Policy            `json:"client"` // all of the client's calls together
+	Tools  map[string]Policy `json:"tools"`  // calls of one tool; "*" applies to tools without an entry
+}
+
+// Config is the JSON layout of a policy file. Clients override the default
+// policies per client name: a non-zero client policy replaces the default
+// one and tool entries replace the default entry for the same tool
+type Config struct {
+	Policies
+	Clients map[string]Policies `json:"clients,omitempty"`
+}
+
+// DefaultConfig keeps a runaway agent loop from hammering the job provider
+// and Neo4j while leaving interactive use unaffected
+func DefaultConfig() Config {
+	return Config{Policies: Policies{
+		Client: Policy{PerMinute: 120, Burst: 30},
+		Tools: map[string]Policy{
+			"job_search":       {PerMinute: 10, Burst: 5, Daily: 500},
+			"job_recheck":      {PerMinute: 10, Burst: 5, Daily: 500},
+			"saved_search_run": {PerMinute: 5, Burst: 3},
+			"graph_tool":       {PerMinute: 30, Burst: 10},
+		},
+	}}
+}
+
+// LoadFile reads a policy file
+func LoadFile(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("read rate limit config: %w", err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("rate limit config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Decision is the outcome of one call
+type Decision struct {
+	Allowed bool
+	// The fields below describe the refusal with the longest wait
+	Reason     string        // ReasonRate or ReasonQuota
+	Tool       string        // the tool whose policy refused, empty for the client policy
+	Policy     Policy        // the policy that refused
+	RetryAfter time.Duration // until the call would be allowed
+}
+
+// Usage counts the calls of one client, in total (Tool is empty) or for one tool
+type Usage struct {
+	Client     string // the key the client's counters are kept under
+	Name       string // the name its policies are looked up by
+	Tool       string
+	Allowed    int64     // calls let through since the server started
+	Limited    int64     // calls refused since the server started
+	Today      int       // calls counted against today's quota
+	DailyQuota int       // zero when the policy has no daily quota
+	LastCall   time.Time // last call, allowed or not
+}
+
+// counter is a token bucket, a daily quota and the usage of one stream
+type counter struct {
+	tokens   float64
+	refilled time.Time
+	day      time.Time
+	today    int
+
+	allowed  int64
+	limited  int64
+	lastCall time.Time
+}
+
+type client struct {
+	name  string
+	total counter
+	tools map[string]*counter
+}
+
+// Limiter decides whether calls are allowed. It is safe for concurrent use
+type Limiter struct {
+	cfg   Config
+	clock func() time.Time
+
+	mu      sync.Mutex
+	clients map[string]*client
+}
+
+// New validates cfg and creates a limiter over it
+func New(cfg Config) (*Limiter, error) {
+	if err := cfg.Policies.validate(""); err != nil {
+		return nil, err
+	}
+	for name, policies := range cfg.Clients {
+		if err := policies.validate(name); err != nil {
+			return nil, err
+		}
+	}
+	return &Limiter{cfg: cfg, clock: time.Now, clients: make(map[string]*client)}, nil
+}
+
+func (p Policies) validate(clientName string) error {
+	check := func(what string, policy Policy) error {
+		if policy.PerMinute < 0 || policy.Burst < 0 || policy.Daily < 0 {
+			if clientName != "" {
+				return fmt.Errorf("rate limit policy for %s of client %q: limits must not be negative", what, clientName)
+			}
+			return fmt.Errorf("rate limit policy for %s: limits must not be negative", what)
+		}
+		return nil
+	}
+	if err := check("all tools", p.Client); err != nil {
+		return err
+	}
+	for tool, policy := range p.Tools {
+		if err := check(tool, policy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clientPolicy returns the policy for all of a client's calls
+func (l *Limiter) clientPolicy(name string) Policy {
+	if override, ok := l.cfg.Clients[name]; ok && override.Client != (Policy{}) {
+		return override.Client
+	}
+	return l.cfg.Client
+}
+
+// toolPolicy returns the policy for a client's calls of one tool
+func (l *Limiter) toolPolicy(name, tool string) Policy {
+	override := l.cfg.Clients[name]
+	for _, key := range []string{tool, AnyTool} {
+		if policy, ok := override.Tools[key]; ok {
+			return policy
+		}
+		if policy, ok := l.cfg.Tools[key]; ok {
+			return policy
+		}
+	}
+	return Policy{}
+}
+
+// Allow records a call of tool by the client whose counters are kept under
+// key and reports whether it may proceed. name selects the client's
+// policies; clients sharing a name share its overrides but not its counters.
+// Refused calls use up neither the rate nor the quota
+func (l *Limiter) Allow(key, name, tool string) Decision {
+	now := l.clock()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	c, ok := l.clients[key]
+	if !ok {
+		c = &client{name: name, tools: make(map[string]*counter)}
+		l.clients[key] = c
+	}
+	tc, ok := c.tools[tool]
+	if !ok {
+		tc = &counter{}
+		c.tools[tool] = tc
+	}
+
+	clientPolicy := l.clientPolicy(name)
+	toolPolicy := l.toolPolicy(name, tool)
+
+	decision := Decision{Allowed: true}
+	refuse := func(reason, tool string, policy Policy, wait time.Duration) {
+		if decision.Allowed || wait > decision.RetryAfter {
+			decision = Decision{Reason: reason, Tool: tool, Policy: policy, RetryAfter: wait}
+		}
+	}
+	streams := []struct {
+		tool   string
+		policy Policy
+		count  *counter
+	}{
+		{tool, toolPolicy, tc},
+		{"", clientPolicy, &c.total},
+	}
+	for _, s := range streams {
+		s.count.refill(s.policy, now)
+		if reason, wait, ok := s.count.check(s.policy, now); !ok {
+			refuse(reason, s.tool, s.policy, wait)
+		}
+	}
+
+	for _, s := range streams {
+		s.count.lastCall = now
+		if !decision.Allowed {
+			s.count.limited++
+			continue
+		}
+		s.count.allowed++
+		s.count.today++
+		if s.policy.PerMinute > 0 {
+			s.count.tokens--
+		}
+	}
+	return decision
+}
+
+// refill adds the tokens earned since the last call and starts a new quota
+// day at UTC midnight
+func (c *counter) refill(policy Policy, now time.Time) {
+	if day := now.UTC().Truncate(24 * time.Hour); !day.Equal(c.day) {
+		c.day = day
+		c.today = 0
+	}
+
+	burst := policy.burst()
+	if c.refilled.IsZero() {
+		c.tokens = burst
+	} else if elapsed := now.Sub(c.refilled); elapsed > 0 {
+		c.tokens = math.Min(burst, c.tokens+elapsed.Minutes()*policy.PerMinute)
+	}
+	c.refilled = now
+}
+
+// check reports whether one more call fits the policy, and otherwise how
+// long until it does
+func (c *counter) check(policy Policy, now time.Time) (string, time.Duration, bool) {
+	if policy.Daily > 0 && c.today >= policy.Daily {
+		return ReasonQuota, c.day.Add(24 * time.Hour).Sub(now), false
+	}
+	if policy.PerMinute > 0 && c.tokens < 1 {
+		wait := time.Duration((1 - c.tokens) / policy.PerMinute * float64(time.Minute))
+		return ReasonRate, wait, false
+	}
+	return "", 0, true
+}
+
+func (p Policy) burst() float64 {
+	if p.Burst > 0 {
+		return float64(p.Burst)
+	}
+	return math.Max(1, math.Ceil(p.PerMinute))
+}
+
+// Usage returns the counters of the clients whose key or name is client, or
+// of every client when it is empty, sorted by key with the client total
+// before its tools
+func (l *Limiter) Usage(client string) []Usage {
+	now := l.clock()
+	today := now.UTC().Truncate(24 * time.Hour)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var out []Usage
+	add := func(key, name, tool string, policy Policy, c *counter) {
+		u := Usage{
+			Client:     key,
+			Name:       name,
+			Tool:       tool,
+			Allowed:    c.allowed,
+			Limited:    c.limited,
+			DailyQuota: policy.Daily,
+			LastCall:   c.lastCall,
+		}
+		if c.day.Equal(today) {
+			u.Today = c.today
+		}
+		out = append(out, u)
+	}
+	for key, c := range l.clients {
+		if client != "" && key != client && c.name != client {
+			continue
+		}
+		add(key, c.name, "", l.clientPolicy(c.name), &c.total)
+		for tool, tc := range c.tools {
+			add(key, c.name, tool, l.toolPolicy(c.name, tool), tc)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Client != out[j].Client {
+			return out[i].Client < out[j].Client
+		}
+		return out[i].Tool < out[j].Tool
+	})
+	return out
+}
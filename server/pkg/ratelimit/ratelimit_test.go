@@ -0,0 +1,198 @@
+package ratelimit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newLimiter(t *testing.T, cfg Config, start time.Time) (*Limiter, *fakeClock) {
+	t.Helper()
+	l, err := New(cfg)
+	if err != nil {
+		t.Fatalf("new limiter: %v", err)
+	}
+	clock := &fakeClock{now: start}
+	l.clock = func() time.Time { return clock.now }
+	return l, clock
+}
+
+func TestBurstThenSustainedRate(t *testing.T) {
+	l, clock := newLimiter(t, Config{Policies: Policies{
+		Tools: map[string]Policy{"job_search": {PerMinute: 6, Burst: 2}},
+	}}, time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC))
+
+	for i := 0; i < 2; i++ {
+		if d := l.Allow("alice", "alice", "job_search"); !d.Allowed {
+			t.Fatalf("call %d within the burst refused: %+v", i+1, d)
+		}
+	}
+	d := l.Allow("alice", "alice", "job_search")
+	if d.Allowed || d.Reason != ReasonRate || d.Tool != "job_search" {
+		t.Fatalf("third call = %+v, want a rate refusal by job_search", d)
+	}
+	if d.RetryAfter != 10*time.Second {
+		t.Fatalf("retry after %v, want 10s", d.RetryAfter)
+	}
+
+	if d := l.Allow("bob", "bob", "job_search"); !d.Allowed {
+		t.Fatalf("another client shares alice's bucket: %+v", d)
+	}
+	if d := l.Allow("alice", "alice", "profile_get"); !d.Allowed {
+		t.Fatalf("a tool without a policy was limited: %+v", d)
+	}
+
+	clock.advance(10 * time.Second)
+	if d := l.Allow("alice", "alice", "job_search"); !d.Allowed {
+		t.Fatalf("call after the retry delay refused: %+v", d)
+	}
+}
+
+func TestDailyQuotaResetsAtUTCMidnight(t *testing.T) {
+	l, clock := newLimiter(t, Config{Policies: Policies{
+		Client: Policy{Daily: 2},
+	}}, time.Date(2026, 5, 1, 23, 0, 0, 0, time.UTC))
+
+	l.Allow("alice", "alice", "job_search")
+	l.Allow("alice", "alice", "profile_get")
+	d := l.Allow("alice", "alice", "job_analysis")
+	if d.Allowed || d.Reason != ReasonQuota || d.Tool != "" {
+		t.Fatalf("third call = %+v, want a quota refusal by the client policy", d)
+	}
+	if d.RetryAfter != time.Hour {
+		t.Fatalf("retry after %v, want the hour until midnight", d.RetryAfter)
+	}
+
+	clock.advance(time.Hour)
+	if d := l.Allow("alice", "alice", "job_analysis"); !d.Allowed {
+		t.Fatalf("call on the next day refused: %+v", d)
+	}
+}
+
+func TestRefusalReportsLongestWait(t *testing.T) {
+	l, _ := newLimiter(t, Config{Policies: Policies{
+		Client: Policy{Daily: 1},
+		Tools:  map[string]Policy{AnyTool: {PerMinute: 1}},
+	}}, time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC))
+
+	l.Allow("alice", "alice", "job_search")
+	d := l.Allow("alice", "alice", "job_search")
+	if d.Allowed || d.Reason != ReasonQuota {
+		t.Fatalf("decision = %+v, want the daily quota, which outlasts the rate limit", d)
+	}
+}
+
+func TestClientOverrides(t *testing.T) {
+	l, _ := newLimiter(t, Config{
+		Policies: Policies{
+			Client: Policy{PerMinute: 1},
+			Tools:  map[string]Policy{"job_search": {PerMinute: 1}},
+		},
+		Clients: map[string]Policies{
+			"batch": {
+				Client: Policy{PerMinute: 100},
+				Tools:  map[string]Policy{"job_search": {PerMinute: 3}},
+			},
+		},
+	}, time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC))
+
+	for i := 0; i < 3; i++ {
+		if d := l.Allow("batch", "batch", "job_search"); !d.Allowed {
+			t.Fatalf("batch call %d refused: %+v", i+1, d)
+		}
+	}
+	if d := l.Allow("batch", "batch", "job_search"); d.Allowed {
+		t.Fatal("batch exceeded its own job_search policy")
+	}
+	l.Allow("alice", "alice", "profile_get")
+	if d := l.Allow("alice", "alice", "profile_get"); d.Allowed || d.Tool != "" {
+		t.Fatalf("alice's second call = %+v, want the default client policy to refuse", d)
+	}
+}
+
+func TestClientsSharingANameKeepTheirOwnCounters(t *testing.T) {
+	l, _ := newLimiter(t, Config{
+		Policies: Policies{Tools: map[string]Policy{"job_search": {PerMinute: 1}}},
+		Clients:  map[string]Policies{"alice": {Tools: map[string]Policy{"job_search": {PerMinute: 2}}}},
+	}, time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC))
+
+	// An API key and a token subject both named alice
+	for _, key := range []string{"apikey alice", "https://issuer alice"} {
+		for i := 0; i < 2; i++ {
+			if d := l.Allow(key, "alice", "job_search"); !d.Allowed {
+				t.Fatalf("%s call %d refused: %+v", key, i+1, d)
+			}
+		}
+		if d := l.Allow(key, "alice", "job_search"); d.Allowed {
+			t.Fatalf("%s exceeded alice's job_search policy", key)
+		}
+	}
+
+	if got := len(l.Usage("alice")); got != 4 {
+		t.Errorf("usage by name has %d rows, want both clients' total and job_search", got)
+	}
+	usage := l.Usage("apikey alice")
+	if len(usage) != 2 || usage[0].Name != "alice" || usage[0].Allowed != 2 {
+		t.Errorf("usage by key = %+v", usage)
+	}
+}
+
+func TestUsageCountsAllowedAndLimitedCalls(t *testing.T) {
+	l, _ := newLimiter(t, Config{Policies: Policies{
+		Tools: map[string]Policy{"job_search": {PerMinute: 1, Daily: 10}},
+	}}, time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC))
+
+	l.Allow("alice", "alice", "job_search")
+	l.Allow("alice", "alice", "job_search")
+	l.Allow("bob", "bob", "profile_get")
+
+	usage := l.Usage("alice")
+	if len(usage) != 2 {
+		t.Fatalf("alice has %d usage rows, want the total and job_search", len(usage))
+	}
+	total, search := usage[0], usage[1]
+	if total.Tool != "" || total.Allowed != 1 || total.Limited != 1 {
+		t.Errorf("total = %+v", total)
+	}
+	if search.Tool != "job_search" || search.Allowed != 1 || search.Limited != 1 || search.Today != 1 || search.DailyQuota != 10 {
+		t.Errorf("job_search = %+v", search)
+	}
+	if got := len(l.Usage("")); got != 4 {
+		t.Errorf("all clients have %d usage rows, want 4", got)
+	}
+}
+
+func TestConfigValidation(t *testing.T) {
+	if _, err := New(Config{Policies: Policies{Tools: map[string]Policy{"job_search": {PerMinute: -1}}}}); err == nil {
+		t.Error("negative rate accepted")
+	}
+	if _, err := New(Config{Clients: map[string]Policies{"ci": {Client: Policy{Daily: -5}}}}); err == nil {
+		t.Error("negative client quota accepted")
+	}
+	if _, err := New(DefaultConfig()); err != nil {
+		t.Errorf("default config: %v", err)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "limits.json")
+	data := `{"client": {"per_minute": 60}, "tools": {"job_search": {"per_minute": 5, "burst": 2, "daily": 100}}, "clients": {"ci": {"tools": {"*": {"per_minute": 1}}}}}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.Client.PerMinute != 60 || cfg.Tools["job_search"] != (Policy{PerMinute: 5, Burst: 2, Daily: 100}) {
+		t.Errorf("policies = %+v", cfg.Policies)
+	}
+	if cfg.Clients["ci"].Tools[AnyTool].PerMinute != 1 {
+		t.Errorf("clients = %+v", cfg.Clients)
+	}
+}